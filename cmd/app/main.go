@@ -4,8 +4,11 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"maestro/internal/config"
+	"maestro/internal/handlers"
+	"maestro/internal/service"
 	"maestro/internal/store"
 )
 
@@ -29,6 +32,23 @@ func main() {
 
 	log.Println("✅ DB initialisée")
 
+	// === JOBS ===
+	go service.NewTimeSlotService().RunEvery(time.Hour)           // best_time_slot + difficulty_success_rate
+	go service.NewReportService().RunSchedule(time.Hour)          // rapports hebdo / mensuels (report_dir)
+	go service.NewAchievementService().CheckAll()                 // succès mérités avant cette version
+	go service.NewSessionService().RunIdleSweep(10 * time.Minute) // sessions inactives → abandoned
+
+	// === ABONNEMENTS (bus d'événements) ===
+	unsubscribe := []func(){
+		handlers.SubscribeDashboardCache(),          // snapshot dashboard périmé à chaque écriture
+		service.NewAchievementService().Subscribe(), // reviews, fins de session, créations
+	}
+	defer func() {
+		for _, u := range unsubscribe {
+			u()
+		}
+	}()
+
 	// === ROUTES ===
 	log.Println("🔧 Configuration routes...")
 	mux := config.Routes()
@@ -6,14 +6,15 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"time"
 
+	"maestro/internal/domain/calendar"
 	"maestro/internal/store"
 
 	_ "modernc.org/sqlite"
 )
 
 // JSONExercise : Structure JSON source (FORMAT DATES YYYYMMDD)
+// Les dates de cycle de vie sont converties en Unix à l'insertion.
 type JSONExercise struct {
 	ID                int         `json:"id"`
 	Title             string      `json:"title"`
@@ -89,25 +90,31 @@ func main() {
 
 	// 4. Insert chaque exercice
 	for _, ex := range exercises {
+		// created_at est NOT NULL : pas de date inventée pour une source incomplète
+		if ex.CreatedAt == 0 {
+			log.Printf("❌ Exercice #%d (%s) ignoré : created_at manquant", ex.ID, ex.Title)
+			continue
+		}
+
 		// Serialize JSON fields
 		stepsJSON, _ := json.Marshal(ex.Steps)
 		completedJSON, _ := json.Marshal(ex.CompletedSteps)
 		visualsJSON, _ := json.Marshal(ex.ConceptualVisuals)
 
-		// Execute INSERT (les dates sont déjà en format YYYYMMDD depuis le JSON)
+		// Execute INSERT (échéances en YYYYMMDD, cycle de vie en Unix)
 		_, err := stmt.Exec(
 			ex.ID, ex.Title, ex.Description, ex.Domain, ex.Difficulty,
 			ex.Content, ex.Mnemonic, visualsJSON,
 			stepsJSON, completedJSON,
-			boolToInt(ex.Done),  // ✅ Conversion bool → int
-			ex.LastReviewedDate, // Déjà *int depuis JSON
-			ex.NextReviewDate,   // Déjà int depuis JSON
+			boolToInt(ex.Done), // ✅ Conversion bool → int
+			dateIntPtrToUnix(ex.LastReviewedDate),
+			ex.NextReviewDate, // Déjà int depuis JSON
 			ex.EaseFactor, ex.IntervalDays, ex.Repetitions,
 			ex.SkippedCount,
 			ex.LastSkippedDate,    // Déjà *int depuis JSON
 			boolToInt(ex.Deleted), // ✅ Conversion bool → int
-			ex.CreatedAt,          // Déjà int depuis JSON
-			ex.UpdatedAt,          // Déjà int depuis JSON
+			dateIntToUnix(ex.CreatedAt),
+			dateIntToUnix(max(ex.UpdatedAt, ex.CreatedAt)), // Jamais modifié → création
 		)
 		if err != nil {
 			log.Printf("❌ Erreur insert exercice #%d (%s): %v", ex.ID, ex.Title, err)
@@ -144,12 +151,21 @@ func boolToInt(b bool) int {
 	return 0
 }
 
-// toDateInt : Convertit time.Time en YYYYMMDD
-func toDateInt(t time.Time) int {
-	if t.IsZero() {
-		return 0
+// dateIntToUnix : YYYYMMDD → Unix (début du jour utilisateur, comme la
+// migration 1 du store) ; 0 → NULL
+func dateIntToUnix(dateInt int) sql.NullInt64 {
+	if dateInt == 0 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: calendar.Current().FromDayKey(dateInt).Unix(), Valid: true}
+}
+
+// dateIntPtrToUnix : Variante nullable (jamais révisé → NULL)
+func dateIntPtrToUnix(dateInt *int) sql.NullInt64 {
+	if dateInt == nil {
+		return sql.NullInt64{}
 	}
-	return t.Year()*10000 + int(t.Month())*100 + t.Day()
+	return dateIntToUnix(*dateInt)
 }
 
 // formatDateInt : YYYYMMDD → "2025-11-29"
@@ -187,7 +203,7 @@ func printStats(db *sql.DB) {
 	}
 
 	// Exercices dus aujourd'hui
-	today := toDateInt(time.Now())
+	today := calendar.Current().Today()
 	var dueToday int
 	db.QueryRow(`
 		SELECT COUNT(*) FROM exercises 
@@ -216,5 +232,5 @@ func printStats(db *sql.DB) {
 		log.Printf("📅 Prochaine révision : %s", formatDateInt(nextDate))
 	}
 
-	log.Println("\n═══════════════════════════════\n")
+	log.Print("\n═══════════════════════════════\n")
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/report"
+	"maestro/internal/service"
+	"maestro/internal/store"
+)
+
+// Génère les rapports de progression (HTML + Markdown) de la dernière
+// période terminée.
+// Usage : go run ./cmd/report -period weekly -out data/reports
+//
+//	go run ./cmd/report -schedule 1h   (dossier : -out ou réglage report_dir)
+func main() {
+	dbPath := flag.String("db", "data/maestro.db", "base SQLite")
+	period := flag.String("period", "both", "weekly | monthly | both")
+	date := flag.String("date", "", "jour de référence YYYY-MM-DD (défaut: aujourd'hui)")
+	out := flag.String("out", "", "dossier de sortie (défaut: réglage report_dir, sinon data/reports)")
+	schedule := flag.Duration("schedule", 0, "tourne en continu et génère les rapports manquants à cet intervalle")
+	flag.Parse()
+
+	// 1. Fréquences demandées
+	kinds := report.Kinds
+	if *period != "both" {
+		kind, err := report.ParseKind(*period)
+		if err != nil {
+			log.Fatalf("❌ -period invalide: %v", err)
+		}
+		kinds = []report.Kind{kind}
+	}
+
+	if err := store.InitDB(*dbPath); err != nil {
+		log.Fatalf("❌ Erreur init DB: %v", err)
+	}
+	defer store.CloseDB()
+
+	dir := *out
+	if dir == "" {
+		dir = store.GetSetting(store.SettingReportDir, "")
+	}
+	if dir == "" {
+		dir = "data/reports"
+	}
+
+	reports := service.NewReportService()
+
+	// 2. Mode planifié : rapports manquants à chaque passage
+	if *schedule > 0 {
+		log.Printf("📝 Rapports planifiés toutes les %v → %s", *schedule, dir)
+		for {
+			if err := reports.GenerateMissing(dir, kinds); err != nil {
+				log.Printf("❌ [Reports] %v", err)
+			}
+			time.Sleep(*schedule)
+		}
+	}
+
+	// 3. Génération ponctuelle
+	cal := calendar.Current()
+	ref := cal.Now()
+	if *date != "" {
+		t, err := cal.ParseDay(*date)
+		if err != nil {
+			log.Fatalf("❌ -date invalide: %v", err)
+		}
+		ref = t
+	}
+
+	for _, kind := range kinds {
+		started := time.Now()
+		r, err := reports.Build(kind, ref)
+		if err != nil {
+			log.Fatalf("❌ Erreur rapport %s: %v", kind, err)
+		}
+		paths, err := reports.WriteFiles(r, dir)
+		if err != nil {
+			log.Fatalf("❌ Erreur écriture: %v", err)
+		}
+		log.Printf("✅ %s %s : %d révisions, %d%% rétention → %v (%s)",
+			kind, r.Label, r.Reviews, r.RetentionRate, paths, time.Since(started).Round(time.Millisecond))
+	}
+}
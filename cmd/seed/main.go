@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/seed"
+	"maestro/internal/store"
+)
+
+// Génère une base synthétique volumineuse (benchmarks, tests de charge).
+// Usage : go run ./cmd/seed -db data/seed.db -seed 42 -until 2026-01-01
+func main() {
+	cfg := seed.DefaultConfig(time.Time{})
+
+	dbPath := flag.String("db", "data/seed.db", "fichier SQLite à créer")
+	force := flag.Bool("force", false, "écrase le fichier s'il existe")
+	until := flag.String("until", "", "fin de l'historique YYYY-MM-DD (défaut: aujourd'hui)")
+	flag.Uint64Var(&cfg.Seed, "seed", cfg.Seed, "graine du générateur")
+	flag.IntVar(&cfg.Exercises, "exercises", cfg.Exercises, "nombre d'exercices")
+	flag.IntVar(&cfg.Reviews, "reviews", cfg.Reviews, "nombre cible de révisions")
+	flag.IntVar(&cfg.Years, "years", cfg.Years, "années d'historique")
+	flag.Parse()
+
+	log.Println("🌱 Seed Maestro : génération de données synthétiques")
+
+	// 1. Fichier cible (jamais la vraie base par accident)
+	if _, err := os.Stat(*dbPath); err == nil {
+		if !*force {
+			log.Fatalf("❌ %s existe déjà (utilise -force pour écraser)", *dbPath)
+		}
+		for _, suffix := range []string{"", "-wal", "-shm"} {
+			os.Remove(*dbPath + suffix)
+		}
+	}
+
+	if err := store.InitDB(*dbPath); err != nil {
+		log.Fatalf("❌ Erreur init DB: %v", err)
+	}
+	defer store.CloseDB()
+
+	// 2. Fin de l'historique (fixe → base reproductible)
+	cal := calendar.Current()
+	cfg.Until = cal.TodayStart()
+	if *until != "" {
+		t, err := cal.ParseDay(*until)
+		if err != nil {
+			log.Fatalf("❌ -until invalide: %v", err)
+		}
+		cfg.Until = t
+	}
+
+	log.Printf("📦 %d exercices, ~%d révisions sur %d an(s), seed=%d, jusqu'au %s",
+		cfg.Exercises, cfg.Reviews, cfg.Years, cfg.Seed, cfg.Until.Format("2006-01-02"))
+
+	// 3. Génération
+	started := time.Now()
+	stats, err := seed.Generate(store.GetDB(), cal, cfg)
+	if err != nil {
+		log.Fatalf("❌ Erreur génération: %v", err)
+	}
+
+	log.Printf("✅ %d exercices, %d révisions, %d sessions sur %d jours (%s)",
+		stats.Exercises, stats.Reviews, stats.Sessions, stats.Days, time.Since(started).Round(time.Millisecond))
+}
@@ -16,6 +16,15 @@ func Routes() *http.ServeMux {
 	mux.HandleFunc("GET /", handlers.HandleDashboard)
 	mux.HandleFunc("GET /exercises", handlers.HandleExercisesPage)
 	mux.HandleFunc("GET /planner", handlers.HandlePlannerPage)
+	mux.HandleFunc("GET /achievements", handlers.HandleAchievementsPage)
+
+	// Fragments dashboard
+	mux.HandleFunc("GET /dashboard/retention", handlers.HandleRetentionCurve)
+	mux.HandleFunc("GET /metrics/cache", handlers.HandleCacheMetrics)
+
+	// Streak : jours de congé planifiés
+	mux.HandleFunc("POST /streak/freezes", handlers.HandleAddStreakFreeze)
+	mux.HandleFunc("POST /streak/freezes/{day}/delete", handlers.HandleDeleteStreakFreeze)
 
 	// ============================================
 	// GROUPE 2.5 : EXERCICES - CRÉATION/ÉDITION
@@ -45,9 +54,32 @@ func Routes() *http.ServeMux {
 	// GROUPE 3 : SESSIONS
 	// ============================================
 	mux.HandleFunc("GET /session/builder", handlers.HandleSessionBuilder)
-	mux.HandleFunc("GET /session/start", handlers.HandleStartSession)       // Démarre session
-	mux.HandleFunc("GET /session/complete", handlers.HandleSessionComplete) // Page fin
-	mux.HandleFunc("POST /session/{id}/stop", handlers.HandleStopSession)   // Arrête session
+	mux.HandleFunc("GET /session/start", handlers.HandleStartSession)        // Démarre session
+	mux.HandleFunc("GET /session/complete", handlers.HandleSessionComplete)  // Page fin
+	mux.HandleFunc("POST /session/{id}/stop", handlers.HandleStopSession)    // Arrête session
+	mux.HandleFunc("GET /session/{id}/resume", handlers.HandleResumeSession) // Reprend session interrompue
+
+	// Historique
+	mux.HandleFunc("GET /sessions", handlers.HandleSessionHistory)    // Liste paginée + filtres
+	mux.HandleFunc("GET /session/{id}", handlers.HandleSessionDetail) // Détail par exercice
+
+	// Pauses imposées
+	mux.HandleFunc("GET /session/{id}/break", handlers.HandleSessionBreak)
+	mux.HandleFunc("GET /session/{id}/break/timer", handlers.HandleBreakTimer) // Fragment compte à rebours
+	mux.HandleFunc("POST /session/{id}/break/end", handlers.HandleEndBreak)
+	mux.HandleFunc("POST /session/{id}/break/skip", handlers.HandleSkipBreak)
+
+	// Minuteur (mode concentration)
+	mux.HandleFunc("GET /session/{id}/timer", handlers.HandleSessionTimer)      // Fragment temps restant
+	mux.HandleFunc("POST /session/{id}/timeout", handlers.HandleSessionTimeout) // Fin à l'échéance
+
+	// Templates de session
+	mux.HandleFunc("GET /templates", handlers.HandleTemplatesPage)
+	mux.HandleFunc("GET /templates/new", handlers.HandleTemplateNew)
+	mux.HandleFunc("POST /templates", handlers.HandleTemplateCreate)
+	mux.HandleFunc("GET /templates/{id}/edit", handlers.HandleTemplateEdit)
+	mux.HandleFunc("POST /templates/{id}", handlers.HandleTemplateUpdate)
+	mux.HandleFunc("POST /templates/{id}/delete", handlers.HandleTemplateDelete)
 
 	// ============================================
 	// GROUPE 4 : PLANNER (Calendrier)
@@ -56,6 +88,12 @@ func Routes() *http.ServeMux {
 	mux.HandleFunc("GET /planner/week", handlers.HandlePlannerWeek)
 	mux.HandleFunc("GET /planner/month", handlers.HandlePlannerMonth)
 
+	// ============================================
+	// GROUPE 4.5 : RÉGLAGES
+	// ============================================
+	mux.HandleFunc("GET /settings", handlers.HandleSettingsPage)
+	mux.HandleFunc("POST /settings", handlers.HandleSettingsUpdate)
+
 	// ============================================
 	// GROUPE 5 : ASSETS STATIQUES
 	// ============================================
@@ -0,0 +1,116 @@
+package achievement
+
+import "strings"
+
+// ============================================
+// SUCCÈS (Règles Métier)
+// ============================================
+
+// ID : Identifiant stable d'un succès (clé persistée)
+type ID string
+
+const (
+	FirstReview  ID = "first_review"
+	Reviews100   ID = "reviews_100"
+	Reviews1000  ID = "reviews_1000"
+	Streak7      ID = "streak_7"
+	Streak30     ID = "streak_30"
+	Streak100    ID = "streak_100"
+	DomainMature ID = "domain_mature" // Par domaine ("domain_mature:Go")
+	PerfectDeep  ID = "perfect_deep"
+	Library10    ID = "library_10"
+	Library50    ID = "library_50"
+)
+
+// Definition : Succès affiché (Scoped = débloqué une fois par domaine)
+type Definition struct {
+	ID          ID
+	Title       string
+	Description string
+	Icon        string
+	Scoped      bool
+}
+
+// Catalog : Tous les succès, dans l'ordre d'affichage
+var Catalog = []Definition{
+	{FirstReview, "Premier pas", "Première révision enregistrée", "🌱", false},
+	{Reviews100, "Centurion", "100 révisions", "💯", false},
+	{Reviews1000, "Millier", "1000 révisions", "🏛", false},
+	{Streak7, "Semaine pleine", "Streak de 7 jours", "🔥", false},
+	{Streak30, "Mois de fer", "Streak de 30 jours", "⚡", false},
+	{Streak100, "Centenaire", "Streak de 100 jours", "👑", false},
+	{DomainMature, "Domaine mature", "Tous les exercices d'un domaine (≥ 3) à l'état mature ou mieux", "🌳", true},
+	{PerfectDeep, "Session parfaite", "Session deep complète, chaque réponse Good ou Easy", "💎", false},
+	{Library10, "Bibliothécaire", "10 exercices créés", "📚", false},
+	{Library50, "Archiviste", "50 exercices créés", "🗄", false},
+}
+
+// Seuils
+const (
+	MinMatureDomainSize = 3 // Un domaine de 1-2 exercices ne compte pas
+	MinPerfectSession   = 3 // Exercices minimum pour une session parfaite
+	PerfectQuality      = 2 // Good
+)
+
+var (
+	reviewMilestones  = []milestone{{1, FirstReview}, {100, Reviews100}, {1000, Reviews1000}}
+	streakMilestones  = []milestone{{7, Streak7}, {30, Streak30}, {100, Streak100}}
+	libraryMilestones = []milestone{{10, Library10}, {50, Library50}}
+)
+
+type milestone struct {
+	threshold int
+	id        ID
+}
+
+func reached(milestones []milestone, value int) []ID {
+	var ids []ID
+	for _, m := range milestones {
+		if value >= m.threshold {
+			ids = append(ids, m.id)
+		}
+	}
+	return ids
+}
+
+// ReviewMilestones : Succès mérités pour un total de révisions
+func ReviewMilestones(total int) []ID { return reached(reviewMilestones, total) }
+
+// StreakMilestones : Succès mérités pour un streak (courant ou record)
+func StreakMilestones(days int) []ID { return reached(streakMilestones, days) }
+
+// LibraryMilestones : Succès mérités pour un nombre d'exercices
+func LibraryMilestones(count int) []ID { return reached(libraryMilestones, count) }
+
+// DomainFullyMature : Tous les exercices du domaine sont mature ou mastered
+func DomainFullyMature(total, matureOrBetter int) bool {
+	return total >= MinMatureDomainSize && matureOrBetter == total
+}
+
+// PerfectSession : Session deep où tout ce qui était prévu a été répondu Good/Easy
+func PerfectSession(mode string, planned, completed, good int) bool {
+	return mode == "deep" && planned >= MinPerfectSession && completed == planned && good == planned
+}
+
+// ============================================
+// CLÉS (succès par domaine)
+// ============================================
+
+// Key : Clé persistée ("streak_30", "domain_mature:Go")
+func Key(id ID, scope string) string {
+	if scope == "" {
+		return string(id)
+	}
+	return string(id) + ":" + scope
+}
+
+// Lookup : Définition et portée d'une clé persistée
+func Lookup(key string) (Definition, string, bool) {
+	id, scope, _ := strings.Cut(key, ":")
+	for _, d := range Catalog {
+		if d.ID == ID(id) {
+			return d, scope, true
+		}
+	}
+	return Definition{}, "", false
+}
@@ -0,0 +1,54 @@
+package achievement
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMilestones(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []ID
+		want []ID
+	}{
+		{"aucune review", ReviewMilestones(0), nil},
+		{"99 reviews", ReviewMilestones(99), []ID{FirstReview}},
+		{"100 reviews", ReviewMilestones(100), []ID{FirstReview, Reviews100}},
+		{"streak 30", StreakMilestones(30), []ID{Streak7, Streak30}},
+		{"9 exercices", LibraryMilestones(9), nil},
+		{"50 exercices", LibraryMilestones(50), []ID{Library10, Library50}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.Equal(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDomainAndSessionRules(t *testing.T) {
+	if DomainFullyMature(2, 2) {
+		t.Error("domaine de 2 exercices ne doit pas compter")
+	}
+	if !DomainFullyMature(3, 3) || DomainFullyMature(4, 3) {
+		t.Error("domaine mature = tous les exercices mature ou mieux")
+	}
+
+	if !PerfectSession("deep", 4, 4, 4) {
+		t.Error("session deep complète et parfaite refusée")
+	}
+	if PerfectSession("standard", 4, 4, 4) || PerfectSession("deep", 4, 3, 3) || PerfectSession("deep", 4, 4, 3) {
+		t.Error("session imparfaite acceptée")
+	}
+}
+
+func TestKeyLookup(t *testing.T) {
+	def, scope, ok := Lookup(Key(DomainMature, "Go"))
+	if !ok || def.ID != DomainMature || scope != "Go" {
+		t.Errorf("Lookup(domain_mature:Go) = %v %q %v", def.ID, scope, ok)
+	}
+	if _, _, ok := Lookup("unknown"); ok {
+		t.Error("clé inconnue acceptée")
+	}
+}
@@ -0,0 +1,178 @@
+// internal/domain/calendar/calendar.go
+package calendar
+
+import (
+	"sync"
+	"time"
+
+	"maestro/internal/domain/clock"
+)
+
+// ============================================
+// JOUR UTILISATEUR (Fuseau + heure de bascule)
+// ============================================
+
+// Calendar : Définit ce qu'est "un jour" pour l'utilisateur.
+// Un jour commence à RolloverHour dans Location (ex: 4h → une révision
+// à 1h du matin compte encore pour la veille).
+// Le calendrier porte aussi l'horloge de l'application (Clock).
+type Calendar struct {
+	Location     *time.Location
+	RolloverHour int         // 0-23
+	Clock        clock.Clock // nil → horloge système
+}
+
+// New : Construit un calendrier validé
+func New(loc *time.Location, rolloverHour int) (Calendar, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	if rolloverHour < 0 || rolloverHour > 23 {
+		return Calendar{}, ErrInvalidRolloverHour
+	}
+	return Calendar{Location: loc, RolloverHour: rolloverHour}, nil
+}
+
+func (c Calendar) loc() *time.Location {
+	if c.Location == nil {
+		return time.Local
+	}
+	return c.Location
+}
+
+func (c Calendar) clock() clock.Clock {
+	if c.Clock == nil {
+		return clock.System{}
+	}
+	return c.Clock
+}
+
+// WithClock : Copie du calendrier utilisant une autre horloge
+func (c Calendar) WithClock(clk clock.Clock) Calendar {
+	c.Clock = clk
+	return c
+}
+
+// DayStart : Instant de début du jour utilisateur contenant t
+func (c Calendar) DayStart(t time.Time) time.Time {
+	lt := t.In(c.loc())
+	y, m, d := lt.Date()
+	if lt.Hour() < c.RolloverHour {
+		d--
+	}
+	return time.Date(y, m, d, c.RolloverHour, 0, 0, 0, c.loc())
+}
+
+// DayKey : Clé YYYYMMDD du jour utilisateur contenant t (0 si t zéro)
+func (c Calendar) DayKey(t time.Time) int {
+	if t.IsZero() {
+		return 0
+	}
+	y, m, d := c.DayStart(t).Date()
+	return y*10000 + int(m)*100 + d
+}
+
+// FromDayKey : Début du jour utilisateur correspondant à une clé YYYYMMDD
+func (c Calendar) FromDayKey(key int) time.Time {
+	if key == 0 {
+		return time.Time{}
+	}
+	return c.Date(key/10000, time.Month((key%10000)/100), key%100)
+}
+
+// Date : Début du jour utilisateur pour une date civile
+func (c Calendar) Date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, c.RolloverHour, 0, 0, 0, c.loc())
+}
+
+// AddDays : Ajoute N jours à une clé YYYYMMDD
+func (c Calendar) AddDays(key, days int) int {
+	return c.DayKey(c.FromDayKey(key).AddDate(0, 0, days))
+}
+
+// WeekStart : Début du lundi de la semaine utilisateur contenant t
+func (c Calendar) WeekStart(t time.Time) time.Time {
+	day := c.DayStart(t)
+	offset := (int(day.Weekday()) + 6) % 7 // Lundi = 0
+	return c.Date(day.Year(), day.Month(), day.Day()-offset)
+}
+
+// SameDay : Deux instants dans le même jour utilisateur ?
+func (c Calendar) SameDay(a, b time.Time) bool {
+	return c.DayKey(a) == c.DayKey(b)
+}
+
+// Now : Instant courant exprimé dans le fuseau utilisateur
+// (Calendar satisfait donc clock.Clock)
+func (c Calendar) Now() time.Time {
+	return c.In(c.clock().Now())
+}
+
+// In : t exprimé dans le fuseau utilisateur (fuseau serveur si non défini)
+func (c Calendar) In(t time.Time) time.Time {
+	return t.In(c.loc())
+}
+
+// Today : Clé YYYYMMDD du jour utilisateur courant
+func (c Calendar) Today() int {
+	return c.DayKey(c.Now())
+}
+
+// TodayStart : Début du jour utilisateur courant
+func (c Calendar) TodayStart() time.Time {
+	return c.DayStart(c.Now())
+}
+
+// ParseDay : "2006-01-02" → début du jour utilisateur
+func (c Calendar) ParseDay(s string) (time.Time, error) {
+	t, err := time.ParseInLocation("2006-01-02", s, c.loc())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return c.Date(t.Year(), t.Month(), t.Day()), nil
+}
+
+// ============================================
+// CALENDRIER COURANT (configuré depuis settings)
+// ============================================
+
+var (
+	mu      sync.RWMutex
+	current = Calendar{Location: time.Local}
+)
+
+// Current : Calendrier actif de l'application
+func Current() Calendar {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Configure : Remplace le calendrier actif (garde l'horloge si c.Clock est nil)
+func Configure(c Calendar) {
+	mu.Lock()
+	defer mu.Unlock()
+	if c.Clock == nil {
+		c.Clock = current.Clock
+	}
+	current = c
+}
+
+// SetClock : Remplace l'horloge du calendrier actif
+func SetClock(clk clock.Clock) {
+	mu.Lock()
+	defer mu.Unlock()
+	current.Clock = clk
+}
+
+// LoadLocation : Résout un nom de fuseau ("" ou "Local" → fuseau serveur)
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrUnknownTimezone
+	}
+	return loc, nil
+}
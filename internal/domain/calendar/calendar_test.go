@@ -0,0 +1,98 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"maestro/internal/domain/clock"
+)
+
+func TestDayKeyAndAddDays(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("timezone indisponible: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		rollover int
+		t        time.Time
+		wantKey  int
+		add      int
+		wantNext int
+	}{
+		{"midi", 0, time.Date(2026, 7, 14, 12, 0, 0, 0, paris), 20260714, 1, 20260715},
+		{"avant bascule", 4, time.Date(2026, 7, 14, 3, 59, 0, 0, paris), 20260713, 1, 20260714},
+		{"heure d'été", 0, time.Date(2026, 3, 29, 3, 0, 0, 0, paris), 20260329, 1, 20260330},
+		{"heure d'hiver", 3, time.Date(2026, 10, 25, 2, 30, 0, 0, paris), 20261024, 1, 20261025},
+		{"fin d'année", 0, time.Date(2026, 12, 31, 23, 59, 0, 0, paris), 20261231, 1, 20270101},
+		{"recul d'année", 0, time.Date(2027, 1, 1, 0, 0, 0, 0, paris), 20270101, -1, 20261231},
+		{"bissextile", 0, time.Date(2028, 2, 28, 10, 0, 0, 0, paris), 20280228, 1, 20280229},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := Calendar{Location: paris, RolloverHour: tt.rollover}
+			key := cal.DayKey(tt.t)
+			if key != tt.wantKey {
+				t.Fatalf("DayKey = %d, want %d", key, tt.wantKey)
+			}
+			if got := cal.AddDays(key, tt.add); got != tt.wantNext {
+				t.Errorf("AddDays(%d, %d) = %d, want %d", key, tt.add, got, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestTodayUsesClock(t *testing.T) {
+	clk := clock.NewFixed(time.Date(2026, 12, 31, 22, 30, 0, 0, time.UTC))
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("timezone indisponible: %v", err)
+	}
+	cal := Calendar{Location: tokyo, Clock: clk}
+
+	if got := cal.Today(); got != 20270101 {
+		t.Errorf("Today = %d, want 20270101", got)
+	}
+
+	clk.Advance(-24 * time.Hour)
+	if got := cal.Today(); got != 20261231 {
+		t.Errorf("Today après recul = %d, want 20261231", got)
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	cal := Calendar{Location: time.UTC, RolloverHour: 4}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{"mercredi", time.Date(2026, 7, 15, 12, 0, 0, 0, time.UTC), time.Date(2026, 7, 13, 4, 0, 0, 0, time.UTC)},
+		{"lundi", time.Date(2026, 7, 13, 9, 0, 0, 0, time.UTC), time.Date(2026, 7, 13, 4, 0, 0, 0, time.UTC)},
+		{"lundi avant bascule", time.Date(2026, 7, 13, 3, 0, 0, 0, time.UTC), time.Date(2026, 7, 6, 4, 0, 0, 0, time.UTC)},
+		{"dimanche", time.Date(2026, 7, 19, 23, 0, 0, 0, time.UTC), time.Date(2026, 7, 13, 4, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cal.WeekStart(tt.t); !got.Equal(tt.want) {
+				t.Errorf("WeekStart = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInZeroValue(t *testing.T) {
+	at := time.Date(2026, 7, 15, 12, 0, 0, 0, time.UTC)
+
+	// Calendrier zéro : fuseau serveur, pas de panique
+	if got := (Calendar{}).In(at); got.Location() != time.Local || !got.Equal(at) {
+		t.Errorf("Calendar{}.In = %v, want %v en heure locale", got, at)
+	}
+	if got := (Calendar{Location: time.UTC}).In(at); got.Location() != time.UTC {
+		t.Errorf("In = %v, want UTC", got)
+	}
+}
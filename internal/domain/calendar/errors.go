@@ -0,0 +1,8 @@
+package calendar
+
+import "errors"
+
+var (
+	ErrInvalidRolloverHour = errors.New("rollover hour must be 0-23")
+	ErrUnknownTimezone     = errors.New("unknown timezone")
+)
@@ -0,0 +1,56 @@
+// internal/domain/clock/clock.go
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// ============================================
+// HORLOGE INJECTABLE (Domain, Service, Store)
+// ============================================
+
+// Clock : Source de "maintenant" (remplaçable en test)
+type Clock interface {
+	Now() time.Time
+}
+
+// System : Horloge réelle du serveur
+type System struct{}
+
+// Now : Instant courant
+func (System) Now() time.Time {
+	return time.Now()
+}
+
+// Fixed : Horloge figée, avançable à la main (tests, seed, rapports)
+type Fixed struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+// NewFixed : Horloge figée sur t
+func NewFixed(t time.Time) *Fixed {
+	return &Fixed{t: t}
+}
+
+// Now : Instant figé
+func (f *Fixed) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.t
+}
+
+// Set : Repositionne l'horloge
+func (f *Fixed) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.t = t
+}
+
+// Advance : Avance l'horloge de d
+func (f *Fixed) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.t = f.t.Add(d)
+}
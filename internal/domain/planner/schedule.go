@@ -4,29 +4,20 @@ import (
 	"sort"
 	"time"
 
+	"maestro/internal/domain/calendar"
 	"maestro/internal/models"
 )
 
-// normalizeDate normalise une date à minuit UTC
-func normalizeDate(t time.Time) time.Time {
-	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
-}
-
-// IsSameDay compare deux dates ignorants l'heure
-func IsSameDay(a, b time.Time) bool {
-	return normalizeDate(a).Equal(normalizeDate(b))
-}
-
-// GetReviewsForDate filtre les exercices dus à une date donnée
-func GetReviewsForDate(exercises []models.Exercise, date time.Time) []models.Exercise {
+// GetReviewsForDate filtre les exercices dus à une date donnée (jour utilisateur)
+func GetReviewsForDate(cal calendar.Calendar, exercises []models.Exercise, date time.Time) []models.Exercise {
 	var reviews []models.Exercise
-	targetDate := normalizeDate(date)
+	targetDay := cal.DayKey(date)
 
 	for _, ex := range exercises {
 		if ex.NextReviewAt.IsZero() {
 			continue
 		}
-		if IsSameDay(ex.NextReviewAt, targetDate) {
+		if cal.DayKey(ex.NextReviewAt) == targetDay {
 			reviews = append(reviews, ex)
 		}
 	}
@@ -39,16 +30,16 @@ func GetReviewsForDate(exercises []models.Exercise, date time.Time) []models.Exe
 	return reviews
 }
 
-// GetOverdueReviews filtre les exercices en retard (non faits et date passée)
-func GetOverdueReviews(exercises []models.Exercise) []models.Exercise {
+// GetOverdueReviews filtre les exercices en retard (non faits et jour passé)
+func GetOverdueReviews(cal calendar.Calendar, exercises []models.Exercise) []models.Exercise {
 	var overdue []models.Exercise
-	now := time.Now()
+	today := cal.Today()
 
 	for _, ex := range exercises {
 		if ex.NextReviewAt.IsZero() {
 			continue
 		}
-		if ex.NextReviewAt.Before(now) && !ex.Done {
+		if cal.DayKey(ex.NextReviewAt) < today && !ex.Done {
 			overdue = append(overdue, ex)
 		}
 	}
@@ -62,15 +53,15 @@ func GetOverdueReviews(exercises []models.Exercise) []models.Exercise {
 }
 
 // GetUpcomingReviews récupère les prochaines N révisions après aujourd'hui
-func GetUpcomingReviews(exercises []models.Exercise, limit int) []models.Exercise {
+func GetUpcomingReviews(cal calendar.Calendar, exercises []models.Exercise, limit int) []models.Exercise {
 	var upcoming []models.Exercise
-	now := time.Now()
+	today := cal.Today()
 
 	for _, ex := range exercises {
 		if ex.NextReviewAt.IsZero() {
 			continue
 		}
-		if ex.NextReviewAt.After(now) {
+		if cal.DayKey(ex.NextReviewAt) > today {
 			upcoming = append(upcoming, ex)
 		}
 	}
@@ -0,0 +1,127 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/clock"
+	"maestro/internal/models"
+)
+
+func parisCalendar(t *testing.T, now time.Time) calendar.Calendar {
+	t.Helper()
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("timezone indisponible: %v", err)
+	}
+	return calendar.Calendar{Location: paris, Clock: clock.NewFixed(now.In(paris))}
+}
+
+func TestPlannerFiltering(t *testing.T) {
+	tests := []struct {
+		name         string
+		now          time.Time
+		exercises    []models.Exercise
+		wantOverdue  []int
+		wantUpcoming []int
+	}{
+		{
+			name: "jour standard",
+			now:  time.Date(2026, 5, 20, 10, 0, 0, 0, time.UTC),
+			exercises: []models.Exercise{
+				{ID: 1, NextReviewAt: time.Date(2026, 5, 18, 8, 0, 0, 0, time.UTC)},
+				{ID: 2, NextReviewAt: time.Date(2026, 5, 20, 20, 0, 0, 0, time.UTC)},
+				{ID: 3, NextReviewAt: time.Date(2026, 5, 22, 8, 0, 0, 0, time.UTC)},
+				{ID: 4, NextReviewAt: time.Date(2026, 5, 17, 8, 0, 0, 0, time.UTC), Done: true},
+				{ID: 5},
+			},
+			wantOverdue:  []int{1},
+			wantUpcoming: []int{3},
+		},
+		{
+			name: "minuit Paris ≠ minuit UTC",
+			// 23h30 UTC le 20 = 1h30 le 21 à Paris
+			now: time.Date(2026, 5, 20, 23, 30, 0, 0, time.UTC),
+			exercises: []models.Exercise{
+				{ID: 1, NextReviewAt: time.Date(2026, 5, 20, 21, 0, 0, 0, time.UTC)},  // 23h Paris le 20
+				{ID: 2, NextReviewAt: time.Date(2026, 5, 20, 22, 30, 0, 0, time.UTC)}, // 0h30 Paris le 21
+				{ID: 3, NextReviewAt: time.Date(2026, 5, 21, 22, 30, 0, 0, time.UTC)}, // 0h30 Paris le 22
+			},
+			wantOverdue:  []int{1},
+			wantUpcoming: []int{3},
+		},
+		{
+			name: "passage à l'heure d'été",
+			now:  time.Date(2026, 3, 29, 1, 30, 0, 0, time.UTC), // 3h30 CEST
+			exercises: []models.Exercise{
+				{ID: 1, NextReviewAt: time.Date(2026, 3, 28, 22, 30, 0, 0, time.UTC)}, // 23h30 CET le 28
+				{ID: 2, NextReviewAt: time.Date(2026, 3, 28, 23, 30, 0, 0, time.UTC)}, // 0h30 CET le 29
+				{ID: 3, NextReviewAt: time.Date(2026, 3, 29, 22, 30, 0, 0, time.UTC)}, // 0h30 CEST le 30
+			},
+			wantOverdue:  []int{1},
+			wantUpcoming: []int{3},
+		},
+		{
+			name: "changement d'année",
+			now:  time.Date(2027, 1, 1, 8, 0, 0, 0, time.UTC),
+			exercises: []models.Exercise{
+				{ID: 1, NextReviewAt: time.Date(2026, 12, 30, 8, 0, 0, 0, time.UTC)},
+				{ID: 2, NextReviewAt: time.Date(2026, 12, 31, 22, 59, 0, 0, time.UTC)}, // 23h59 Paris le 31
+				{ID: 3, NextReviewAt: time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC)},  // 0h Paris le 1er
+				{ID: 4, NextReviewAt: time.Date(2027, 1, 2, 8, 0, 0, 0, time.UTC)},
+			},
+			wantOverdue:  []int{1, 2},
+			wantUpcoming: []int{4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := parisCalendar(t, tt.now)
+			assertIDs(t, "overdue", GetOverdueReviews(cal, tt.exercises), tt.wantOverdue)
+			assertIDs(t, "upcoming", GetUpcomingReviews(cal, tt.exercises, 10), tt.wantUpcoming)
+		})
+	}
+}
+
+func TestGetUpcomingReviewsLimit(t *testing.T) {
+	now := time.Date(2026, 8, 1, 10, 0, 0, 0, time.UTC)
+	cal := parisCalendar(t, now)
+
+	var exercises []models.Exercise
+	for i := 5; i >= 1; i-- {
+		exercises = append(exercises, models.Exercise{ID: i, NextReviewAt: now.AddDate(0, 0, i)})
+	}
+
+	assertIDs(t, "upcoming", GetUpcomingReviews(cal, exercises, 3), []int{1, 2, 3})
+}
+
+func TestGetReviewsForDate(t *testing.T) {
+	now := time.Date(2026, 10, 24, 12, 0, 0, 0, time.UTC)
+	cal := parisCalendar(t, now)
+	// 25 octobre 2026 : journée de 25h à Paris (retour à l'heure d'hiver)
+	day := cal.Date(2026, time.October, 25)
+
+	exercises := []models.Exercise{
+		{ID: 1, Difficulty: 1, NextReviewAt: time.Date(2026, 10, 24, 22, 0, 0, 0, time.UTC)},  // 0h CEST le 25
+		{ID: 2, Difficulty: 3, NextReviewAt: time.Date(2026, 10, 25, 22, 59, 0, 0, time.UTC)}, // 23h59 CET le 25
+		{ID: 3, Difficulty: 2, NextReviewAt: time.Date(2026, 10, 25, 23, 0, 0, 0, time.UTC)},  // 0h CET le 26
+		{ID: 4, Difficulty: 4, NextReviewAt: time.Date(2026, 10, 24, 21, 59, 0, 0, time.UTC)}, // 23h59 CEST le 24
+		{ID: 5, Difficulty: 4},
+	}
+
+	assertIDs(t, "reviews", GetReviewsForDate(cal, exercises, day), []int{2, 1})
+}
+
+func assertIDs(t *testing.T, label string, got []models.Exercise, want []int) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: %d exercice(s), want %v", label, len(got), want)
+	}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Fatalf("%s[%d] = %d, want %d", label, i, got[i].ID, id)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+package report
+
+import "errors"
+
+var (
+	ErrUnknownKind = errors.New("report period must be weekly or monthly")
+)
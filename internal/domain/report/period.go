@@ -0,0 +1,79 @@
+// internal/domain/report/period.go
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"maestro/internal/domain/calendar"
+)
+
+// ============================================
+// PÉRIODES DE RAPPORT (Règles Métier)
+// ============================================
+
+// Kind : Fréquence du rapport
+type Kind string
+
+const (
+	Weekly  Kind = "weekly"
+	Monthly Kind = "monthly"
+)
+
+// Kinds : Fréquences supportées (ordre d'exécution)
+var Kinds = []Kind{Weekly, Monthly}
+
+// ParseKind : "weekly" | "monthly"
+func ParseKind(s string) (Kind, error) {
+	switch Kind(s) {
+	case Weekly, Monthly:
+		return Kind(s), nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownKind, s)
+}
+
+// Period : Intervalle de jours utilisateur [From, To)
+type Period struct {
+	Kind Kind
+	From time.Time // Début du premier jour inclus
+	To   time.Time // Début du premier jour exclu
+}
+
+// LastCompleted : Dernière période terminée avant le jour de ref
+// (semaine ISO lundi → lundi, mois calendaire)
+func LastCompleted(cal calendar.Calendar, kind Kind, ref time.Time) Period {
+	day := cal.DayStart(ref)
+
+	switch kind {
+	case Monthly:
+		to := cal.Date(day.Year(), day.Month(), 1)
+		return Period{Kind: kind, From: cal.Date(to.Year(), to.Month()-1, 1), To: to}
+	default:
+		offset := (int(day.Weekday()) + 6) % 7 // Lundi = 0
+		to := cal.Date(day.Year(), day.Month(), day.Day()-offset)
+		return Period{Kind: kind, From: cal.Date(to.Year(), to.Month(), to.Day()-7), To: to}
+	}
+}
+
+// Previous : Période de même type juste avant (comparaison)
+func (p Period) Previous(cal calendar.Calendar) Period {
+	return LastCompleted(cal, p.Kind, p.From)
+}
+
+// Days : Nombre de jours utilisateur couverts
+func (p Period) Days(cal calendar.Calendar) int {
+	n := 0
+	for d := cal.DayKey(p.From); d < cal.DayKey(p.To); d = cal.AddDays(d, 1) {
+		n++
+	}
+	return n
+}
+
+// Label : "2026-W15" / "2026-03" (noms de fichiers, titres)
+func (p Period) Label() string {
+	if p.Kind == Monthly {
+		return p.From.Format("2006-01")
+	}
+	year, week := p.From.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
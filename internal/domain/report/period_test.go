@@ -0,0 +1,79 @@
+package report
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"maestro/internal/domain/calendar"
+)
+
+func TestLastCompleted(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("timezone indisponible: %v", err)
+	}
+	cal := calendar.Calendar{Location: paris, RolloverHour: 4}
+
+	tests := []struct {
+		name     string
+		kind     Kind
+		ref      time.Time
+		from, to string
+		label    string
+		days     int
+	}{
+		{"semaine en cours", Weekly, time.Date(2026, 4, 15, 12, 0, 0, 0, paris), "2026-04-06", "2026-04-13", "2026-W15", 7},
+		{"lundi", Weekly, time.Date(2026, 4, 13, 12, 0, 0, 0, paris), "2026-04-06", "2026-04-13", "2026-W15", 7},
+		{"lundi avant bascule", Weekly, time.Date(2026, 4, 13, 3, 0, 0, 0, paris), "2026-03-30", "2026-04-06", "2026-W14", 7},
+		{"semaine à cheval sur l'année", Weekly, time.Date(2026, 1, 7, 12, 0, 0, 0, paris), "2025-12-29", "2026-01-05", "2026-W01", 7},
+		{"semaine DST", Weekly, time.Date(2026, 4, 1, 12, 0, 0, 0, paris), "2026-03-23", "2026-03-30", "2026-W13", 7},
+		{"mois", Monthly, time.Date(2026, 3, 15, 12, 0, 0, 0, paris), "2026-02-01", "2026-03-01", "2026-02", 28},
+		{"janvier → décembre", Monthly, time.Date(2026, 1, 1, 12, 0, 0, 0, paris), "2025-12-01", "2026-01-01", "2025-12", 31},
+		{"1er du mois avant bascule", Monthly, time.Date(2026, 4, 1, 2, 0, 0, 0, paris), "2026-02-01", "2026-03-01", "2026-02", 28},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := LastCompleted(cal, tt.kind, tt.ref)
+			if got := p.From.Format("2006-01-02"); got != tt.from {
+				t.Errorf("From = %s, want %s", got, tt.from)
+			}
+			if got := p.To.Format("2006-01-02"); got != tt.to {
+				t.Errorf("To = %s, want %s", got, tt.to)
+			}
+			if p.From.Hour() != 4 || p.To.Hour() != 4 {
+				t.Errorf("bornes hors bascule: %v → %v", p.From, p.To)
+			}
+			if got := p.Label(); got != tt.label {
+				t.Errorf("Label = %s, want %s", got, tt.label)
+			}
+			if got := p.Days(cal); got != tt.days {
+				t.Errorf("Days = %d, want %d", got, tt.days)
+			}
+		})
+	}
+}
+
+func TestPrevious(t *testing.T) {
+	cal := calendar.Calendar{Location: time.UTC}
+
+	week := LastCompleted(cal, Weekly, time.Date(2026, 4, 15, 12, 0, 0, 0, time.UTC))
+	if prev := week.Previous(cal); !prev.To.Equal(week.From) || prev.Label() != "2026-W14" {
+		t.Errorf("semaine précédente = %+v", prev)
+	}
+
+	month := LastCompleted(cal, Monthly, time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC))
+	if prev := month.Previous(cal); !prev.To.Equal(month.From) || prev.Label() != "2026-01" {
+		t.Errorf("mois précédent = %+v", prev)
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	if k, err := ParseKind("monthly"); err != nil || k != Monthly {
+		t.Errorf("ParseKind(monthly) = %q, %v", k, err)
+	}
+	if _, err := ParseKind("daily"); !errors.Is(err, ErrUnknownKind) {
+		t.Errorf("ParseKind(daily) err = %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+// internal/domain/session/breaks.go
+package session
+
+import "time"
+
+// ============================================
+// PAUSES IMPOSÉES
+// ============================================
+
+// BreakRemaining : Temps de pause restant (0 = pause terminée)
+func BreakRemaining(startedAt time.Time, planned time.Duration, now time.Time) time.Duration {
+	remaining := startedAt.Add(planned).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining.Round(time.Second)
+}
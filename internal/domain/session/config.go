@@ -2,64 +2,143 @@
 package session
 
 import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"maestro/internal/models"
 )
 
 // ============================================
-// SESSION CONFIGURATION (Règles Métier)
+// TEMPLATES DE SESSION (Règles Métier)
 // ============================================
 
-// Config : Configuration d'une session par niveau d'énergie
+// Config : Template de session (table session_templates). Les trois
+// templates intégrés (micro / standard / deep) correspondent aux niveaux
+// d'énergie ; l'utilisateur peut en créer d'autres.
 type Config struct {
+	ID            int64
+	Mode          string // Identifiant unique (sessions.mode)
+	Name          string
 	Level         models.EnergyLevel
-	Mode          string
 	Duration      time.Duration
 	MaxExercises  int
+	BreakEvery    int // Pause tous les N exercices complétés (0 = jamais)
 	BreakSchedule []time.Duration
+	Ordering      Ordering
+	Domains       []string // Filtres par défaut (vide = tous)
+	MinDifficulty int
+	MaxDifficulty int
+	NewRatio      int // RatioAuto = réglage new_card_ratio
 	Description   string
+	Builtin       bool // Seedé, non supprimable
 }
 
-// Configs : Map des configurations par niveau d'énergie
-var Configs = map[models.EnergyLevel]Config{
-	models.EnergyLow: {
-		Level:         models.EnergyLow,
-		Mode:          "micro",
-		Duration:      15 * time.Minute,
-		MaxExercises:  2, // ✅ CORRIGÉ (était 3)
-		BreakSchedule: []time.Duration{5 * time.Minute},
-		Description:   "Session courte (1-2 exos, 15min)",
-	},
-	models.EnergyMedium: {
-		Level:         models.EnergyMedium,
-		Mode:          "standard",
-		Duration:      30 * time.Minute,
-		MaxExercises:  4, // ✅ CORRIGÉ (était 5)
-		BreakSchedule: []time.Duration{5 * time.Minute, 10 * time.Minute},
-		Description:   "Session moyenne (2-4 exos, 30min)",
-	},
-	models.EnergyHigh: {
-		Level:         models.EnergyHigh,
-		Mode:          "deep",
-		Duration:      60 * time.Minute,
-		MaxExercises:  8, // ✅ CORRIGÉ (était 10)
-		BreakSchedule: []time.Duration{5 * time.Minute, 10 * time.Minute, 15 * time.Minute},
-		Description:   "Session longue (4-8 exos, 60min)",
-	},
+// DefaultConfigs : Templates intégrés (seed de session_templates, repli si
+// la table n'est pas encore chargée)
+func DefaultConfigs() []Config {
+	return []Config{
+		{
+			Mode:          "micro",
+			Name:          "Micro",
+			Level:         models.EnergyLow,
+			Duration:      15 * time.Minute,
+			MaxExercises:  2,
+			BreakEvery:    0, // Pas de pause en mode micro
+			BreakSchedule: []time.Duration{5 * time.Minute},
+			Ordering:      OrderPriority,
+			NewRatio:      RatioAuto,
+			Description:   "Session courte (1-2 exos, 15min)",
+			Builtin:       true,
+		},
+		{
+			Mode:          "standard",
+			Name:          "Standard",
+			Level:         models.EnergyMedium,
+			Duration:      30 * time.Minute,
+			MaxExercises:  4,
+			BreakEvery:    2, // Pause tous les 2 exos
+			BreakSchedule: []time.Duration{5 * time.Minute, 10 * time.Minute},
+			Ordering:      OrderPriority,
+			NewRatio:      RatioAuto,
+			Description:   "Session moyenne (2-4 exos, 30min)",
+			Builtin:       true,
+		},
+		{
+			Mode:          "deep",
+			Name:          "Deep",
+			Level:         models.EnergyHigh,
+			Duration:      60 * time.Minute,
+			MaxExercises:  8,
+			BreakEvery:    3, // Pause tous les 3 exos
+			BreakSchedule: []time.Duration{5 * time.Minute, 10 * time.Minute, 15 * time.Minute},
+			Ordering:      OrderPriority,
+			NewRatio:      RatioAuto,
+			Description:   "Session longue (4-8 exos, 60min)",
+			Builtin:       true,
+		},
+	}
 }
 
-// ============================================
-// RÈGLES MÉTIER (Domain Logic)
-// ============================================
+// Templates actifs (chargés depuis la base par le store)
+var (
+	templatesMu sync.RWMutex
+	templates   = DefaultConfigs()
+)
+
+// ConfigureTemplates : Remplace les templates actifs (vide = templates intégrés)
+func ConfigureTemplates(configs []Config) {
+	if len(configs) == 0 {
+		configs = DefaultConfigs()
+	}
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+	templates = slices.Clone(configs)
+}
+
+// Templates : Templates actifs (intégrés d'abord, par énergie croissante)
+func Templates() []Config {
+	templatesMu.RLock()
+	defer templatesMu.RUnlock()
+	return slices.Clone(templates)
+}
+
+// GetTemplate : Template par identifiant (mode)
+func GetTemplate(mode string) (Config, bool) {
+	for _, c := range Templates() {
+		if c.Mode == mode {
+			return c, true
+		}
+	}
+	return Config{}, false
+}
 
-// GetConfig : Récupère config pour un niveau d'énergie
+// GetConfig : Template intégré d'un niveau d'énergie (standard par défaut)
 func GetConfig(energy models.EnergyLevel) Config {
-	config, exists := Configs[energy]
-	if !exists {
-		return Configs[models.EnergyMedium] // Défaut
+	all := Templates()
+	for _, c := range all {
+		if c.Builtin && c.Level == energy {
+			return c
+		}
+	}
+	for _, c := range DefaultConfigs() {
+		if c.Level == energy {
+			return c
+		}
+	}
+	return DefaultConfigs()[1] // Défaut
+}
+
+// Modes : Identifiants des templates actifs ("micro", "standard", "deep", ...)
+func Modes() []string {
+	var modes []string
+	for _, c := range Templates() {
+		modes = append(modes, c.Mode)
 	}
-	return config
+	return modes
 }
 
 // GetMaxExercises : Retourne max exercices pour un niveau d'énergie
@@ -67,54 +146,126 @@ func GetMaxExercises(energy models.EnergyLevel) int {
 	return GetConfig(energy).MaxExercises
 }
 
-// LimitExercises : Limite une liste d'exercices selon l'énergie
-func LimitExercises(exerciseIDs []int, energy models.EnergyLevel) []int {
-	maxExercises := GetMaxExercises(energy)
+// Bornes des templates
+const (
+	MinTemplateDuration = 5 * time.Minute
+	MaxBreakDuration    = time.Hour
+	MaxTemplateName     = 40
+)
 
-	if len(exerciseIDs) <= maxExercises {
-		return exerciseIDs
+// templateSlug : Identifiant de template (minuscules, chiffres, tirets)
+var templateSlug = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,29}$`)
+
+// Validate : Template cohérent (identifiant, bornes, planning de pauses)
+func (c Config) Validate() error {
+	switch {
+	case !templateSlug.MatchString(c.Mode):
+		return &InvalidTemplateError{Field: "slug", Reason: "2 à 30 caractères : minuscules, chiffres, tirets"}
+	case strings.TrimSpace(c.Name) == "" || len(c.Name) > MaxTemplateName:
+		return &InvalidTemplateError{Field: "name", Reason: "nom requis (40 caractères max)"}
+	case c.Level < models.EnergyLow || c.Level > models.EnergyHigh:
+		return &InvalidTemplateError{Field: "energy_level", Reason: "doit être entre 1 et 3"}
+	case c.Duration < MinTemplateDuration || c.Duration > MaxTimeBudget:
+		return &InvalidTemplateError{Field: "duration", Reason: "durée hors limites"}
+	case c.MaxExercises < 1 || c.MaxExercises > MaxCustomCount:
+		return &InvalidTemplateError{Field: "max_exercises", Reason: "doit être entre 1 et 20"}
+	case c.BreakEvery < 0 || c.BreakEvery > MaxCustomCount:
+		return &InvalidTemplateError{Field: "break_every", Reason: "doit être entre 0 et 20"}
+	case c.BreakEvery > 0 && len(c.BreakSchedule) == 0:
+		return &InvalidTemplateError{Field: "break_schedule", Reason: "au moins une durée de pause"}
+	}
+	for _, d := range c.BreakSchedule {
+		if d < time.Minute || d > MaxBreakDuration {
+			return &InvalidTemplateError{Field: "break_schedule", Reason: "pauses entre 1 et 60 min"}
+		}
+	}
+
+	// Filtres : mêmes bornes que le builder
+	if err := c.BaseFocus().Validate(); err != nil {
+		var fe *InvalidFocusError
+		if errors.As(err, &fe) {
+			return &InvalidTemplateError{Field: fe.Field, Reason: fe.Reason}
+		}
+		return err
 	}
+	return nil
+}
 
-	return exerciseIDs[:maxExercises]
+// BaseFocus : Filtres par défaut du template (complétés par le builder)
+func (c Config) BaseFocus() Focus {
+	focus := DefaultFocus()
+	focus.Domains = slices.Clone(c.Domains)
+	focus.MinDifficulty = c.MinDifficulty
+	focus.MaxDifficulty = c.MaxDifficulty
+	focus.NewRatio = c.NewRatio
+	if c.Ordering != "" {
+		focus.Ordering = c.Ordering
+	}
+	return focus
 }
 
-// EstimateSessionTime : Estime durée selon nombre d'exercices
-func EstimateSessionTime(exerciseCount int, energy models.EnergyLevel) time.Duration {
-	config := GetConfig(energy)
+// Limit : Garde au plus MaxExercises identifiants
+func (c Config) Limit(exerciseIDs []int) []int {
+	if len(exerciseIDs) <= c.MaxExercises {
+		return exerciseIDs
+	}
+	return exerciseIDs[:c.MaxExercises]
+}
 
-	if exerciseCount == 0 {
+// ShouldTakeBreak : Règle "prendre une pause après X exercices ?"
+func (c Config) ShouldTakeBreak(completedCount int) bool {
+	return c.BreakEvery > 0 && completedCount > 0 && completedCount%c.BreakEvery == 0
+}
+
+// BreakDuration : Durée de pause selon progression (1re pause → 1re durée
+// du planning, puis cycle)
+func (c Config) BreakDuration(completedCount int) time.Duration {
+	if len(c.BreakSchedule) == 0 || c.BreakEvery == 0 || completedCount < c.BreakEvery {
 		return 0
 	}
 
-	// Temps par exercice (moyenne)
-	timePerExercise := config.Duration / time.Duration(config.MaxExercises)
-
-	return timePerExercise * time.Duration(exerciseCount)
+	// Cycle dans les durées de pause
+	index := (completedCount/c.BreakEvery - 1) % len(c.BreakSchedule)
+	return c.BreakSchedule[index]
 }
 
-// ShouldTakeBreak : Règle "prendre une pause après X exercices ?"
-func ShouldTakeBreak(completedCount int, energy models.EnergyLevel) bool {
-	switch energy {
-	case models.EnergyLow:
-		return false // Pas de pause en mode micro
-	case models.EnergyMedium:
-		return completedCount%2 == 0 && completedCount > 0 // Pause tous les 2 exos
-	case models.EnergyHigh:
-		return completedCount%3 == 0 && completedCount > 0 // Pause tous les 3 exos
-	default:
-		return false
+// EstimateSessionTime : Estime durée à partir des temps moyens réels par exercice.
+// Un temps nul (exercice jamais chronométré) retombe sur la moyenne du template.
+func EstimateSessionTime(perExercise []time.Duration, config Config) time.Duration {
+	// Temps par exercice par défaut (template)
+	fallback := config.Duration / time.Duration(max(config.MaxExercises, 1))
+
+	var total time.Duration
+	for _, d := range perExercise {
+		if d <= 0 {
+			d = fallback
+		}
+		total += d
 	}
+	return total
 }
 
-// GetBreakDuration : Durée de pause selon progression
-func GetBreakDuration(completedCount int, energy models.EnergyLevel) time.Duration {
-	config := GetConfig(energy)
+// MaxTrackedDuration : Au-delà, l'exercice est resté ouvert sans activité
+const MaxTrackedDuration = 30 * time.Minute
 
-	if len(config.BreakSchedule) == 0 {
-		return 0
+// AnswerDuration : Temps entre l'affichage et la review (ok = false si inconnu ou aberrant)
+func AnswerDuration(shownAt, answeredAt time.Time) (time.Duration, bool) {
+	if shownAt.IsZero() {
+		return 0, false
+	}
+	d := answeredAt.Sub(shownAt).Truncate(time.Second)
+	if d <= 0 || d > MaxTrackedDuration {
+		return 0, false
 	}
+	return d, true
+}
 
-	// Cycle dans les durées de pause
-	index := (completedCount - 1) % len(config.BreakSchedule)
-	return config.BreakSchedule[index]
+// ShouldTakeBreak : Règle de pause du template intégré d'un niveau d'énergie
+func ShouldTakeBreak(completedCount int, energy models.EnergyLevel) bool {
+	return GetConfig(energy).ShouldTakeBreak(completedCount)
+}
+
+// GetBreakDuration : Durée de pause du template intégré d'un niveau d'énergie
+func GetBreakDuration(completedCount int, energy models.EnergyLevel) time.Duration {
+	return GetConfig(energy).BreakDuration(completedCount)
 }
@@ -0,0 +1,159 @@
+package session
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"maestro/internal/models"
+)
+
+func TestAnswerDuration(t *testing.T) {
+	shown := time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		shownAt  time.Time
+		answered time.Time
+		want     time.Duration
+		wantOK   bool
+	}{
+		{"jamais affiché", time.Time{}, shown, 0, false},
+		{"95 secondes", shown, shown.Add(95*time.Second + 400*time.Millisecond), 95 * time.Second, true},
+		{"horloge en arrière", shown, shown.Add(-time.Minute), 0, false},
+		{"onglet oublié", shown, shown.Add(MaxTrackedDuration + time.Second), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := AnswerDuration(tt.shownAt, tt.answered)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("AnswerDuration = %v (%v), want %v (%v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEstimateSessionTime(t *testing.T) {
+	// Medium : 30 min / 4 exercices = 7m30s par exercice non chronométré
+	got := EstimateSessionTime([]time.Duration{2 * time.Minute, 0, 90 * time.Second}, GetConfig(models.EnergyMedium))
+	want := 2*time.Minute + 7*time.Minute + 30*time.Second + 90*time.Second
+	if got != want {
+		t.Errorf("EstimateSessionTime = %v, want %v", got, want)
+	}
+
+	if got := EstimateSessionTime(nil, GetConfig(models.EnergyHigh)); got != 0 {
+		t.Errorf("EstimateSessionTime(vide) = %v, want 0", got)
+	}
+}
+
+func TestBreakRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		energy    models.EnergyLevel
+		completed int
+		want      bool
+		duration  time.Duration
+	}{
+		{"micro jamais", models.EnergyLow, 2, false, 0},
+		{"standard 1 exo", models.EnergyMedium, 1, false, 0},
+		{"standard 1re pause", models.EnergyMedium, 2, true, 5 * time.Minute},
+		{"standard 2e pause", models.EnergyMedium, 4, true, 10 * time.Minute},
+		{"standard cycle", models.EnergyMedium, 6, true, 5 * time.Minute},
+		{"deep 1re pause", models.EnergyHigh, 3, true, 5 * time.Minute},
+		{"deep 3e pause", models.EnergyHigh, 9, true, 15 * time.Minute},
+		{"deep entre deux", models.EnergyHigh, 4, false, 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShouldTakeBreak(tt.completed, tt.energy); got != tt.want {
+				t.Errorf("ShouldTakeBreak = %v, want %v", got, tt.want)
+			}
+			if got := GetBreakDuration(tt.completed, tt.energy); got != tt.duration {
+				t.Errorf("GetBreakDuration = %v, want %v", got, tt.duration)
+			}
+		})
+	}
+}
+
+func TestBreakRemaining(t *testing.T) {
+	start := time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC)
+	if got := BreakRemaining(start, 5*time.Minute, start.Add(2*time.Minute)); got != 3*time.Minute {
+		t.Errorf("BreakRemaining = %v, want 3m", got)
+	}
+	if got := BreakRemaining(start, 5*time.Minute, start.Add(6*time.Minute)); got != 0 {
+		t.Errorf("BreakRemaining après la pause = %v, want 0", got)
+	}
+}
+
+func TestTemplateValidate(t *testing.T) {
+	valid := Config{
+		Mode: "revision-sql", Name: "Révision SQL", Level: models.EnergyMedium,
+		Duration: 20 * time.Minute, MaxExercises: 3, BreakEvery: 2,
+		BreakSchedule: []time.Duration{5 * time.Minute}, Ordering: OrderDomains,
+		Domains: []string{"SQL"}, NewRatio: RatioAuto,
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate(valide) = %v", err)
+	}
+	for _, c := range DefaultConfigs() {
+		if err := c.Validate(); err != nil {
+			t.Errorf("Validate(%s) = %v", c.Mode, err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		field  string
+	}{
+		{"slug majuscules", func(c *Config) { c.Mode = "Revision" }, "slug"},
+		{"slug trop court", func(c *Config) { c.Mode = "r" }, "slug"},
+		{"nom vide", func(c *Config) { c.Name = "  " }, "name"},
+		{"énergie inconnue", func(c *Config) { c.Level = 4 }, "energy_level"},
+		{"durée trop courte", func(c *Config) { c.Duration = time.Minute }, "duration"},
+		{"aucun exercice", func(c *Config) { c.MaxExercises = 0 }, "max_exercises"},
+		{"pauses sans planning", func(c *Config) { c.BreakSchedule = nil }, "break_schedule"},
+		{"pause trop longue", func(c *Config) { c.BreakSchedule = []time.Duration{2 * time.Hour} }, "break_schedule"},
+		{"difficulté inversée", func(c *Config) { c.MinDifficulty, c.MaxDifficulty = 4, 2 }, "difficulty"},
+		{"ordre inconnu", func(c *Config) { c.Ordering = "alphabetical" }, "ordering"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.modify(&c)
+			var te *InvalidTemplateError
+			if err := c.Validate(); !errors.As(err, &te) || te.Field != tt.field {
+				t.Errorf("Validate = %v, want champ %q", err, tt.field)
+			}
+		})
+	}
+}
+
+func TestTemplateRegistry(t *testing.T) {
+	t.Cleanup(func() { ConfigureTemplates(nil) })
+
+	custom := Config{Mode: "sprint", Name: "Sprint", Level: models.EnergyLow,
+		Duration: 10 * time.Minute, MaxExercises: 3}
+	ConfigureTemplates(append(DefaultConfigs(), custom))
+
+	if got, ok := GetTemplate("sprint"); !ok || got.MaxExercises != 3 {
+		t.Errorf("GetTemplate(sprint) = %+v, %v", got, ok)
+	}
+	// Le template intégré reste la référence du niveau d'énergie
+	if got := GetConfig(models.EnergyLow); got.Mode != "micro" {
+		t.Errorf("GetConfig(low) = %s, want micro", got.Mode)
+	}
+	if got := Modes(); !slices.Equal(got, []string{"micro", "standard", "deep", "sprint"}) {
+		t.Errorf("Modes = %v", got)
+	}
+	if got := custom.Limit([]int{1, 2, 3, 4}); len(got) != 3 {
+		t.Errorf("Limit = %v, want 3 exercices", got)
+	}
+	if custom.ShouldTakeBreak(3) {
+		t.Error("ShouldTakeBreak sans pause planifiée = true")
+	}
+}
@@ -0,0 +1,219 @@
+// internal/domain/session/energy.go
+package session
+
+import (
+	"fmt"
+	"time"
+
+	"maestro/internal/models"
+)
+
+// ============================================
+// RECOMMANDATION D'ÉNERGIE (builder)
+// ============================================
+
+// SessionSignal : Performance d'une session récente
+type SessionSignal struct {
+	Completed int
+	Total     int
+	Qualities []int         // Qualités des exercices faits, ordre de passage
+	AvgAnswer time.Duration // 0 = non mesuré
+}
+
+// EnergyContext : Entrées du recommandeur
+type EnergyContext struct {
+	Recent         []SessionSignal // Plus récentes d'abord
+	BaselineAnswer time.Duration   // Temps de réponse moyen global (0 = inconnu)
+	Hour           int             // Heure locale courante
+	HourRate       float64         // Taux de réussite à cette heure
+	HourReviews    int
+	OverallRate    float64
+}
+
+// Paramètres du recommandeur
+const (
+	RecentSessions   = 5  // Sessions analysées
+	MinEnergySignals = 2  // En dessous : niveau moyen, sans confiance
+	minHourReviews   = 20 // Échantillon minimal du créneau horaire
+	energyThreshold  = 0.25
+)
+
+// Poids des critères (renormalisés sur les critères disponibles)
+const (
+	weightCompletion = 0.30
+	weightQuality    = 0.25
+	weightTrend      = 0.20
+	weightHour       = 0.15
+	weightPace       = 0.10
+)
+
+// RecommendEnergy : Niveau conseillé depuis les sessions récentes (complétion,
+// qualité, baisse en fin de session, rythme de réponse, créneau horaire)
+func RecommendEnergy(ctx EnergyContext) models.EnergyRecommendation {
+	rec := models.EnergyRecommendation{Level: models.EnergyMedium, Sessions: len(ctx.Recent)}
+
+	type weighted struct {
+		factor models.EnergyFactor
+		weight float64
+	}
+	var factors []weighted
+
+	var completed, total, sumQ, nQ int
+	var trendSum float64
+	var trendN int
+	var answerSum time.Duration
+	var answerN int
+	for _, s := range ctx.Recent {
+		completed += s.Completed
+		total += s.Total
+		for _, q := range s.Qualities {
+			sumQ += q
+			nQ++
+		}
+		if t, ok := qualityTrend(s.Qualities); ok {
+			trendSum += t
+			trendN++
+		}
+		if s.AvgAnswer > 0 {
+			answerSum += s.AvgAnswer
+			answerN++
+		}
+	}
+
+	if total > 0 {
+		rate := float64(completed) / float64(total)
+		factors = append(factors, weighted{models.EnergyFactor{
+			Name:   "Complétion",
+			Score:  clampUnit((rate - 0.75) / 0.2),
+			Detail: fmt.Sprintf("%d%% des exercices terminés", int(rate*100+0.5)),
+		}, weightCompletion})
+	}
+	if nQ > 0 {
+		avg := float64(sumQ) / float64(nQ)
+		factors = append(factors, weighted{models.EnergyFactor{
+			Name:   "Qualité",
+			Score:  clampUnit((avg - 2) / 0.75),
+			Detail: fmt.Sprintf("qualité moyenne %.1f / 3", avg),
+		}, weightQuality})
+	}
+	if trendN > 0 {
+		trend := trendSum / float64(trendN)
+		detail := fmt.Sprintf("qualité stable en fin de session (%+.1f)", trend)
+		if trend < -0.25 {
+			detail = fmt.Sprintf("qualité en baisse en fin de session (%+.1f)", trend)
+		} else if trend > 0.25 {
+			detail = fmt.Sprintf("qualité en hausse en fin de session (%+.1f)", trend)
+		}
+		factors = append(factors, weighted{models.EnergyFactor{
+			Name:   "Tendance",
+			Score:  clampUnit(trend),
+			Detail: detail,
+		}, weightTrend})
+	}
+	if answerN > 0 && ctx.BaselineAnswer > 0 {
+		avg := answerSum / time.Duration(answerN)
+		ratio := float64(ctx.BaselineAnswer) / float64(avg)
+		factors = append(factors, weighted{models.EnergyFactor{
+			Name:   "Rythme",
+			Score:  clampUnit((ratio - 1) * 2),
+			Detail: fmt.Sprintf("%ds par exercice (habituel %ds)", int(avg.Seconds()), int(ctx.BaselineAnswer.Seconds())),
+		}, weightPace})
+	}
+	if ctx.HourReviews >= minHourReviews && ctx.OverallRate > 0 {
+		factors = append(factors, weighted{models.EnergyFactor{
+			Name:  "Créneau",
+			Score: clampUnit((ctx.HourRate - ctx.OverallRate) / 0.15),
+			Detail: fmt.Sprintf("%02dh : %d%% de réussite (moyenne %d%%)",
+				ctx.Hour, int(ctx.HourRate*100+0.5), int(ctx.OverallRate*100+0.5)),
+		}, weightHour})
+	}
+
+	var score, weights float64
+	for _, f := range factors {
+		score += f.factor.Score * f.weight
+		weights += f.weight
+		rec.Factors = append(rec.Factors, f.factor)
+	}
+	if weights > 0 {
+		rec.Score = score / weights
+	}
+
+	rec.Confident = len(ctx.Recent) >= MinEnergySignals
+	if !rec.Confident {
+		return rec
+	}
+	switch {
+	case rec.Score >= energyThreshold:
+		rec.Level = models.EnergyHigh
+	case rec.Score <= -energyThreshold:
+		rec.Level = models.EnergyLow
+	}
+	return rec
+}
+
+// qualityTrend : Moyenne de la 2e moitié moins celle de la 1re (au moins 4 réponses)
+func qualityTrend(qualities []int) (float64, bool) {
+	if len(qualities) < 4 {
+		return 0, false
+	}
+	half := len(qualities) / 2
+	return mean(qualities[len(qualities)-half:]) - mean(qualities[:half]), true
+}
+
+func mean(values []int) float64 {
+	sum := 0
+	for _, v := range values {
+		sum += v
+	}
+	return float64(sum) / float64(len(values))
+}
+
+func clampUnit(v float64) float64 {
+	return max(-1, min(1, v))
+}
+
+// ============================================
+// ADAPTATION EN COURS DE SESSION
+// ============================================
+
+// DeclineWindow : Réponses consécutives examinées
+const DeclineWindow = 3
+
+// IsDeclining : Les DeclineWindow dernières qualités baissent sans remonter
+// (au moins 2 points perdus) ou restent toutes sous "Bien"
+func IsDeclining(qualities []int) bool {
+	if len(qualities) < DeclineWindow {
+		return false
+	}
+	last := qualities[len(qualities)-DeclineWindow:]
+
+	allLow, falling := true, true
+	for i, q := range last {
+		if q >= 2 {
+			allLow = false
+		}
+		if i > 0 && q > last[i-1] {
+			falling = false
+		}
+	}
+	return allLow || (falling && last[0]-last[len(last)-1] >= 2)
+}
+
+// Downshift : Niveau d'énergie inférieur (false si déjà au plus bas)
+func Downshift(energy models.EnergyLevel) (models.EnergyLevel, bool) {
+	if energy <= models.EnergyLow || energy > models.EnergyHigh {
+		return energy, false
+	}
+	return energy - 1, true
+}
+
+// ShortenedRemaining : Exercices restants gardés en passant de from à to
+// (file réduite dans le rapport des MaxExercises : template de la session
+// tant que l'énergie n'a pas été abaissée, intégré du niveau ensuite)
+func (c Config) ShortenedRemaining(from, to models.EnergyLevel, remaining int) int {
+	fromMax := GetMaxExercises(from)
+	if from == c.Level && c.MaxExercises > 0 {
+		fromMax = c.MaxExercises
+	}
+	return min(remaining, remaining*GetMaxExercises(to)/fromMax)
+}
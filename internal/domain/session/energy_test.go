@@ -0,0 +1,96 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"maestro/internal/models"
+)
+
+func TestRecommendEnergy(t *testing.T) {
+	strong := SessionSignal{Completed: 8, Total: 8, Qualities: []int{3, 3, 3, 2, 3, 3, 3, 3}, AvgAnswer: 60 * time.Second}
+	tired := SessionSignal{Completed: 2, Total: 4, Qualities: []int{2, 1}, AvgAnswer: 3 * time.Minute}
+	fading := SessionSignal{Completed: 4, Total: 5, Qualities: []int{3, 3, 1, 0}}
+	steady := SessionSignal{Completed: 3, Total: 4, Qualities: []int{2, 2, 2}}
+
+	tests := []struct {
+		name      string
+		ctx       EnergyContext
+		want      models.EnergyLevel
+		confident bool
+	}{
+		{"aucun historique", EnergyContext{}, models.EnergyMedium, false},
+		{"une seule session", EnergyContext{Recent: []SessionSignal{strong}}, models.EnergyMedium, false},
+		{"en forme", EnergyContext{Recent: []SessionSignal{strong, strong}, BaselineAnswer: 90 * time.Second}, models.EnergyHigh, true},
+		{"fatigué", EnergyContext{Recent: []SessionSignal{tired, tired}, BaselineAnswer: 90 * time.Second}, models.EnergyLow, true},
+		{"baisse en fin de session", EnergyContext{Recent: []SessionSignal{fading, fading}}, models.EnergyLow, true},
+		{"régulier", EnergyContext{Recent: []SessionSignal{steady, steady, steady}}, models.EnergyMedium, true},
+		{"bon créneau", EnergyContext{Recent: []SessionSignal{steady, steady}, Hour: 9, HourRate: 0.95, HourReviews: 40, OverallRate: 0.70}, models.EnergyMedium, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RecommendEnergy(tt.ctx)
+			if got.Level != tt.want || got.Confident != tt.confident {
+				t.Errorf("RecommendEnergy = %v (confident %v, score %.2f), want %v (confident %v)",
+					got.Level, got.Confident, got.Score, tt.want, tt.confident)
+			}
+		})
+	}
+}
+
+func TestIsDeclining(t *testing.T) {
+	tests := []struct {
+		qualities []int
+		want      bool
+	}{
+		{nil, false},
+		{[]int{3, 1}, false},
+		{[]int{3, 2, 1}, true},
+		{[]int{3, 3, 2, 2, 0}, true},
+		{[]int{1, 1, 0}, true},
+		{[]int{3, 2, 2}, false},
+		{[]int{3, 1, 2}, false},
+		{[]int{0, 0, 3}, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsDeclining(tt.qualities); got != tt.want {
+			t.Errorf("IsDeclining(%v) = %v, want %v", tt.qualities, got, tt.want)
+		}
+	}
+}
+
+func TestDownshift(t *testing.T) {
+	if lower, ok := Downshift(models.EnergyHigh); !ok || lower != models.EnergyMedium {
+		t.Errorf("Downshift(high) = %v, %v", lower, ok)
+	}
+	if _, ok := Downshift(models.EnergyLow); ok {
+		t.Error("Downshift(low) = true, want false")
+	}
+
+	custom := Config{Mode: "marathon", Level: models.EnergyHigh, MaxExercises: 12}
+	tests := []struct {
+		name      string
+		config    Config
+		from, to  models.EnergyLevel
+		remaining int
+		want      int
+	}{
+		// 8 → 4 exercices max : la file restante est divisée par deux
+		{"deep → standard", GetConfig(models.EnergyHigh), models.EnergyHigh, models.EnergyMedium, 5, 2},
+		// Template 12 exercices → standard (4) : un tiers gardé
+		{"template → standard", custom, models.EnergyHigh, models.EnergyMedium, 9, 3},
+		// Déjà abaissé : rapport des intégrés (4 → 2)
+		{"second palier", custom, models.EnergyMedium, models.EnergyLow, 3, 1},
+		// Petit template : jamais plus que la file restante
+		{"petit template", Config{Level: models.EnergyHigh, MaxExercises: 2}, models.EnergyHigh, models.EnergyMedium, 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.ShortenedRemaining(tt.from, tt.to, tt.remaining); got != tt.want {
+				t.Errorf("ShortenedRemaining = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package session
 
 import (
 	"fmt"
+	"time"
 
 	"maestro/internal/models"
 )
@@ -44,3 +45,99 @@ type SessionNotFoundError struct {
 func (e *SessionNotFoundError) Error() string {
 	return fmt.Sprintf("session %d not found", e.SessionID)
 }
+
+// SessionClosedError : Session déjà terminée ou abandonnée (non reprenable)
+type SessionClosedError struct {
+	SessionID int64
+	Status    Status
+}
+
+func (e *SessionClosedError) Error() string {
+	return fmt.Sprintf("session %d is %s", e.SessionID, e.Status)
+}
+
+// InvalidIdleTimeoutError : Délai d'inactivité hors bornes
+type InvalidIdleTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *InvalidIdleTimeoutError) Error() string {
+	return fmt.Sprintf("délai d'inactivité %v invalide (%v à %v)", e.Timeout, MinIdleTimeout, MaxIdleTimeout)
+}
+
+// BreakNotOverError : Fin de pause demandée avant la durée prévue
+type BreakNotOverError struct {
+	Remaining time.Duration
+}
+
+func (e *BreakNotOverError) Error() string {
+	return fmt.Sprintf("pause en cours, encore %v", e.Remaining)
+}
+
+// InvalidFocusError : Critère du session builder invalide
+type InvalidFocusError struct {
+	Field  string
+	Reason string
+}
+
+func (e *InvalidFocusError) Error() string {
+	return fmt.Sprintf("%s : %s", e.Field, e.Reason)
+}
+
+// InvalidNewCardPolicyError : Réglage des nouveaux exercices hors bornes
+type InvalidNewCardPolicyError struct {
+	Field  string
+	Reason string
+}
+
+func (e *InvalidNewCardPolicyError) Error() string {
+	return fmt.Sprintf("%s : %s", e.Field, e.Reason)
+}
+
+// InvalidHistoryFilterError : Filtre de l'historique des sessions invalide
+type InvalidHistoryFilterError struct {
+	Field  string
+	Reason string
+}
+
+func (e *InvalidHistoryFilterError) Error() string {
+	return fmt.Sprintf("%s : %s", e.Field, e.Reason)
+}
+
+// InvalidTemplateError : Champ d'un template de session invalide
+type InvalidTemplateError struct {
+	Field  string
+	Reason string
+}
+
+func (e *InvalidTemplateError) Error() string {
+	return fmt.Sprintf("%s : %s", e.Field, e.Reason)
+}
+
+// TemplateNotFoundError : Template de session introuvable
+type TemplateNotFoundError struct {
+	Mode string
+}
+
+func (e *TemplateNotFoundError) Error() string {
+	return fmt.Sprintf("template %q introuvable", e.Mode)
+}
+
+// TemplateInUseError : Template référencé par des sessions (suppression refusée)
+type TemplateInUseError struct {
+	Mode     string
+	Sessions int
+}
+
+func (e *TemplateInUseError) Error() string {
+	return fmt.Sprintf("template %q utilisé par %d session(s)", e.Mode, e.Sessions)
+}
+
+// BuiltinTemplateError : Template intégré (non supprimable)
+type BuiltinTemplateError struct {
+	Mode string
+}
+
+func (e *BuiltinTemplateError) Error() string {
+	return fmt.Sprintf("template %q intégré, non supprimable", e.Mode)
+}
@@ -0,0 +1,151 @@
+// internal/domain/session/focus.go
+package session
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/models"
+)
+
+// ============================================
+// FOCUS (Session builder)
+// ============================================
+
+// Focus : Critères de sélection choisis dans le builder (valeur zéro = aucun filtre)
+type Focus struct {
+	Domains       []string      // Vide = tous les domaines
+	Tags          []string      // Vide = pas de filtre, sinon au moins un tag commun
+	MinDifficulty int           // 0 = pas de borne
+	MaxDifficulty int           // 0 = pas de borne
+	NewRatio      int           // Part de nouveaux visée (%), RatioAuto = réglage new_card_ratio (priorité seule à défaut)
+	Count         int           // 0 = max du template
+	TimeBudget    time.Duration // 0 = pas de budget
+	Ordering      Ordering      // Enchaînement de la session ("" = priorité)
+	Timer         time.Duration // Minuteur : la session s'arrête à l'échéance (0 = désactivé)
+	Practice      bool          // Entraînement : tous les exercices, planification SRS inchangée
+}
+
+// Bornes du builder
+const (
+	RatioAuto      = -1
+	MaxCustomCount = 20
+	MaxTimeBudget  = 3 * time.Hour
+)
+
+// DefaultFocus : Comportement historique (priorité, max de l'énergie)
+func DefaultFocus() Focus {
+	return Focus{NewRatio: RatioAuto, Ordering: OrderPriority}
+}
+
+// Validate : Bornes cohérentes
+func (f Focus) Validate() error {
+	switch {
+	case f.MinDifficulty < 0 || f.MinDifficulty > 5:
+		return &InvalidFocusError{Field: "min_difficulty", Reason: "doit être entre 1 et 5"}
+	case f.MaxDifficulty < 0 || f.MaxDifficulty > 5:
+		return &InvalidFocusError{Field: "max_difficulty", Reason: "doit être entre 1 et 5"}
+	case f.MinDifficulty > 0 && f.MaxDifficulty > 0 && f.MinDifficulty > f.MaxDifficulty:
+		return &InvalidFocusError{Field: "difficulty", Reason: "minimum supérieur au maximum"}
+	case f.NewRatio != RatioAuto && (f.NewRatio < 0 || f.NewRatio > 100):
+		return &InvalidFocusError{Field: "new_ratio", Reason: "doit être entre 0 et 100"}
+	case f.Count < 0 || f.Count > MaxCustomCount:
+		return &InvalidFocusError{Field: "count", Reason: "trop d'exercices"}
+	case f.TimeBudget < 0 || f.TimeBudget > MaxTimeBudget:
+		return &InvalidFocusError{Field: "budget", Reason: "budget hors limites"}
+	case f.Ordering != "" && !f.Ordering.Valid():
+		return &InvalidFocusError{Field: "ordering", Reason: "stratégie inconnue"}
+	}
+	return ValidateTimer(f.Timer)
+}
+
+// Matches : Exercice dans les domaines, les tags et la plage de difficulté
+func (f Focus) Matches(ex models.Exercise) bool {
+	if len(f.Domains) > 0 && !slices.Contains(f.Domains, ex.Domain) {
+		return false
+	}
+	if len(f.Tags) > 0 && !slices.ContainsFunc(ex.Tags, func(t string) bool { return slices.Contains(f.Tags, t) }) {
+		return false
+	}
+	if f.MinDifficulty > 0 && ex.Difficulty < f.MinDifficulty {
+		return false
+	}
+	if f.MaxDifficulty > 0 && ex.Difficulty > f.MaxDifficulty {
+		return false
+	}
+	return true
+}
+
+// Target : Nombre d'exercices visé (compte explicite > budget / minuteur > template)
+func (f Focus) Target(maxExercises int) int {
+	switch {
+	case f.Count > 0:
+		return f.Count
+	case f.TimeBudget > 0 || f.Timer > 0:
+		return MaxCustomCount // Le budget (ou le minuteur) tranche ensuite
+	default:
+		return maxExercises
+	}
+}
+
+// Select : Choisit les exercices d'une session parmi les candidats du jour.
+// newQuota borne les nouveaux (quota quotidien restant), estimate donne la
+// durée attendue d'un exercice (budget temps), maxExercises le maximum du
+// template choisi.
+func Select(
+	cal calendar.Calendar,
+	candidates []models.Exercise,
+	focus Focus,
+	newQuota int,
+	maxExercises int,
+	estimate func(models.Exercise) time.Duration,
+) []models.Exercise {
+	// 1. Filtre + priorité + quota de nouveaux
+	var matching []models.Exercise
+	for _, ex := range candidates {
+		if focus.Matches(ex) {
+			matching = append(matching, ex)
+		}
+	}
+	matching = LimitNew(SortByPriority(cal, matching), newQuota)
+
+	// 2. Ratio nouveaux / révisions
+	target := focus.Target(maxExercises)
+	selected := pickWithRatio(matching, target, focus.NewRatio)
+
+	// 3. Budget temps (au moins un exercice)
+	if focus.TimeBudget > 0 {
+		var total time.Duration
+		for i, ex := range selected {
+			total += estimate(ex)
+			if total > focus.TimeBudget && i > 0 {
+				selected = selected[:i]
+				break
+			}
+		}
+	}
+
+	// 4. Nouveaux répartis entre les révisions
+	return orderPriority(cal, selected)
+}
+
+// pickWithRatio : target exercices dont ~ratio% de nouveaux, complétés par
+// l'autre groupe si l'un manque (ordre de priorité conservé dans chaque groupe)
+func pickWithRatio(sorted []models.Exercise, target, ratio int) []models.Exercise {
+	if len(sorted) <= target {
+		return sorted
+	}
+	if ratio == RatioAuto {
+		return sorted[:target]
+	}
+
+	reviews, fresh := splitNew(sorted)
+	wantNew := int(math.Round(float64(target) * float64(ratio) / 100))
+	nNew := min(wantNew, len(fresh))
+	nReview := min(target-nNew, len(reviews))
+	nNew = min(target-nReview, len(fresh)) // Complète avec des nouveaux si révisions insuffisantes
+
+	return append(append([]models.Exercise{}, reviews[:nReview]...), fresh[:nNew]...)
+}
@@ -0,0 +1,86 @@
+package session
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"maestro/internal/models"
+)
+
+func TestSelect(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+	cal := testCalendar(t, now, 0)
+	last := now.AddDate(0, 0, -3)
+
+	review := func(id int, domain string, difficulty, overdueDays int) models.Exercise {
+		return models.Exercise{ID: id, Domain: domain, Difficulty: difficulty, Done: true,
+			LastReviewed: &last, NextReviewAt: now.AddDate(0, 0, -overdueDays)}
+	}
+	fresh := func(id int, domain string, difficulty int) models.Exercise {
+		return models.Exercise{ID: id, Domain: domain, Difficulty: difficulty, NextReviewAt: now}
+	}
+	candidates := []models.Exercise{
+		fresh(1, "Go", 1), fresh(2, "Go", 4), fresh(3, "SQL", 2),
+		review(4, "Go", 2, 0), review(5, "SQL", 3, 5), review(6, "Go", 5, 2), review(7, "Rust", 3, 1),
+	}
+	candidates[0].Tags = []string{"concurrence"}
+	candidates[4].Tags = []string{"index"}
+	candidates[5].Tags = []string{"concurrence", "runtime"}
+	minutes := func(ex models.Exercise) time.Duration { return time.Duration(ex.Difficulty) * 5 * time.Minute }
+
+	tests := []struct {
+		name   string
+		focus  Focus
+		quota  int
+		energy models.EnergyLevel
+		want   []int
+	}{
+		{"défaut : priorité, max énergie", DefaultFocus(), 10, models.EnergyMedium, []int{5, 6, 7, 4}},
+		{"domaine Go, nouveaux intercalés", Focus{Domains: []string{"Go"}, NewRatio: RatioAuto}, 10, models.EnergyMedium, []int{6, 1, 4, 2}},
+		{"tag concurrence", Focus{Tags: []string{"concurrence"}, NewRatio: RatioAuto}, 10, models.EnergyMedium, []int{6, 1}},
+		{"tags concurrence ou index", Focus{Tags: []string{"concurrence", "index"}, NewRatio: RatioAuto}, 10, models.EnergyMedium, []int{5, 6, 1}},
+		{"domaine SQL + tag concurrence", Focus{Domains: []string{"SQL"}, Tags: []string{"concurrence"}, NewRatio: RatioAuto}, 10, models.EnergyMedium, nil},
+		{"difficulté 2-3", Focus{MinDifficulty: 2, MaxDifficulty: 3, NewRatio: RatioAuto}, 10, models.EnergyHigh, []int{5, 7, 4, 3}},
+		{"50% nouveaux", Focus{NewRatio: 50, Count: 4}, 10, models.EnergyLow, []int{5, 1, 6, 2}},
+		{"100% nouveaux, complété", Focus{NewRatio: 100, Count: 5}, 10, models.EnergyLow, []int{5, 1, 6, 2, 3}},
+		{"quota : un seul nouveau", Focus{NewRatio: 100, Count: 5}, 1, models.EnergyLow, []int{5, 6, 7, 4, 1}},
+		{"quota épuisé", Focus{Domains: []string{"Go"}, NewRatio: RatioAuto}, 0, models.EnergyMedium, []int{6, 4}},
+		{"budget 45 min", Focus{TimeBudget: 45 * time.Minute, NewRatio: RatioAuto}, 10, models.EnergyLow, []int{5, 6}},
+		{"budget trop court : un exercice", Focus{TimeBudget: time.Minute, NewRatio: RatioAuto}, 10, models.EnergyLow, []int{5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := slices.Clone(candidates)
+			var got []int
+			for _, ex := range Select(cal, pool, tt.focus, tt.quota, GetMaxExercises(tt.energy), minutes) {
+				got = append(got, ex.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Select = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFocusValidate(t *testing.T) {
+	invalid := []Focus{
+		{MinDifficulty: 4, MaxDifficulty: 2, NewRatio: RatioAuto},
+		{MaxDifficulty: 6, NewRatio: RatioAuto},
+		{NewRatio: 120},
+		{Count: MaxCustomCount + 1, NewRatio: RatioAuto},
+		{TimeBudget: 4 * time.Hour, NewRatio: RatioAuto},
+		{NewRatio: RatioAuto, Ordering: "alphabetical"},
+		{NewRatio: RatioAuto, Timer: time.Minute},
+		{NewRatio: RatioAuto, Timer: 4 * time.Hour},
+	}
+	for _, f := range invalid {
+		if err := f.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want error", f)
+		}
+	}
+	if err := DefaultFocus().Validate(); err != nil {
+		t.Errorf("DefaultFocus().Validate() = %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+// internal/domain/session/newcards.go
+package session
+
+import (
+	"maestro/internal/models"
+)
+
+// ============================================
+// NOUVEAUX EXERCICES (quota quotidien)
+// ============================================
+
+// NewCardPolicy : Introduction des exercices jamais révisés
+type NewCardPolicy struct {
+	DailyLimit int // Nouveaux introduits max par jour utilisateur (0 = aucun)
+	Ratio      int // Part de nouveaux (%) quand le builder est en "Auto"
+}
+
+// Bornes des réglages
+const (
+	MaxNewCardsPerDay = 100
+)
+
+// DefaultNewCardPolicy : 10 nouveaux par jour, ~1 exercice sur 4
+func DefaultNewCardPolicy() NewCardPolicy {
+	return NewCardPolicy{DailyLimit: 10, Ratio: 25}
+}
+
+// Validate : Bornes cohérentes
+func (p NewCardPolicy) Validate() error {
+	switch {
+	case p.DailyLimit < 0 || p.DailyLimit > MaxNewCardsPerDay:
+		return &InvalidNewCardPolicyError{Field: "new_cards_per_day", Reason: "doit être entre 0 et 100"}
+	case p.Ratio < 0 || p.Ratio > 100:
+		return &InvalidNewCardPolicyError{Field: "new_card_ratio", Reason: "doit être entre 0 et 100"}
+	}
+	return nil
+}
+
+// Remaining : Nouveaux encore introductibles aujourd'hui
+func (p NewCardPolicy) Remaining(introduced int) int {
+	return max(p.DailyLimit-introduced, 0)
+}
+
+// LimitNew : Garde toutes les révisions et les quota premiers nouveaux (ordre conservé)
+func LimitNew(exercises []models.Exercise, quota int) []models.Exercise {
+	kept := make([]models.Exercise, 0, len(exercises))
+	for _, ex := range exercises {
+		if IsNew(ex) {
+			if quota <= 0 {
+				continue
+			}
+			quota--
+		}
+		kept = append(kept, ex)
+	}
+	return kept
+}
+
+// Interleave : Répartit les nouveaux régulièrement entre les révisions
+// (les révisions ouvrent la session, le dernier nouveau la ferme)
+func Interleave(reviews, fresh []models.Exercise) []models.Exercise {
+	n := len(reviews) + len(fresh)
+	out := make([]models.Exercise, 0, n)
+	r, f := 0, 0
+	for i := 0; i < n; i++ {
+		if f < len(fresh) && (r == len(reviews) || f < (i+1)*len(fresh)/n) {
+			out = append(out, fresh[f])
+			f++
+		} else {
+			out = append(out, reviews[r])
+			r++
+		}
+	}
+	return out
+}
+
+// splitNew : Sépare révisions et nouveaux (ordre conservé dans chaque groupe)
+func splitNew(exercises []models.Exercise) (reviews, fresh []models.Exercise) {
+	for _, ex := range exercises {
+		if IsNew(ex) {
+			fresh = append(fresh, ex)
+		} else {
+			reviews = append(reviews, ex)
+		}
+	}
+	return reviews, fresh
+}
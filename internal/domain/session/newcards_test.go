@@ -0,0 +1,69 @@
+package session
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"maestro/internal/models"
+)
+
+func TestInterleave(t *testing.T) {
+	last := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	group := func(ids ...int) []models.Exercise {
+		var out []models.Exercise
+		for _, id := range ids {
+			ex := models.Exercise{ID: id}
+			if id < 100 {
+				ex.Done, ex.LastReviewed = true, &last
+			}
+			out = append(out, ex)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		reviews []int
+		fresh   []int
+		want    []int
+	}{
+		{"aucun nouveau", []int{1, 2, 3}, nil, []int{1, 2, 3}},
+		{"que des nouveaux", nil, []int{101, 102}, []int{101, 102}},
+		{"un sur deux", []int{1, 2}, []int{101, 102}, []int{1, 101, 2, 102}},
+		{"un sur trois", []int{1, 2, 3, 4}, []int{101, 102}, []int{1, 2, 101, 3, 4, 102}},
+		{"plus de nouveaux", []int{1}, []int{101, 102, 103}, []int{1, 101, 102, 103}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, ex := range Interleave(group(tt.reviews...), group(tt.fresh...)) {
+				got = append(got, ex.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Interleave = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCardPolicy(t *testing.T) {
+	p := DefaultNewCardPolicy()
+	for introduced, want := range map[int]int{0: 10, 4: 6, 10: 0, 12: 0} {
+		if got := p.Remaining(introduced); got != want {
+			t.Errorf("Remaining(%d) = %d, want %d", introduced, got, want)
+		}
+	}
+
+	var invalid *InvalidNewCardPolicyError
+	for _, bad := range []NewCardPolicy{{DailyLimit: -1}, {DailyLimit: 101}, {DailyLimit: 5, Ratio: 120}} {
+		if err := bad.Validate(); !errors.As(err, &invalid) {
+			t.Errorf("Validate(%+v) = %v, want InvalidNewCardPolicyError", bad, err)
+		}
+	}
+	if err := (NewCardPolicy{DailyLimit: 0, Ratio: 0}).Validate(); err != nil {
+		t.Errorf("Validate(zero) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,119 @@
+// internal/domain/session/ordering.go
+package session
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/models"
+)
+
+// ============================================
+// ORDRE DES EXERCICES (stratégies)
+// ============================================
+
+// Ordering : Stratégie d'enchaînement des exercices retenus
+type Ordering string
+
+const (
+	OrderPriority   Ordering = "priority"             // Retard → aujourd'hui → date (nouveaux intercalés)
+	OrderDomains    Ordering = "interleave_domain"    // Évite deux domaines identiques d'affilée
+	OrderDifficulty Ordering = "alternate_difficulty" // Alterne difficile (≥ 3) et facile
+	OrderShuffle    Ordering = "shuffle_tier"         // Aléatoire à urgence égale
+)
+
+// Orderings : Stratégies proposées dans le builder (ordre d'affichage)
+var Orderings = []Ordering{OrderPriority, OrderDomains, OrderDifficulty, OrderShuffle}
+
+// Valid : Stratégie connue
+func (o Ordering) Valid() bool {
+	return slices.Contains(Orderings, o)
+}
+
+// Label : Libellé affiché
+func (o Ordering) Label() string {
+	switch o {
+	case OrderDomains:
+		return "Alterner les domaines"
+	case OrderDifficulty:
+		return "Alterner la difficulté"
+	case OrderShuffle:
+		return "Aléatoire par urgence"
+	default:
+		return "Priorité stricte"
+	}
+}
+
+// Order : Enchaîne les exercices selon la stratégie (rng : OrderShuffle uniquement)
+func Order(cal calendar.Calendar, exercises []models.Exercise, ordering Ordering, rng *rand.Rand) []models.Exercise {
+	switch ordering {
+	case OrderDomains:
+		return spreadBy(orderPriority(cal, exercises), func(ex models.Exercise) string {
+			return ex.Domain
+		})
+	case OrderDifficulty:
+		return spreadBy(orderPriority(cal, exercises), func(ex models.Exercise) string {
+			if ex.Difficulty >= 3 {
+				return "hard"
+			}
+			return "easy"
+		})
+	case OrderShuffle:
+		return shuffleTiers(cal, exercises, rng)
+	default:
+		return orderPriority(cal, exercises)
+	}
+}
+
+// orderPriority : Priorité stricte, nouveaux répartis entre les révisions
+func orderPriority(cal calendar.Calendar, exercises []models.Exercise) []models.Exercise {
+	return Interleave(splitNew(SortByPriority(cal, slices.Clone(exercises))))
+}
+
+// spreadBy : Prend à chaque pas l'exercice le plus prioritaire dont la clé
+// diffère du précédent (le premier restant si aucun)
+func spreadBy(sorted []models.Exercise, key func(models.Exercise) string) []models.Exercise {
+	rest := slices.Clone(sorted)
+	out := make([]models.Exercise, 0, len(sorted))
+	prev := ""
+	for len(rest) > 0 {
+		i := slices.IndexFunc(rest, func(ex models.Exercise) bool { return key(ex) != prev })
+		if i < 0 {
+			i = 0
+		}
+		prev = key(rest[i])
+		out = append(out, rest[i])
+		rest = slices.Delete(rest, i, i+1)
+	}
+	return out
+}
+
+// tier : Niveau d'urgence (0 retard, 1 aujourd'hui, 2 nouveau, 3 autre)
+func tier(cal calendar.Calendar, ex models.Exercise) int {
+	switch {
+	case IsOverdue(cal, ex):
+		return 0
+	case IsDueToday(cal, ex):
+		return 1
+	case IsNew(ex):
+		return 2
+	default:
+		return 3
+	}
+}
+
+// shuffleTiers : Mélange chaque niveau d'urgence sans les croiser
+func shuffleTiers(cal calendar.Calendar, exercises []models.Exercise, rng *rand.Rand) []models.Exercise {
+	sorted := SortByPriority(cal, slices.Clone(exercises))
+	for start := 0; start < len(sorted); {
+		end := start + 1
+		for end < len(sorted) && tier(cal, sorted[end]) == tier(cal, sorted[start]) {
+			end++
+		}
+		group := sorted[start:end]
+		rng.Shuffle(len(group), func(i, j int) { group[i], group[j] = group[j], group[i] })
+		start = end
+	}
+	return Interleave(splitNew(sorted))
+}
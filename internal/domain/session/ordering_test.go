@@ -0,0 +1,65 @@
+package session
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"maestro/internal/models"
+)
+
+func TestOrder(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+	cal := testCalendar(t, now, 0)
+	last := now.AddDate(0, 0, -3)
+
+	review := func(id int, domain string, difficulty, overdueDays int) models.Exercise {
+		return models.Exercise{ID: id, Domain: domain, Difficulty: difficulty, Done: true,
+			LastReviewed: &last, NextReviewAt: now.AddDate(0, 0, -overdueDays)}
+	}
+	exercises := []models.Exercise{
+		review(1, "Go", 4, 5), review(2, "Go", 5, 4), review(3, "Go", 2, 3),
+		review(4, "SQL", 1, 2), review(5, "SQL", 3, 0), review(6, "Rust", 2, 0),
+		{ID: 7, Domain: "Go", Difficulty: 1, NextReviewAt: now},
+	}
+
+	tests := []struct {
+		ordering Ordering
+		want     []int
+	}{
+		{OrderPriority, []int{1, 2, 3, 4, 5, 6, 7}},
+		{"", []int{1, 2, 3, 4, 5, 6, 7}},
+		{OrderDomains, []int{1, 4, 2, 5, 3, 6, 7}},
+		{OrderDifficulty, []int{1, 3, 2, 4, 5, 6, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.ordering), func(t *testing.T) {
+			var got []int
+			for _, ex := range Order(cal, slices.Clone(exercises), tt.ordering, nil) {
+				got = append(got, ex.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Order(%q) = %v, want %v", tt.ordering, got, tt.want)
+			}
+		})
+	}
+
+	// Aléatoire : les retards (1-4) restent devant les exercices du jour (5, 6)
+	for seed := uint64(0); seed < 20; seed++ {
+		got := Order(cal, slices.Clone(exercises), OrderShuffle, rand.New(rand.NewPCG(seed, 0)))
+		var reviews []int
+		for _, ex := range got {
+			if ex.ID != 7 {
+				reviews = append(reviews, ex.ID)
+			}
+		}
+		overdue, today := slices.Clone(reviews[:4]), slices.Clone(reviews[4:])
+		slices.Sort(overdue)
+		slices.Sort(today)
+		if len(got) != 7 || !slices.Equal(overdue, []int{1, 2, 3, 4}) || !slices.Equal(today, []int{5, 6}) {
+			t.Errorf("seed %d: Order(shuffle) = %v, tiers mixed", seed, reviews)
+		}
+	}
+}
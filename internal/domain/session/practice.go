@@ -0,0 +1,41 @@
+// internal/domain/session/practice.go
+package session
+
+import "maestro/internal/models"
+
+// ============================================
+// ENTRAÎNEMENT (sans effet sur la planification)
+// ============================================
+
+// PracticeSuccess : Qualité minimale comptée comme réussie (Bien)
+const PracticeSuccess = 2
+
+// SummarizePractice : Bilan des qualités d'une session d'entraînement
+// (qualités hors 0-3 ignorées)
+func SummarizePractice(qualities []int) models.PracticeSummary {
+	var summary models.PracticeSummary
+	total := 0
+	for _, q := range qualities {
+		if q < 0 || q >= len(summary.ByQuality) {
+			continue
+		}
+		summary.ByQuality[q]++
+		summary.Reviewed++
+		total += q
+		if q >= PracticeSuccess {
+			summary.Successes++
+		}
+	}
+	if summary.Reviewed > 0 {
+		summary.AvgQuality = float64(total) / float64(summary.Reviewed)
+	}
+	return summary
+}
+
+// SuccessRate : Part des exercices réussis (0 si aucun)
+func SuccessRate(summary models.PracticeSummary) float64 {
+	if summary.Reviewed == 0 {
+		return 0
+	}
+	return float64(summary.Successes) / float64(summary.Reviewed)
+}
@@ -0,0 +1,33 @@
+package session
+
+import (
+	"testing"
+
+	"maestro/internal/models"
+)
+
+func TestSummarizePractice(t *testing.T) {
+	tests := []struct {
+		name      string
+		qualities []int
+		want      models.PracticeSummary
+		rate      float64
+	}{
+		{"vide", nil, models.PracticeSummary{}, 0},
+		{"mixte", []int{0, 2, 3, 3}, models.PracticeSummary{Reviewed: 4, Successes: 3, AvgQuality: 2, ByQuality: [4]int{1, 0, 1, 2}}, 0.75},
+		{"difficile non réussi", []int{1, 1}, models.PracticeSummary{Reviewed: 2, AvgQuality: 1, ByQuality: [4]int{0, 2, 0, 0}}, 0},
+		{"qualité invalide ignorée", []int{2, 7, -1}, models.PracticeSummary{Reviewed: 1, Successes: 1, AvgQuality: 2, ByQuality: [4]int{0, 0, 1, 0}}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SummarizePractice(tt.qualities)
+			if got != tt.want {
+				t.Errorf("SummarizePractice = %+v, want %+v", got, tt.want)
+			}
+			if rate := SuccessRate(got); rate != tt.rate {
+				t.Errorf("SuccessRate = %v, want %v", rate, tt.rate)
+			}
+		})
+	}
+}
@@ -2,8 +2,8 @@ package session
 
 import (
 	"sort"
-	"time"
 
+	"maestro/internal/domain/calendar"
 	"maestro/internal/models"
 )
 
@@ -12,24 +12,20 @@ import (
 // ============================================
 
 // SortByPriority : Trie exercices par priorité (en retard → aujourd'hui → nouveaux)
-func SortByPriority(exercises []models.Exercise) []models.Exercise {
-	// now := time.Now()
-	// today := now.Truncate(24 * time.Hour)
-	// tomorrow := today.Add(24 * time.Hour)
-
+func SortByPriority(cal calendar.Calendar, exercises []models.Exercise) []models.Exercise {
 	sort.Slice(exercises, func(i, j int) bool {
 		a, b := exercises[i], exercises[j]
 
 		// Priorité 1 : En retard (urgent)
-		aOverdue := IsOverdue(a)
-		bOverdue := IsOverdue(b)
+		aOverdue := IsOverdue(cal, a)
+		bOverdue := IsOverdue(cal, b)
 		if aOverdue != bOverdue {
 			return aOverdue
 		}
 
 		// Priorité 2 : À réviser aujourd'hui
-		aToday := IsDueToday(a)
-		bToday := IsDueToday(b)
+		aToday := IsDueToday(cal, a)
+		bToday := IsDueToday(cal, b)
 		if aToday != bToday {
 			return aToday
 		}
@@ -51,22 +47,18 @@ func SortByPriority(exercises []models.Exercise) []models.Exercise {
 	return exercises
 }
 
-// IsOverdue : Exercice en retard
-func IsOverdue(ex models.Exercise) bool {
-	return ex.Done && ex.NextReviewAt.Before(time.Now())
+// IsOverdue : Exercice en retard (échéance avant le jour utilisateur courant)
+func IsOverdue(cal calendar.Calendar, ex models.Exercise) bool {
+	return ex.Done && cal.DayKey(ex.NextReviewAt) < cal.Today()
 }
 
-// IsDueToday : Exercice à réviser aujourd'hui
-func IsDueToday(ex models.Exercise) bool {
+// IsDueToday : Exercice à réviser aujourd'hui (jour utilisateur)
+func IsDueToday(cal calendar.Calendar, ex models.Exercise) bool {
 	if !ex.Done {
 		return false
 	}
 
-	now := time.Now()
-	today := now.Truncate(24 * time.Hour)
-	tomorrow := today.Add(24 * time.Hour)
-
-	return ex.NextReviewAt.After(today) && ex.NextReviewAt.Before(tomorrow)
+	return cal.DayKey(ex.NextReviewAt) == cal.Today()
 }
 
 // IsNew : Exercice jamais révisé
@@ -75,11 +67,11 @@ func IsNew(ex models.Exercise) bool {
 }
 
 // GetPriorityLabel : Label de priorité pour affichage
-func GetPriorityLabel(ex models.Exercise) string {
-	if IsOverdue(ex) {
+func GetPriorityLabel(cal calendar.Calendar, ex models.Exercise) string {
+	if IsOverdue(cal, ex) {
 		return "🔴 En retard"
 	}
-	if IsDueToday(ex) {
+	if IsDueToday(cal, ex) {
 		return "🟡 Aujourd'hui"
 	}
 	if IsNew(ex) {
@@ -0,0 +1,147 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/clock"
+	"maestro/internal/models"
+)
+
+func testCalendar(t *testing.T, now time.Time, rollover int) calendar.Calendar {
+	t.Helper()
+	return calendar.Calendar{Location: now.Location(), RolloverHour: rollover, Clock: clock.NewFixed(now)}
+}
+
+func TestIsOverdueAndDueToday(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("timezone indisponible: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		now         time.Time
+		rollover    int
+		ex          models.Exercise
+		wantOverdue bool
+		wantToday   bool
+	}{
+		{
+			name:      "dû aujourd'hui",
+			now:       time.Date(2026, 6, 15, 12, 0, 0, 0, paris),
+			ex:        models.Exercise{Done: true, NextReviewAt: time.Date(2026, 6, 15, 22, 0, 0, 0, paris)},
+			wantToday: true,
+		},
+		{
+			name:        "dû hier soir",
+			now:         time.Date(2026, 6, 15, 0, 5, 0, 0, paris),
+			ex:          models.Exercise{Done: true, NextReviewAt: time.Date(2026, 6, 14, 23, 55, 0, 0, paris)},
+			wantOverdue: true,
+		},
+		{
+			name:      "hier soir mais bascule à 4h",
+			now:       time.Date(2026, 6, 15, 0, 5, 0, 0, paris),
+			rollover:  4,
+			ex:        models.Exercise{Done: true, NextReviewAt: time.Date(2026, 6, 14, 23, 55, 0, 0, paris)},
+			wantToday: true,
+		},
+		{
+			name: "non fait jamais en retard",
+			now:  time.Date(2026, 6, 15, 12, 0, 0, 0, paris),
+			ex:   models.Exercise{Done: false, NextReviewAt: time.Date(2026, 6, 1, 8, 0, 0, 0, paris)},
+		},
+		{
+			name:      "jour du passage à l'heure d'été",
+			now:       time.Date(2026, 3, 29, 1, 30, 0, 0, paris),
+			ex:        models.Exercise{Done: true, NextReviewAt: time.Date(2026, 3, 29, 23, 0, 0, 0, paris)},
+			wantToday: true,
+		},
+		{
+			name:        "passage à l'heure d'hiver",
+			now:         time.Date(2026, 10, 25, 2, 30, 0, 0, paris),
+			ex:          models.Exercise{Done: true, NextReviewAt: time.Date(2026, 10, 24, 23, 30, 0, 0, paris)},
+			wantOverdue: true,
+		},
+		{
+			name:        "changement d'année",
+			now:         time.Date(2027, 1, 1, 9, 0, 0, 0, paris),
+			ex:          models.Exercise{Done: true, NextReviewAt: time.Date(2026, 12, 31, 9, 0, 0, 0, paris)},
+			wantOverdue: true,
+		},
+		{
+			name:      "échéance UTC, jour utilisateur Paris",
+			now:       time.Date(2026, 6, 15, 0, 30, 0, 0, paris),
+			ex:        models.Exercise{Done: true, NextReviewAt: time.Date(2026, 6, 14, 22, 45, 0, 0, time.UTC)},
+			wantToday: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := testCalendar(t, tt.now, tt.rollover)
+			if got := IsOverdue(cal, tt.ex); got != tt.wantOverdue {
+				t.Errorf("IsOverdue = %v, want %v", got, tt.wantOverdue)
+			}
+			if got := IsDueToday(cal, tt.ex); got != tt.wantToday {
+				t.Errorf("IsDueToday = %v, want %v", got, tt.wantToday)
+			}
+		})
+	}
+}
+
+func TestSortByPriority(t *testing.T) {
+	now := time.Date(2026, 12, 31, 10, 0, 0, 0, time.UTC)
+	cal := testCalendar(t, now, 0)
+	reviewed := now.AddDate(0, 0, -3)
+
+	exercises := []models.Exercise{
+		{ID: 1, Done: true, NextReviewAt: now.AddDate(0, 0, 5), LastReviewed: &reviewed}, // à venir (2027)
+		{ID: 2, Done: false}, // nouveau
+		{ID: 3, Done: true, NextReviewAt: now.Add(6 * time.Hour), LastReviewed: &reviewed}, // aujourd'hui
+		{ID: 4, Done: true, NextReviewAt: now.AddDate(0, 0, -2), LastReviewed: &reviewed},  // en retard
+		{ID: 5, Done: false}, // nouveau
+		{ID: 6, Done: true, NextReviewAt: now.AddDate(0, 0, -9), LastReviewed: &reviewed}, // en retard
+		{ID: 7, Done: true, NextReviewAt: now.AddDate(0, 0, 2), LastReviewed: &reviewed},  // à venir (2027)
+	}
+
+	got := SortByPriority(cal, exercises)
+	want := []int{6, 4, 3, 2, 5, 7, 1}
+
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Fatalf("ordre = %v, want %v", ids(got), want)
+		}
+	}
+}
+
+func TestGetPriorityLabel(t *testing.T) {
+	now := time.Date(2026, 4, 1, 10, 0, 0, 0, time.UTC)
+	cal := testCalendar(t, now, 0)
+	reviewed := now.AddDate(0, 0, -1)
+
+	tests := []struct {
+		ex   models.Exercise
+		want string
+	}{
+		{models.Exercise{Done: true, NextReviewAt: now.AddDate(0, 0, -1)}, "🔴 En retard"},
+		{models.Exercise{Done: true, NextReviewAt: now}, "🟡 Aujourd'hui"},
+		{models.Exercise{}, "🆕 Nouveau"},
+		{models.Exercise{Done: true, NextReviewAt: now.AddDate(0, 0, 3), LastReviewed: &reviewed}, "🟢 À venir"},
+	}
+
+	for _, tt := range tests {
+		if got := GetPriorityLabel(cal, tt.ex); got != tt.want {
+			t.Errorf("GetPriorityLabel = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func ids(exercises []models.Exercise) []int {
+	out := make([]int, len(exercises))
+	for i, ex := range exercises {
+		out[i] = ex.ID
+	}
+	return out
+}
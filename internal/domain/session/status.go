@@ -0,0 +1,37 @@
+// internal/domain/session/status.go
+package session
+
+import "time"
+
+// ============================================
+// CYCLE DE VIE (sessions.status)
+// ============================================
+
+// Status : État persisté d'une session
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusCompleted Status = "completed" // Fin normale ou arrêt manuel
+	StatusAbandoned Status = "abandoned" // Fermée automatiquement après inactivité
+)
+
+// Inactivité tolérée avant fermeture automatique
+const (
+	DefaultIdleTimeout = 2 * time.Hour
+	MinIdleTimeout     = 10 * time.Minute
+	MaxIdleTimeout     = 24 * time.Hour
+)
+
+// ValidateIdleTimeout : Borne le délai d'inactivité configurable
+func ValidateIdleTimeout(timeout time.Duration) error {
+	if timeout < MinIdleTimeout || timeout > MaxIdleTimeout {
+		return &InvalidIdleTimeoutError{Timeout: timeout}
+	}
+	return nil
+}
+
+// IsIdle : Aucune activité (début, affichage, review) depuis au moins timeout
+func IsIdle(lastActivity, now time.Time, timeout time.Duration) bool {
+	return !now.Before(lastActivity.Add(timeout))
+}
@@ -0,0 +1,44 @@
+package session
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIsIdle(t *testing.T) {
+	last := time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"juste après", last.Add(time.Minute), false},
+		{"avant le délai", last.Add(DefaultIdleTimeout - time.Second), false},
+		{"au délai", last.Add(DefaultIdleTimeout), true},
+		{"lendemain", last.Add(20 * time.Hour), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsIdle(last, tt.now, DefaultIdleTimeout); got != tt.want {
+				t.Errorf("IsIdle = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateIdleTimeout(t *testing.T) {
+	var invalid *InvalidIdleTimeoutError
+	for _, d := range []time.Duration{0, 5 * time.Minute, 25 * time.Hour} {
+		if err := ValidateIdleTimeout(d); !errors.As(err, &invalid) {
+			t.Errorf("ValidateIdleTimeout(%v) = %v, want InvalidIdleTimeoutError", d, err)
+		}
+	}
+	for _, d := range []time.Duration{MinIdleTimeout, DefaultIdleTimeout, MaxIdleTimeout} {
+		if err := ValidateIdleTimeout(d); err != nil {
+			t.Errorf("ValidateIdleTimeout(%v) = %v, want nil", d, err)
+		}
+	}
+}
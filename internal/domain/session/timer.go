@@ -0,0 +1,55 @@
+// internal/domain/session/timer.go
+package session
+
+import "time"
+
+// ============================================
+// MINUTEUR (mode concentration chronométré)
+// ============================================
+
+// Bornes du minuteur (0 = session non chronométrée)
+const (
+	FocusBlock = 25 * time.Minute // Bloc de concentration (Pomodoro)
+	MinTimer   = 5 * time.Minute
+)
+
+// TimerPresets : Durées proposées dans le builder (1 à 4 blocs)
+func TimerPresets() []time.Duration {
+	return []time.Duration{FocusBlock, 2 * FocusBlock, 3 * FocusBlock, 4 * FocusBlock}
+}
+
+// ValidateTimer : 0 (désactivé) ou entre MinTimer et MaxTimeBudget
+func ValidateTimer(timer time.Duration) error {
+	if timer != 0 && (timer < MinTimer || timer > MaxTimeBudget) {
+		return &InvalidFocusError{Field: "timer", Reason: "minuteur hors limites"}
+	}
+	return nil
+}
+
+// ActiveTime : Temps actif = blocs clos + bloc en cours (blockStart zéro =
+// aucun bloc ouvert, ex. pendant une pause)
+func ActiveTime(closed time.Duration, blockStart, now time.Time) time.Duration {
+	if blockStart.IsZero() || now.Before(blockStart) {
+		return closed
+	}
+	return closed + now.Sub(blockStart)
+}
+
+// TimerRemaining : Temps restant du minuteur (0 = écoulé)
+func TimerRemaining(budget, active time.Duration) time.Duration {
+	if active >= budget {
+		return 0
+	}
+	return (budget - active).Round(time.Second)
+}
+
+// TimerExpired : Session chronométrée dont le budget est consommé
+func TimerExpired(budget, active time.Duration) bool {
+	return budget > 0 && active >= budget
+}
+
+// IsInterrupted : Écart d'activité trop long pour compter comme du temps actif
+// (le bloc en cours est clos à la dernière activité, un nouveau bloc commence)
+func IsInterrupted(lastActivity, now time.Time) bool {
+	return now.Sub(lastActivity) > MaxTrackedDuration
+}
@@ -0,0 +1,74 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActiveTime(t *testing.T) {
+	start := time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		closed     time.Duration
+		blockStart time.Time
+		now        time.Time
+		want       time.Duration
+	}{
+		{"premier bloc", 0, start, start.Add(10 * time.Minute), 10 * time.Minute},
+		{"après une pause", 20 * time.Minute, start, start.Add(5 * time.Minute), 25 * time.Minute},
+		{"pendant une pause", 20 * time.Minute, time.Time{}, start.Add(time.Hour), 20 * time.Minute},
+		{"horloge en retard", 3 * time.Minute, start, start.Add(-time.Minute), 3 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ActiveTime(tt.closed, tt.blockStart, tt.now); got != tt.want {
+				t.Errorf("ActiveTime = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimerRemaining(t *testing.T) {
+	tests := []struct {
+		name    string
+		budget  time.Duration
+		active  time.Duration
+		want    time.Duration
+		expired bool
+	}{
+		{"début", FocusBlock, 0, FocusBlock, false},
+		{"en cours", FocusBlock, 10 * time.Minute, 15 * time.Minute, false},
+		{"échéance", FocusBlock, FocusBlock, 0, true},
+		{"dépassé", FocusBlock, time.Hour, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TimerRemaining(tt.budget, tt.active); got != tt.want {
+				t.Errorf("TimerRemaining = %v, want %v", got, tt.want)
+			}
+			if got := TimerExpired(tt.budget, tt.active); got != tt.expired {
+				t.Errorf("TimerExpired = %v, want %v", got, tt.expired)
+			}
+		})
+	}
+
+	if TimerExpired(0, time.Hour) {
+		t.Error("TimerExpired sans minuteur = true, want false")
+	}
+}
+
+func TestValidateTimer(t *testing.T) {
+	for _, d := range []time.Duration{-time.Minute, time.Minute, 4 * time.Hour} {
+		if err := ValidateTimer(d); err == nil {
+			t.Errorf("ValidateTimer(%v) = nil, want error", d)
+		}
+	}
+	for _, d := range append([]time.Duration{0, MinTimer, MaxTimeBudget}, TimerPresets()...) {
+		if err := ValidateTimer(d); err != nil {
+			t.Errorf("ValidateTimer(%v) = %v, want nil", d, err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package srs
+
+import "time"
+
+// ============================================
+// DÉCROISSANCE (Exercices "à risque")
+// ============================================
+
+// RiskPolicy : Seuil de rappel surveillé et horizon d'alerte
+type RiskPolicy struct {
+	Threshold   float64 // Rappel prédit sous lequel un exercice est perdu (0-1)
+	HorizonDays int     // Fenêtre d'alerte avant le franchissement
+}
+
+// DefaultRiskPolicy : 85% de rappel, 3 jours à l'avance
+func DefaultRiskPolicy() RiskPolicy {
+	return RiskPolicy{Threshold: 0.85, HorizonDays: 3}
+}
+
+// Validate : Bornes métier
+func (p RiskPolicy) Validate() error {
+	if p.Threshold < 0.5 || p.Threshold > 0.99 {
+		return ErrInvalidRiskThreshold
+	}
+	if p.HorizonDays < 1 || p.HorizonDays > 30 {
+		return ErrInvalidRiskHorizon
+	}
+	return nil
+}
+
+// CrossingTime : Instant où le rappel prédit tombe sous threshold
+func CrossingTime(lastReviewed time.Time, intervalDays int, threshold float64) time.Time {
+	interval := max(1, intervalDays)
+	days := RecallElapsedRatio(threshold) * float64(interval)
+	return lastReviewed.Add(time.Duration(days * float64(24*time.Hour)))
+}
+
+// AtRisk : Exercice mature encore au-dessus du seuil, qui le franchit dans l'horizon
+func (p RiskPolicy) AtRisk(lastReviewed *time.Time, intervalDays int, now time.Time) bool {
+	if lastReviewed == nil || intervalDays < MatureInterval {
+		return false
+	}
+	crossing := CrossingTime(*lastReviewed, intervalDays, p.Threshold)
+	return !crossing.Before(now) && crossing.Before(now.AddDate(0, 0, p.HorizonDays))
+}
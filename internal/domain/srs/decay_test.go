@@ -0,0 +1,57 @@
+package srs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRiskPolicyAtRisk(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(days float64) *time.Time {
+		t := now.Add(-time.Duration(days * float64(24*time.Hour)))
+		return &t
+	}
+	p := DefaultRiskPolicy() // 85% → franchi à ~1.54 × intervalle
+
+	tests := []struct {
+		name     string
+		last     *time.Time
+		interval int
+		want     bool
+	}{
+		{"jamais révisé", nil, 0, false},
+		{"jeune (hors périmètre)", ago(15), 10, false},
+		{"mature, loin du seuil", ago(20), 30, false},
+		{"mature, franchit dans 2j", ago(44), 30, true},
+		{"mature, déjà sous le seuil", ago(50), 30, false},
+		{"maîtrisé, franchit demain", ago(137), 90, true},
+		{"maîtrisé, dans 5j (hors horizon)", ago(133), 90, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.AtRisk(tt.last, tt.interval, now); got != tt.want {
+				t.Errorf("AtRisk = %v, want %v (R=%.3f)", got, tt.want, Retrievability(tt.last, tt.interval, now))
+			}
+		})
+	}
+}
+
+func TestCrossingTime(t *testing.T) {
+	last := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	if got := CrossingTime(last, 30, TargetRecall); !got.Equal(last.AddDate(0, 0, 30)) {
+		t.Errorf("CrossingTime(TargetRecall) = %v, want l'échéance", got)
+	}
+}
+
+func TestRiskPolicyValidate(t *testing.T) {
+	if err := DefaultRiskPolicy().Validate(); err != nil {
+		t.Errorf("défaut invalide: %v", err)
+	}
+	if err := (RiskPolicy{Threshold: 0.3, HorizonDays: 3}).Validate(); err != ErrInvalidRiskThreshold {
+		t.Errorf("seuil 30%% err = %v", err)
+	}
+	if err := (RiskPolicy{Threshold: 0.8, HorizonDays: 0}).Validate(); err != ErrInvalidRiskHorizon {
+		t.Errorf("horizon 0 err = %v", err)
+	}
+}
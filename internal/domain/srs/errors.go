@@ -0,0 +1,8 @@
+package srs
+
+import "errors"
+
+var (
+	ErrInvalidRiskThreshold = errors.New("at-risk recall threshold must be between 50% and 99%")
+	ErrInvalidRiskHorizon   = errors.New("at-risk horizon must be between 1 and 30 days")
+)
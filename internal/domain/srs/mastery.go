@@ -0,0 +1,102 @@
+package srs
+
+import (
+	"math"
+	"time"
+)
+
+// ============================================
+// MAÎTRISE (États gradués par exercice)
+// ============================================
+
+// MasteryState : Niveau de maîtrise d'un exercice
+type MasteryState string
+
+const (
+	StateNew      MasteryState = "new"      // Jamais révisé
+	StateLearning MasteryState = "learning" // Intervalle court ou probablement oublié
+	StateYoung    MasteryState = "young"    // Intervalle de quelques semaines
+	StateMature   MasteryState = "mature"   // Intervalle long
+	StateMastered MasteryState = "mastered" // Intervalle très long et toujours retenu
+)
+
+// MasteryStates : États dans l'ordre de progression
+var MasteryStates = []MasteryState{StateNew, StateLearning, StateYoung, StateMature, StateMastered}
+
+// Seuils de maîtrise (intervalle planifié, en jours)
+const (
+	YoungInterval    = 7
+	MatureInterval   = 21
+	MasteredInterval = 60
+
+	// ForgottenRecall : En dessous, l'exercice est probablement oublié
+	// (il repasse en apprentissage quel que soit son intervalle)
+	ForgottenRecall = 0.7
+)
+
+// Retrievability : Probabilité de rappel actuelle (courbe d'oubli calée sur
+// l'intervalle planifié, cf. PredictedRecall). 0 si jamais révisé.
+func Retrievability(lastReviewed *time.Time, intervalDays int, now time.Time) float64 {
+	if lastReviewed == nil {
+		return 0
+	}
+	return PredictedRecall(now.Sub(*lastReviewed).Hours()/24, intervalDays)
+}
+
+// Mastery : État de maîtrise à partir de l'intervalle et du rappel actuel.
+// Mastered exige d'être encore au-dessus de TargetRecall (pas en retard).
+func Mastery(lastReviewed *time.Time, intervalDays int, now time.Time) MasteryState {
+	if lastReviewed == nil {
+		return StateNew
+	}
+
+	recall := Retrievability(lastReviewed, intervalDays, now)
+	switch {
+	case intervalDays < YoungInterval || recall < ForgottenRecall:
+		return StateLearning
+	case intervalDays < MatureInterval:
+		return StateYoung
+	case intervalDays < MasteredInterval || recall < TargetRecall:
+		return StateMature
+	default:
+		return StateMastered
+	}
+}
+
+// RecallElapsedRatio : Temps écoulé / intervalle auquel le rappel prédit
+// tombe à recall (permet d'exprimer les seuils en SQL sans exp/log)
+func RecallElapsedRatio(recall float64) float64 {
+	return math.Log(recall) / math.Log(TargetRecall)
+}
+
+// MasteryWeight : Contribution d'un état à un score de force (0-1)
+func MasteryWeight(state MasteryState) float64 {
+	switch state {
+	case StateLearning:
+		return 0.25
+	case StateYoung:
+		return 0.5
+	case StateMature:
+		return 0.75
+	case StateMastered:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Label : Libellé affiché
+func (s MasteryState) Label() string {
+	switch s {
+	case StateLearning:
+		return "Apprentissage"
+	case StateYoung:
+		return "Jeune"
+	case StateMature:
+		return "Mature"
+	case StateMastered:
+		return "Maîtrisé"
+	default:
+		return "Nouveau"
+	}
+}
@@ -0,0 +1,50 @@
+package srs
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMastery(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(days int) *time.Time {
+		t := now.AddDate(0, 0, -days)
+		return &t
+	}
+
+	tests := []struct {
+		name     string
+		last     *time.Time
+		interval int
+		want     MasteryState
+	}{
+		{"jamais révisé", nil, 0, StateNew},
+		{"intervalle court", ago(1), 3, StateLearning},
+		{"jeune à l'heure", ago(5), 10, StateYoung},
+		{"jeune très en retard", ago(40), 10, StateLearning},
+		{"mature", ago(10), 30, StateMature},
+		{"maîtrisé", ago(30), 90, StateMastered},
+		{"maîtrisé à l'échéance", ago(90), 90, StateMastered},
+		{"maîtrisé en retard", ago(120), 90, StateMature},
+		{"maîtrisé oublié", ago(400), 90, StateLearning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mastery(tt.last, tt.interval, now); got != tt.want {
+				t.Errorf("Mastery(%d) = %s, want %s (R=%.2f)", tt.interval, got, tt.want,
+					Retrievability(tt.last, tt.interval, now))
+			}
+		})
+	}
+}
+
+func TestRecallElapsedRatio(t *testing.T) {
+	for _, recall := range []float64{TargetRecall, ForgottenRecall, 0.5} {
+		ratio := RecallElapsedRatio(recall)
+		if got := PredictedRecall(ratio*10, 10); math.Abs(got-recall) > 1e-9 {
+			t.Errorf("PredictedRecall(ratio(%.2f)) = %.4f", recall, got)
+		}
+	}
+}
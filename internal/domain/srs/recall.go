@@ -0,0 +1,54 @@
+package srs
+
+import "math"
+
+// ============================================
+// MODÈLE DE RAPPEL (Calibration du scheduler)
+// ============================================
+
+// TargetRecall : Probabilité de rappel visée à l'échéance planifiée
+const TargetRecall = 0.9
+
+// RecallBucket : Tranche d'intervalle écoulé (jours) [MinDays, MaxDays)
+type RecallBucket struct {
+	Label   string
+	MinDays float64
+	MaxDays float64 // 0 = sans borne
+}
+
+// RecallBuckets : Tranches utilisées par la courbe de rétention
+var RecallBuckets = []RecallBucket{
+	{"0-1d", 0, 1},
+	{"1-3d", 1, 3},
+	{"3-7d", 3, 7},
+	{"7-14d", 7, 14},
+	{"14-30d", 14, 30},
+	{"30d+", 30, 0},
+}
+
+// BucketIndex : Index de la tranche contenant elapsedDays
+func BucketIndex(elapsedDays float64) int {
+	for i, b := range RecallBuckets {
+		if elapsedDays >= b.MinDays && (b.MaxDays == 0 || elapsedDays < b.MaxDays) {
+			return i
+		}
+	}
+	return len(RecallBuckets) - 1
+}
+
+// Recalled : Une review est un rappel réussi à partir de Good (même seuil que
+// le taux de rétention du tableau de bord et des rapports : quality >= 2)
+func Recalled(quality ReviewQuality) bool {
+	return quality >= Good
+}
+
+// PredictedRecall : Rappel attendu après elapsedDays pour un intervalle planifié.
+// Courbe d'oubli exponentielle calée pour valoir TargetRecall à l'échéance :
+// R = TargetRecall^(elapsed / interval). Un intervalle < 1 jour compte pour 1.
+func PredictedRecall(elapsedDays float64, intervalDays int) float64 {
+	if elapsedDays <= 0 {
+		return 1
+	}
+	interval := math.Max(1, float64(intervalDays))
+	return math.Pow(TargetRecall, elapsedDays/interval)
+}
@@ -0,0 +1,67 @@
+package srs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPredictedRecall(t *testing.T) {
+	tests := []struct {
+		name     string
+		elapsed  float64
+		interval int
+		want     float64
+	}{
+		{"immédiat", 0, 4, 1},
+		{"à l'échéance", 4, 4, TargetRecall},
+		{"double de l'échéance", 8, 4, TargetRecall * TargetRecall},
+		{"intervalle nul = 1 jour", 1, 0, TargetRecall},
+		{"moitié", 5, 10, math.Sqrt(TargetRecall)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PredictedRecall(tt.elapsed, tt.interval); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("PredictedRecall(%v, %d) = %.4f, want %.4f", tt.elapsed, tt.interval, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBucketIndex(t *testing.T) {
+	tests := []struct {
+		elapsed float64
+		want    string
+	}{
+		{0.01, "0-1d"},
+		{1, "1-3d"},
+		{6.99, "3-7d"},
+		{7, "7-14d"},
+		{29.9, "14-30d"},
+		{400, "30d+"},
+	}
+
+	for _, tt := range tests {
+		if got := RecallBuckets[BucketIndex(tt.elapsed)].Label; got != tt.want {
+			t.Errorf("BucketIndex(%v) = %s, want %s", tt.elapsed, got, tt.want)
+		}
+	}
+}
+
+func TestRecalled(t *testing.T) {
+	tests := []struct {
+		quality ReviewQuality
+		want    bool
+	}{
+		{Again, false},
+		{Hard, false},
+		{Good, true},
+		{Easy, true},
+	}
+
+	for _, tt := range tests {
+		if got := Recalled(tt.quality); got != tt.want {
+			t.Errorf("Recalled(%d) = %v, want %v", tt.quality, got, tt.want)
+		}
+	}
+}
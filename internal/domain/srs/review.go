@@ -4,6 +4,8 @@ import (
 	"math"
 	"time"
 
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/clock"
 	"maestro/internal/models"
 )
 
@@ -17,14 +19,15 @@ const (
 	Easy  ReviewQuality = 3 // Facile (intervalle × 3)
 )
 
-// CalculateNextReview : Algorithme SM-2 adapté
+// CalculateNextReview : Algorithme SM-2 adapté (échéances relatives à clk)
 func CalculateNextReview(
+	clk clock.Clock,
 	quality ReviewQuality,
 	currentInterval int,
 	currentEase float64,
 	currentReps int,
 ) models.ReviewResult {
-	now := time.Now()
+	now := clk.Now()
 	result := models.ReviewResult{
 		EaseFactor:  currentEase,
 		Repetitions: currentReps,
@@ -67,7 +70,7 @@ func CalculateNextReview(
 	return result
 }
 
-// IsDueForReview vérifie si une révision est due
-func IsDueForReview(nextReview time.Time) bool {
-	return time.Now().After(nextReview)
+// IsDueForReview vérifie si une révision est due (jour utilisateur)
+func IsDueForReview(cal calendar.Calendar, nextReview time.Time) bool {
+	return cal.DayKey(nextReview) <= cal.Today()
 }
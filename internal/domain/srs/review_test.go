@@ -0,0 +1,104 @@
+package srs
+
+import (
+	"testing"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/clock"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("timezone %s indisponible: %v", name, err)
+	}
+	return loc
+}
+
+func TestCalculateNextReview(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+	clk := clock.NewFixed(now)
+
+	tests := []struct {
+		name         string
+		quality      ReviewQuality
+		interval     int
+		ease         float64
+		reps         int
+		wantInterval int
+		wantEase     float64
+		wantReps     int
+		wantNext     time.Time
+	}{
+		{"again reset", Again, 8, 2.5, 4, 0, 2.2, 0, now.Add(10 * time.Minute)},
+		{"again ease plancher", Again, 1, 1.4, 1, 0, 1.3, 0, now.Add(10 * time.Minute)},
+		{"hard", Hard, 8, 2.5, 4, 1, 2.3, 5, now.AddDate(0, 0, 1)},
+		{"hard ease plancher", Hard, 3, 1.3, 2, 1, 1.3, 3, now.AddDate(0, 0, 1)},
+		{"good nouveau", Good, 0, 2.5, 0, 1, 2.5, 1, now.AddDate(0, 0, 1)},
+		{"good double", Good, 4, 2.5, 2, 8, 2.5, 3, now.AddDate(0, 0, 8)},
+		{"easy nouveau", Easy, 0, 2.5, 0, 4, 2.5, 1, now.AddDate(0, 0, 4)},
+		{"easy triple", Easy, 3, 2.2, 2, 9, 2.3, 3, now.AddDate(0, 0, 9)},
+		{"easy ease plafond", Easy, 3, 2.5, 2, 9, 2.5, 3, now.AddDate(0, 0, 9)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateNextReview(clk, tt.quality, tt.interval, tt.ease, tt.reps)
+			if got.IntervalDays != tt.wantInterval {
+				t.Errorf("IntervalDays = %d, want %d", got.IntervalDays, tt.wantInterval)
+			}
+			if diff := got.EaseFactor - tt.wantEase; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("EaseFactor = %.2f, want %.2f", got.EaseFactor, tt.wantEase)
+			}
+			if got.Repetitions != tt.wantReps {
+				t.Errorf("Repetitions = %d, want %d", got.Repetitions, tt.wantReps)
+			}
+			if !got.NextReview.Equal(tt.wantNext) {
+				t.Errorf("NextReview = %v, want %v", got.NextReview, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestCalculateNextReviewAcrossDST(t *testing.T) {
+	paris := mustLoad(t, "Europe/Paris")
+	// Veille du passage à l'heure d'été (29 mars 2026, 2h → 3h)
+	clk := clock.NewFixed(time.Date(2026, 3, 28, 20, 0, 0, 0, paris))
+
+	got := CalculateNextReview(clk, Hard, 1, 2.5, 1)
+	want := time.Date(2026, 3, 29, 20, 0, 0, 0, paris)
+	if !got.NextReview.Equal(want) {
+		t.Errorf("NextReview = %v, want %v (même heure murale)", got.NextReview, want)
+	}
+}
+
+func TestIsDueForReview(t *testing.T) {
+	paris := mustLoad(t, "Europe/Paris")
+
+	tests := []struct {
+		name     string
+		rollover int
+		now      time.Time
+		next     time.Time
+		want     bool
+	}{
+		{"même jour", 0, time.Date(2026, 5, 2, 8, 0, 0, 0, paris), time.Date(2026, 5, 2, 23, 0, 0, 0, paris), true},
+		{"lendemain", 0, time.Date(2026, 5, 2, 23, 59, 0, 0, paris), time.Date(2026, 5, 3, 0, 1, 0, 0, paris), false},
+		{"passé", 0, time.Date(2026, 5, 2, 8, 0, 0, 0, paris), time.Date(2026, 4, 30, 8, 0, 0, 0, paris), true},
+		{"avant bascule 4h", 4, time.Date(2026, 5, 3, 1, 0, 0, 0, paris), time.Date(2026, 5, 3, 10, 0, 0, 0, paris), false},
+		{"après bascule 4h", 4, time.Date(2026, 5, 3, 4, 0, 0, 0, paris), time.Date(2026, 5, 3, 10, 0, 0, 0, paris), true},
+		{"nouvel an", 0, time.Date(2027, 1, 1, 0, 0, 0, 0, paris), time.Date(2026, 12, 31, 23, 59, 0, 0, paris), true},
+		{"réveillon", 0, time.Date(2026, 12, 31, 23, 59, 0, 0, paris), time.Date(2027, 1, 1, 0, 0, 0, 0, paris), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := calendar.Calendar{Location: paris, RolloverHour: tt.rollover, Clock: clock.NewFixed(tt.now)}
+			if got := IsDueForReview(cal, tt.next); got != tt.want {
+				t.Errorf("IsDueForReview = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
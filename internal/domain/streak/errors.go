@@ -0,0 +1,10 @@
+package streak
+
+import "errors"
+
+var (
+	ErrInvalidGoalKind   = errors.New("daily goal must be counted in reviews or minutes")
+	ErrInvalidGoalTarget = errors.New("daily goal target out of range")
+	ErrFreezeInPast      = errors.New("streak freeze must be today or later")
+	ErrTooManyFreezes    = errors.New("too many planned streak freezes")
+)
@@ -0,0 +1,145 @@
+// internal/domain/streak/streak.go
+package streak
+
+import (
+	"maestro/internal/domain/calendar"
+)
+
+// ============================================
+// OBJECTIF QUOTIDIEN
+// ============================================
+
+// GoalKind : Unité de l'objectif quotidien
+type GoalKind string
+
+const (
+	GoalReviews GoalKind = "reviews" // Nombre de reviews
+	GoalMinutes GoalKind = "minutes" // Minutes de session
+)
+
+// Bornes de l'objectif (par unité)
+const (
+	MaxReviewsTarget = 500
+	MaxMinutesTarget = 600
+)
+
+// Goal : Seuil qui fait d'un jour utilisateur un "jour de streak"
+type Goal struct {
+	Kind   GoalKind
+	Target int
+}
+
+// DefaultGoal : 1 review = ancien comportement (toute activité compte)
+func DefaultGoal() Goal {
+	return Goal{Kind: GoalReviews, Target: 1}
+}
+
+// Activity : Activité d'un jour utilisateur
+type Activity struct {
+	Reviews int
+	Minutes int
+}
+
+// Validate : Vérifie unité et bornes
+func (g Goal) Validate() error {
+	switch g.Kind {
+	case GoalReviews:
+		if g.Target < 1 || g.Target > MaxReviewsTarget {
+			return ErrInvalidGoalTarget
+		}
+	case GoalMinutes:
+		if g.Target < 1 || g.Target > MaxMinutesTarget {
+			return ErrInvalidGoalTarget
+		}
+	default:
+		return ErrInvalidGoalKind
+	}
+	return nil
+}
+
+// Progress : Avancement du jour dans l'unité de l'objectif
+func (g Goal) Progress(a Activity) int {
+	if g.Kind == GoalMinutes {
+		return a.Minutes
+	}
+	return a.Reviews
+}
+
+// Met : Vrai si l'activité atteint l'objectif
+func (g Goal) Met(a Activity) bool {
+	return g.Progress(a) >= g.Target
+}
+
+// ============================================
+// ÉTAT DU STREAK
+// ============================================
+
+// State : Streak persisté (analytics)
+type State struct {
+	Current int
+	Longest int
+	LastDay int // Dernier jour objectif atteint (YYYYMMDD, 0 = jamais)
+}
+
+// Freezes : Jours de congé planifiés (YYYYMMDD)
+type Freezes map[int]bool
+
+// bridged : Vrai si tous les jours strictement entre from et to sont gelés
+func bridged(cal calendar.Calendar, from, to int, frozen Freezes) bool {
+	for day := cal.AddDays(from, 1); day < to; day = cal.AddDays(day, 1) {
+		if !frozen[day] {
+			return false
+		}
+	}
+	return true
+}
+
+// Record : Enregistre un jour où l'objectif est atteint.
+// Idempotent pour un même jour ; un jour antérieur à LastDay est ignoré.
+func Record(cal calendar.Calendar, s State, day int, frozen Freezes) State {
+	if s.LastDay >= day {
+		return s
+	}
+
+	if s.LastDay > 0 && bridged(cal, s.LastDay, day, frozen) {
+		s.Current++
+	} else {
+		s.Current = 1
+	}
+
+	s.LastDay = day
+	if s.Current > s.Longest {
+		s.Longest = s.Current
+	}
+	return s
+}
+
+// Alive : Streak courant vu depuis today.
+// Le jour en cours n'est pas encore perdu : seuls les jours passés non gelés cassent le streak.
+func Alive(cal calendar.Calendar, s State, today int, frozen Freezes) int {
+	if s.LastDay == 0 || s.LastDay > today {
+		return s.Current
+	}
+	if !bridged(cal, s.LastDay, today, frozen) {
+		return 0
+	}
+	return s.Current
+}
+
+// ============================================
+// GELS (jours de congé planifiés)
+// ============================================
+
+// MaxPlannedFreezes : Gels à venir autorisés simultanément
+const MaxPlannedFreezes = 14
+
+// ValidateFreeze : Un gel se planifie pour aujourd'hui ou plus tard, dans la limite autorisée
+func ValidateFreeze(day, today, planned int) error {
+	if day < today {
+		return ErrFreezeInPast
+	}
+	if planned >= MaxPlannedFreezes {
+		return ErrTooManyFreezes
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package streak
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/clock"
+)
+
+// replay : Rejoue des reviews (ordre quelconque) avec l'objectif par défaut
+func replay(cal calendar.Calendar, times []time.Time, frozen Freezes) State {
+	days := make([]int, 0, len(times))
+	for _, t := range times {
+		days = append(days, cal.DayKey(t))
+	}
+	sort.Ints(days)
+
+	var s State
+	for _, day := range days {
+		s = Record(cal, s, day, frozen)
+	}
+	return s
+}
+
+func TestAlive(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("timezone indisponible: %v", err)
+	}
+	at := func(y int, m time.Month, d, h, min int) time.Time {
+		return time.Date(y, m, d, h, min, 0, 0, paris)
+	}
+	day := func(y int, m time.Month, d int) int {
+		return y*10000 + int(m)*100 + d
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		rollover int
+		reviews  []time.Time
+		frozen   Freezes
+		want     int
+	}{
+		{
+			name: "aucune révision",
+			now:  at(2026, 6, 10, 12, 0),
+			want: 0,
+		},
+		{
+			name:    "rien encore aujourd'hui : streak toujours en jeu",
+			now:     at(2026, 6, 10, 12, 0),
+			reviews: []time.Time{at(2026, 6, 9, 10, 0), at(2026, 6, 8, 10, 0)},
+			want:    2,
+		},
+		{
+			name:    "hier manqué : streak cassé",
+			now:     at(2026, 6, 10, 12, 0),
+			reviews: []time.Time{at(2026, 6, 8, 10, 0), at(2026, 6, 7, 10, 0)},
+			want:    0,
+		},
+		{
+			name:    "trois jours consécutifs",
+			now:     at(2026, 6, 10, 12, 0),
+			reviews: []time.Time{at(2026, 6, 10, 8, 0), at(2026, 6, 9, 23, 59), at(2026, 6, 8, 0, 0), at(2026, 6, 6, 9, 0)},
+			want:    3,
+		},
+		{
+			name:     "révision après minuit avec bascule à 4h",
+			now:      at(2026, 6, 10, 2, 0),
+			rollover: 4,
+			reviews:  []time.Time{at(2026, 6, 10, 1, 30), at(2026, 6, 8, 21, 0)},
+			want:     2,
+		},
+		{
+			name:    "à travers le passage à l'heure d'été",
+			now:     at(2026, 3, 30, 9, 0),
+			reviews: []time.Time{at(2026, 3, 30, 8, 0), at(2026, 3, 29, 3, 0), at(2026, 3, 28, 23, 30)},
+			want:    3,
+		},
+		{
+			name:    "à travers le passage à l'heure d'hiver",
+			now:     at(2026, 10, 26, 0, 30),
+			reviews: []time.Time{at(2026, 10, 26, 0, 10), at(2026, 10, 25, 2, 30), at(2026, 10, 24, 23, 0)},
+			want:    3,
+		},
+		{
+			name:    "à travers le nouvel an",
+			now:     at(2027, 1, 2, 10, 0),
+			reviews: []time.Time{at(2027, 1, 2, 9, 0), at(2027, 1, 1, 0, 1), at(2026, 12, 31, 23, 59), at(2026, 12, 30, 12, 0)},
+			want:    4,
+		},
+		{
+			name:    "horodatage UTC, jour Paris",
+			now:     at(2026, 6, 10, 0, 30),
+			reviews: []time.Time{time.Date(2026, 6, 9, 22, 15, 0, 0, time.UTC), time.Date(2026, 6, 9, 12, 0, 0, 0, time.UTC)},
+			want:    2,
+		},
+		{
+			name:    "gel comble un trou",
+			now:     at(2026, 6, 10, 12, 0),
+			reviews: []time.Time{at(2026, 6, 10, 8, 0), at(2026, 6, 8, 8, 0), at(2026, 6, 7, 8, 0)},
+			frozen:  Freezes{day(2026, 6, 9): true},
+			want:    3,
+		},
+		{
+			name:    "gels en cours : streak conservé",
+			now:     at(2026, 6, 10, 12, 0),
+			reviews: []time.Time{at(2026, 6, 7, 8, 0), at(2026, 6, 6, 8, 0)},
+			frozen:  Freezes{day(2026, 6, 8): true, day(2026, 6, 9): true},
+			want:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := calendar.Calendar{Location: paris, RolloverHour: tt.rollover, Clock: clock.NewFixed(tt.now)}
+			s := replay(cal, tt.reviews, tt.frozen)
+			if got := Alive(cal, s, cal.Today(), tt.frozen); got != tt.want {
+				t.Errorf("Alive = %d, want %d (state %+v)", got, tt.want, s)
+			}
+		})
+	}
+}
+
+func TestRecordBeyondOneYear(t *testing.T) {
+	cal := calendar.Calendar{Location: time.UTC}
+	start := cal.DayKey(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	var s State
+	for i := 0; i < 800; i++ {
+		s = Record(cal, s, cal.AddDays(start, i), nil)
+	}
+	if s.Current != 800 || s.Longest != 800 {
+		t.Fatalf("state = %+v, want 800/800", s)
+	}
+
+	// Même jour rejoué : idempotent
+	if again := Record(cal, s, s.LastDay, nil); again != s {
+		t.Errorf("Record même jour = %+v, want %+v", again, s)
+	}
+
+	// Trou de deux jours : repart à 1, le record reste
+	s = Record(cal, s, cal.AddDays(s.LastDay, 3), nil)
+	if s.Current != 1 || s.Longest != 800 {
+		t.Errorf("après trou = %+v, want current 1, longest 800", s)
+	}
+}
+
+func TestGoal(t *testing.T) {
+	tests := []struct {
+		name     string
+		goal     Goal
+		activity Activity
+		valid    bool
+		met      bool
+	}{
+		{"défaut, une review", DefaultGoal(), Activity{Reviews: 1}, true, true},
+		{"défaut, rien", DefaultGoal(), Activity{Minutes: 30}, true, false},
+		{"10 reviews, 9 faites", Goal{GoalReviews, 10}, Activity{Reviews: 9}, true, false},
+		{"20 minutes atteintes", Goal{GoalMinutes, 20}, Activity{Reviews: 1, Minutes: 25}, true, true},
+		{"unité inconnue", Goal{"pages", 3}, Activity{}, false, false},
+		{"seuil nul", Goal{GoalMinutes, 0}, Activity{}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.goal.Validate(); (err == nil) != tt.valid {
+				t.Errorf("Validate() = %v, want valid=%v", err, tt.valid)
+			}
+			if got := tt.goal.Met(tt.activity); got != tt.met {
+				t.Errorf("Met() = %v, want %v", got, tt.met)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// internal/domain/timeslot/timeslot.go
+package timeslot
+
+import (
+	"fmt"
+	"time"
+)
+
+// ============================================
+// PERFORMANCE PAR CRÉNEAU (Règles Métier)
+// ============================================
+
+const (
+	SlotHours      = 3  // Largeur d'un créneau recommandé
+	MinSlotReviews = 30 // Échantillon minimal pour recommander un créneau
+	priorWeight    = 20 // Poids du taux global dans le lissage bayésien
+)
+
+// DefaultDifficultySuccessRate : Valeurs historiques (schema.sql)
+var DefaultDifficultySuccessRate = map[int]float64{1: 0.95, 2: 0.85, 3: 0.70, 4: 0.50, 5: 0.30}
+
+// Tally : Compteurs bruts d'un créneau (heure, jour de semaine, difficulté)
+type Tally struct {
+	Reviews     int
+	Successes   int   // quality >= 2
+	ResponseSec int64 // Somme des temps de réponse mesurés
+	Responses   int   // Nombre de temps de réponse mesurés
+}
+
+// Add : Cumule deux compteurs
+func (t Tally) Add(o Tally) Tally {
+	return Tally{
+		Reviews:     t.Reviews + o.Reviews,
+		Successes:   t.Successes + o.Successes,
+		ResponseSec: t.ResponseSec + o.ResponseSec,
+		Responses:   t.Responses + o.Responses,
+	}
+}
+
+// SuccessRate : Taux brut (0 si aucune review)
+func (t Tally) SuccessRate() float64 {
+	if t.Reviews == 0 {
+		return 0
+	}
+	return float64(t.Successes) / float64(t.Reviews)
+}
+
+// AvgResponseSec : Temps de réponse moyen (0 si non mesuré)
+func (t Tally) AvgResponseSec() int {
+	if t.Responses == 0 {
+		return 0
+	}
+	return int(t.ResponseSec / int64(t.Responses))
+}
+
+// Smoothed : Taux lissé vers prior (évite qu'un créneau de 3 reviews gagne)
+func (t Tally) Smoothed(prior float64) float64 {
+	return (float64(t.Successes) + priorWeight*prior) / (float64(t.Reviews) + priorWeight)
+}
+
+// ============================================
+// MEILLEUR CRÉNEAU / JOUR
+// ============================================
+
+// SlotLabel : "09h-12h"
+func SlotLabel(start int) string {
+	return fmt.Sprintf("%02dh-%02dh", start, (start+SlotHours)%24)
+}
+
+// window : Cumul des heures [start, start+SlotHours) (passe minuit)
+func window(hours [24]Tally, start int) Tally {
+	var t Tally
+	for h := range SlotHours {
+		t = t.Add(hours[(start+h)%24])
+	}
+	return t
+}
+
+// BestSlot : Début du créneau de SlotHours heures au meilleur taux lissé.
+// ok = false si aucun créneau n'a assez de reviews.
+func BestSlot(hours [24]Tally) (start int, ok bool) {
+	var total Tally
+	for _, h := range hours {
+		total = total.Add(h)
+	}
+	prior := total.SuccessRate()
+
+	best := -1.0
+	for s := range 24 {
+		w := window(hours, s)
+		if w.Reviews < MinSlotReviews {
+			continue
+		}
+		if score := w.Smoothed(prior); score > best {
+			best, start, ok = score, s, true
+		}
+	}
+	return start, ok
+}
+
+// BestWeekday : Jour de semaine au meilleur taux lissé
+func BestWeekday(days [7]Tally) (day time.Weekday, ok bool) {
+	var total Tally
+	for _, d := range days {
+		total = total.Add(d)
+	}
+	prior := total.SuccessRate()
+
+	best := -1.0
+	for i, d := range days {
+		if d.Reviews < MinSlotReviews {
+			continue
+		}
+		if score := d.Smoothed(prior); score > best {
+			best, day, ok = score, time.Weekday(i), true
+		}
+	}
+	return day, ok
+}
+
+// CalibrateDifficulty : Taux de réussite par difficulté, lissé vers les valeurs par défaut
+func CalibrateDifficulty(observed map[int]Tally) map[int]float64 {
+	rates := make(map[int]float64, len(DefaultDifficultySuccessRate))
+	for d, def := range DefaultDifficultySuccessRate {
+		rate := observed[d].Smoothed(def)
+		rates[d] = float64(int(rate*100+0.5)) / 100
+	}
+	return rates
+}
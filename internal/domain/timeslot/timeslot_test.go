@@ -0,0 +1,95 @@
+package timeslot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBestSlot(t *testing.T) {
+	tests := []struct {
+		name   string
+		hours  map[int]Tally
+		want   int
+		wantOK bool
+	}{
+		{
+			name:   "pas assez de reviews",
+			hours:  map[int]Tally{9: {Reviews: 10, Successes: 10}},
+			wantOK: false,
+		},
+		{
+			name: "matin meilleur que le soir",
+			hours: map[int]Tally{
+				9:  {Reviews: 40, Successes: 36},
+				10: {Reviews: 40, Successes: 36},
+				20: {Reviews: 80, Successes: 40},
+			},
+			want: 8, wantOK: true, // 08h-11h couvre 9h et 10h
+		},
+		{
+			name: "petit créneau parfait lissé vers la moyenne",
+			hours: map[int]Tally{
+				3:  {Reviews: 30, Successes: 30},
+				14: {Reviews: 300, Successes: 285},
+				20: {Reviews: 300, Successes: 150},
+			},
+			want: 12, wantOK: true,
+		},
+		{
+			name: "créneau à cheval sur minuit",
+			hours: map[int]Tally{
+				23: {Reviews: 40, Successes: 40},
+				0:  {Reviews: 40, Successes: 40},
+				12: {Reviews: 100, Successes: 50},
+			},
+			want: 22, wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hours [24]Tally
+			for h, tally := range tt.hours {
+				hours[h] = tally
+			}
+			got, ok := BestSlot(hours)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("BestSlot = %d (%v), want %d (%v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestBestWeekday(t *testing.T) {
+	var days [7]Tally
+	days[time.Monday] = Tally{Reviews: 100, Successes: 60}
+	days[time.Saturday] = Tally{Reviews: 100, Successes: 90}
+	days[time.Sunday] = Tally{Reviews: 5, Successes: 5} // Échantillon trop petit
+
+	if got, ok := BestWeekday(days); !ok || got != time.Saturday {
+		t.Errorf("BestWeekday = %v (%v), want Saturday", got, ok)
+	}
+}
+
+func TestCalibrateDifficulty(t *testing.T) {
+	rates := CalibrateDifficulty(map[int]Tally{
+		2: {Reviews: 980, Successes: 490}, // Beaucoup de données : suit l'observé
+		5: {Reviews: 2, Successes: 2},     // Presque rien : reste proche du défaut
+	})
+
+	if rates[1] != DefaultDifficultySuccessRate[1] {
+		t.Errorf("difficulté 1 sans données = %.2f, want %.2f", rates[1], DefaultDifficultySuccessRate[1])
+	}
+	if rates[2] < 0.50 || rates[2] > 0.52 {
+		t.Errorf("difficulté 2 = %.2f, want ≈ 0.51", rates[2])
+	}
+	if rates[5] < 0.30 || rates[5] > 0.40 {
+		t.Errorf("difficulté 5 = %.2f, want proche de 0.30", rates[5])
+	}
+}
+
+func TestSlotLabel(t *testing.T) {
+	if got := SlotLabel(22); got != "22h-01h" {
+		t.Errorf("SlotLabel(22) = %q, want 22h-01h", got)
+	}
+}
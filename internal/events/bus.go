@@ -0,0 +1,109 @@
+// internal/events/bus.go
+package events
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// ============================================
+// BUS D'ÉVÉNEMENTS (in-process, synchrone)
+// ============================================
+
+// Kind : Type d'événement métier
+type Kind string
+
+const (
+	ReviewRecorded  Kind = "review.recorded"  // Review enregistrée (streak déjà à jour)
+	SessionEnded    Kind = "session.ended"    // Session terminée
+	ExerciseCreated Kind = "exercise.created" // Nouvel exercice
+	DataChanged     Kind = "data.changed"     // Écriture en base (invalidation des caches)
+)
+
+// Event : Charge utile commune (champs non pertinents à zéro)
+type Event struct {
+	Kind       Kind
+	At         time.Time
+	ExerciseID int
+	SessionID  int64
+	Domain     string
+	Quality    int
+	Table      string // DataChanged : table modifiée ("" = base entière)
+}
+
+// Handler : Abonné (doit rester rapide : appelé dans la requête)
+type Handler func(Event)
+
+type subscriber struct {
+	id      int
+	handler Handler
+}
+
+// Bus : Abonnés par type d'événement, appelés dans l'ordre d'abonnement
+type Bus struct {
+	mu     sync.RWMutex
+	nextID int
+	subs   map[Kind][]subscriber
+}
+
+func NewBus() *Bus {
+	return &Bus{subs: make(map[Kind][]subscriber)}
+}
+
+// Subscribe : Abonne h à kind, retourne la fonction de désabonnement
+func (b *Bus) Subscribe(kind Kind, h Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subs[kind] = append(b.subs[kind], subscriber{id: id, handler: h})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		subs := b.subs[kind]
+		for i, s := range subs {
+			if s.id == id {
+				b.subs[kind] = append(subs[:i:i], subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish : Appelle chaque abonné ; un abonné en panique n'interrompt ni
+// les suivants ni l'action qui a publié
+func (b *Bus) Publish(e Event) {
+	b.mu.RLock()
+	subs := append([]subscriber(nil), b.subs[e.Kind]...)
+	b.mu.RUnlock()
+
+	for _, s := range subs {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("❌ [Events] %s handler panic: %v", e.Kind, r)
+				}
+			}()
+			s.handler(e)
+		}()
+	}
+}
+
+// ============================================
+// BUS GLOBAL (services)
+// ============================================
+
+var defaultBus = NewBus()
+
+// Subscribe : Abonnement au bus global
+func Subscribe(kind Kind, h Handler) func() {
+	return defaultBus.Subscribe(kind, h)
+}
+
+// Publish : Publication sur le bus global
+func Publish(e Event) {
+	defaultBus.Publish(e)
+}
@@ -0,0 +1,25 @@
+package events
+
+import "testing"
+
+func TestBusPublishOrderAndUnsubscribe(t *testing.T) {
+	b := NewBus()
+	var got []string
+
+	b.Subscribe(ReviewRecorded, func(Event) { got = append(got, "a") })
+	b.Subscribe(ReviewRecorded, func(Event) { panic("boom") })
+	cancel := b.Subscribe(ReviewRecorded, func(Event) { got = append(got, "c") })
+	b.Subscribe(SessionEnded, func(Event) { got = append(got, "session") })
+
+	b.Publish(Event{Kind: ReviewRecorded})
+	if len(got) != 2 || got[0] != "a" || got[1] != "c" {
+		t.Fatalf("abonnés appelés = %v, want [a c] (panique isolée)", got)
+	}
+
+	cancel()
+	got = nil
+	b.Publish(Event{Kind: ReviewRecorded})
+	if len(got) != 1 || got[0] != "a" {
+		t.Fatalf("après désabonnement = %v, want [a]", got)
+	}
+}
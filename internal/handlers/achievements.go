@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"maestro/internal/service"
+	"maestro/internal/views/pages"
+)
+
+// ============================================
+// SERVICE GLOBAL
+// ============================================
+
+var achievementService *service.AchievementService
+
+func init() {
+	achievementService = service.NewAchievementService()
+}
+
+// ============================================
+// 1️⃣ PAGE SUCCÈS
+// ============================================
+
+func HandleAchievementsPage(w http.ResponseWriter, r *http.Request) {
+	component := pages.AchievementsPage(
+		achievementService.GetAchievements(),
+		achievementService.GetSummary(0),
+	)
+
+	if err := component.Render(r.Context(), w); err != nil {
+		log.Printf("❌ Render error: %v", err)
+		http.Error(w, "Erreur affichage", http.StatusInternalServerError)
+	}
+}
@@ -2,41 +2,66 @@
 package handlers
 
 import (
+	"encoding/json"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"maestro/internal/service"
+	"maestro/internal/views/components"
 	"maestro/internal/views/pages"
 )
 
 var dashboardService *service.DashboardService
+var retentionService *service.RetentionService
+var decayService *service.DecayService
+var dashboardCache *service.DashboardCache
+
+// dashboardCacheTTL : Durée max d'un snapshot sans écriture (rafale de requêtes)
+const dashboardCacheTTL = 30 * time.Second
 
 func init() {
 	dashboardService = service.NewDashboardService()
+	retentionService = service.NewRetentionService()
+	decayService = service.NewDecayService()
 	plannerService = service.NewPlannerService()
+
+	dashboardCache = service.NewDashboardCache(dashboardCacheTTL)
+}
+
+// SubscribeDashboardCache : Invalidation du snapshot sur écriture en base
+// (branché par main, retourne le désabonnement)
+func SubscribeDashboardCache() func() {
+	return dashboardCache.Subscribe()
 }
 
 func HandleDashboard(w http.ResponseWriter, r *http.Request) {
 	log.Println("🔍 Dashboard: rendering with templ")
 
-	// Récupère stats
-	stats := dashboardService.GetDashboardStats()
-	todayReviews := plannerService.GetReviewsForDate(time.Now())
-	overdueReviews := plannerService.GetOverdueReviews()
-	upcomingReviews := plannerService.GetUpcomingReviews(5)
+	// Snapshot partagé (recalculé après chaque écriture) ; la reprise dépend
+	// du délai d'inactivité, elle est calculée à chaque requête
+	snap := dashboardCache.Get()
+	resume := sessionService.GetResumable()
 
 	log.Printf("📊 Stats: today=%d, overdue=%d, upcoming=%d",
-		len(todayReviews), len(overdueReviews), len(upcomingReviews))
+		len(snap.TodayReviews), len(snap.OverdueReviews), len(snap.UpcomingReviews))
 
 	// Render component
 	component := pages.Dashboard(
-		stats,
-		len(todayReviews),
-		len(overdueReviews),
-		len(upcomingReviews),
-		overdueReviews,
-		upcomingReviews,
+		snap.Stats,
+		len(snap.TodayReviews),
+		len(snap.OverdueReviews),
+		len(snap.UpcomingReviews),
+		snap.OverdueReviews,
+		snap.UpcomingReviews,
+		snap.Retention,
+		snap.Goal,
+		snap.TimeSlots,
+		snap.Timing,
+		snap.AtRisk,
+		snap.Achievements,
+		resume,
 	)
 
 	if err := component.Render(r.Context(), w); err != nil {
@@ -47,3 +72,27 @@ func HandleDashboard(w http.ResponseWriter, r *http.Request) {
 
 	log.Println("✅ Dashboard rendered successfully")
 }
+
+// HandleCacheMetrics : Compteurs du cache dashboard (JSON)
+func HandleCacheMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(dashboardCache.Metrics()); err != nil {
+		log.Printf("❌ [CacheMetrics] %v", err)
+	}
+}
+
+// HandleRetentionCurve : Fragment HTMX de la courbe de rétention filtrée
+func HandleRetentionCurve(w http.ResponseWriter, r *http.Request) {
+	domain := r.URL.Query().Get("domain")
+	difficulty, _ := strconv.Atoi(r.URL.Query().Get("difficulty"))
+
+	log.Printf("📉 RetentionCurve: domain=%q, difficulty=%d", domain, difficulty)
+
+	curve := retentionService.GetRetentionCurve(domain, difficulty)
+
+	component := components.RetentionCurveCard(curve)
+	if err := component.Render(r.Context(), w); err != nil {
+		log.Printf("❌ Render error: %v", err)
+		http.Error(w, "Erreur affichage", http.StatusInternalServerError)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -155,6 +156,7 @@ func HandleExerciseSubmit(w http.ResponseWriter, r *http.Request) {
 		Content:           r.FormValue("content"),
 		Mnemonic:          r.FormValue("mnemonic"),
 		Steps:             steps,
+		Tags:              parseTags(r.FormValue("tags")),
 		ConceptualVisuals: visuals, // ✅ AJOUTÉ
 	}
 
@@ -297,6 +299,7 @@ func HandleExerciseUpdate(w http.ResponseWriter, r *http.Request) {
 		Content:           r.FormValue("content"),
 		Mnemonic:          r.FormValue("mnemonic"),
 		Steps:             steps,
+		Tags:              parseTags(r.FormValue("tags")),
 		ConceptualVisuals: visuals, // ✅ AJOUTÉ
 	}
 
@@ -340,6 +343,18 @@ func parseSteps(text string) []string {
 	return steps
 }
 
+// parseTags : "go, concurrence" → [go concurrence] (minuscules, sans doublons)
+func parseTags(text string) []string {
+	tags := []string{}
+	for _, tag := range strings.Split(text, ",") {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 // ============================================
 // 1️⃣ PAGE PRINCIPALE EXERCICES
 // ============================================
@@ -372,7 +387,15 @@ func HandleDetailExercice(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 4. ✅ CHANGEMENT : Render avec templ (params typés)
+	// 4. MODE SESSION : Horodate l'affichage (temps de réponse)
+	if fromSession && sessionIDStr != "" {
+		sessionID, _ := strconv.ParseInt(sessionIDStr, 10, 64)
+		if err := sessionService.MarkShown(sessionID, id); err != nil {
+			log.Printf("⚠️ MarkShown error: %v", err)
+		}
+	}
+
+	// 5. ✅ CHANGEMENT : Render avec templ (params typés)
 	component := pages.ExerciseDetail(*ex, fromSession, sessionIDStr)
 
 	if err := component.Render(r.Context(), w); err != nil {
@@ -415,8 +438,9 @@ func HandleToggleDone(w http.ResponseWriter, r *http.Request) {
 	if fromSession && ex.Done && sessionIDStr != "" {
 		sessionID, _ := strconv.ParseInt(sessionIDStr, 10, 64)
 
-		// a) Marque exercice complété
-		if err := sessionService.CompleteExercise(sessionID, id, 3); err != nil {
+		// a) Marque exercice complété (avec temps de réponse)
+		duration := sessionService.AnswerDuration(sessionID, id)
+		if err := sessionService.CompleteExercise(sessionID, id, 3, duration); err != nil {
 			log.Printf("❌ CompleteExercise error: %v", err)
 		}
 
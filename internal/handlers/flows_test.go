@@ -0,0 +1,793 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"maestro/internal/domain/session"
+	"maestro/internal/store"
+)
+
+// ============================================
+// EXERCICES : Création
+// ============================================
+
+func TestCreateExercise(t *testing.T) {
+	app := newTestApp(t)
+
+	rec := app.htmxPost("/exercises/create", url.Values{
+		"title":      {"Goroutines & channels"},
+		"domain":     {"Go"},
+		"difficulty": {"3"},
+		"steps":      {"Lire le cours\nÉcrire un worker pool"},
+		"content":    {"## Worker pool"},
+	})
+	assertStatus(t, rec, http.StatusOK)
+
+	redirect := rec.Header().Get("HX-Redirect")
+	if !strings.HasPrefix(redirect, "/exercise/") {
+		t.Fatalf("HX-Redirect = %q, want /exercise/{id}", redirect)
+	}
+
+	detail := app.get(redirect)
+	assertStatus(t, detail, http.StatusOK)
+	assertContains(t, detail, "Goroutines &amp; channels", "Worker pool", `id="review-panel"`)
+}
+
+func TestCreateExerciseValidationError(t *testing.T) {
+	app := newTestApp(t)
+
+	rec := app.htmxPost("/exercises/create", url.Values{
+		"title":      {""},
+		"domain":     {"Go"},
+		"difficulty": {"2"},
+	})
+	assertStatus(t, rec, http.StatusOK)
+
+	if redirect := rec.Header().Get("HX-Redirect"); redirect != "" {
+		t.Fatalf("HX-Redirect = %q, want aucun", redirect)
+	}
+	assertContains(t, rec, `id="form-errors"`, "title: length must be 1-200")
+	assertNotContains(t, rec, "<html")
+}
+
+// ============================================
+// SRS : Review en mode libre (fragment HTMX)
+// ============================================
+
+func TestReviewEachQuality(t *testing.T) {
+	tests := []struct {
+		quality      int
+		wantInterval string
+		wantEase     string
+		wantDone     bool
+	}{
+		{0, "0 jours", "2.20", false},
+		{1, "1 jours", "2.30", true},
+		{2, "1 jours", "2.50", true},
+		{3, "4 jours", "2.50", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("quality=%d", tt.quality), func(t *testing.T) {
+			app := newTestApp(t)
+			ex := app.seedExercise("Binary search", "Algorithms", 2)
+
+			rec := app.htmxPost(fmt.Sprintf("/exercise/%d/review?quality=%d", ex.ID, tt.quality), nil)
+			assertStatus(t, rec, http.StatusOK)
+
+			// Fragment seul : panneau review, pas de layout
+			assertContains(t, rec, "Statistiques SRS", tt.wantInterval, tt.wantEase)
+			assertNotContains(t, rec, "<html")
+
+			saved, err := store.FindExercise(ex.ID)
+			if err != nil {
+				t.Fatalf("find exercise: %v", err)
+			}
+			if saved.Done != tt.wantDone {
+				t.Errorf("Done = %v, want %v", saved.Done, tt.wantDone)
+			}
+			if saved.LastReviewed == nil || !saved.LastReviewed.Equal(app.clock.Now()) {
+				t.Errorf("LastReviewed = %v, want %v", saved.LastReviewed, app.clock.Now())
+			}
+		})
+	}
+}
+
+func TestReviewInvalidQuality(t *testing.T) {
+	app := newTestApp(t)
+	ex := app.seedExercise("Heap", "Algorithms", 2)
+
+	rec := app.htmxPost(fmt.Sprintf("/exercise/%d/review?quality=7", ex.ID), nil)
+	assertStatus(t, rec, http.StatusBadRequest)
+}
+
+// ============================================
+// SESSION : Démarrage → réponses → fin
+// ============================================
+
+func TestSessionFlow(t *testing.T) {
+	app := newTestApp(t)
+	var ids []int
+	for i := range 3 {
+		ids = append(ids, app.seedExercise(fmt.Sprintf("Exercice %d", i+1), "Go", 2).ID)
+	}
+
+	// 1. Démarre la session (énergie haute)
+	start := app.get("/session/start?energy=3")
+	assertStatus(t, start, http.StatusSeeOther)
+
+	next := start.Header().Get("Location")
+	if !strings.Contains(next, "from=session") {
+		t.Fatalf("Location = %q, want exercice en mode session", next)
+	}
+
+	// 2. Répond à chaque exercice en suivant les HX-Redirect
+	answered := 0
+	for !strings.HasPrefix(next, "/session/complete") {
+		if answered > 3 {
+			t.Fatalf("session sans fin (dernier redirect %q)", next)
+		}
+
+		page := app.get(next)
+		assertStatus(t, page, http.StatusOK)
+		assertContains(t, page, "Statistiques SRS")
+
+		u, _ := url.Parse(next)
+		reviewURL := fmt.Sprintf("%s/review?quality=2&%s", u.Path, u.RawQuery)
+		rec := app.htmxPost(reviewURL, nil)
+		assertStatus(t, rec, http.StatusOK)
+
+		next = rec.Header().Get("HX-Redirect")
+		if next == "" {
+			t.Fatalf("review %s: aucun HX-Redirect", reviewURL)
+		}
+		answered++
+	}
+
+	if answered != 3 {
+		t.Errorf("%d exercice(s) répondus, want 3", answered)
+	}
+
+	// 3. Page de fin
+	done := app.get(next)
+	assertStatus(t, done, http.StatusOK)
+	assertContains(t, done, "Session Complétée !", "Exercices complétés")
+	for _, id := range ids {
+		assertContains(t, done, fmt.Sprintf(`href="/exercise/%d"`, id))
+	}
+}
+
+func TestStartSessionWithoutExercises(t *testing.T) {
+	app := newTestApp(t)
+
+	rec := app.get("/session/start?energy=2")
+	assertStatus(t, rec, http.StatusOK)
+	if loc := rec.Header().Get("Location"); loc != "" {
+		t.Fatalf("Location = %q, want rapport sans redirection", loc)
+	}
+}
+
+func TestRetentionCurveFragment(t *testing.T) {
+	app := newTestApp(t)
+	app.seedExercise("Goroutines", "Go", 2)
+
+	rec := app.get("/dashboard/retention?domain=Go&difficulty=2")
+	assertStatus(t, rec, http.StatusOK)
+	assertContains(t, rec, `id="retention-curve"`, "0-1d", "30d+")
+	assertNotContains(t, rec, "<html")
+}
+
+func TestSessionRecordsAnswerTime(t *testing.T) {
+	app := newTestApp(t)
+	ex := app.seedExercise("Escape analysis", "Go", 4)
+
+	start := app.get("/session/start?energy=1")
+	assertStatus(t, start, http.StatusSeeOther)
+	next := start.Header().Get("Location")
+
+	// Affichage, puis rechargement : le chrono part du premier affichage
+	assertStatus(t, app.get(next), http.StatusOK)
+	app.clock.Advance(60 * time.Second)
+	assertStatus(t, app.get(next), http.StatusOK)
+	app.clock.Advance(40 * time.Second)
+
+	u, _ := url.Parse(next)
+	rec := app.htmxPost(fmt.Sprintf("%s/review?quality=3&%s", u.Path, u.RawQuery), nil)
+	assertStatus(t, rec, http.StatusOK)
+
+	times, err := store.GetExerciseAnswerTimes([]int{ex.ID})
+	if err != nil {
+		t.Fatalf("answer times: %v", err)
+	}
+	if times[ex.ID] != 100 {
+		t.Errorf("temps de réponse = %ds, want 100s", times[ex.ID])
+	}
+
+	// Dashboard : temps par domaine + exercice le plus lent (≥ 2 mesures)
+	dash := app.get("/")
+	assertStatus(t, dash, http.StatusOK)
+	assertContains(t, dash, "TIME_INVESTMENT", "1m40s/exo")
+}
+
+func TestAtRiskQueueAndSession(t *testing.T) {
+	app := newTestApp(t)
+	app.seedExercise("Nouveau", "Go", 1) // Dû aujourd'hui, pas à risque
+
+	// Mature (30j), révisé il y a 44j : passe sous 85% dans ~2j
+	risky := app.seedExercise("Consistent hashing", "Systems", 3)
+	last := app.clock.Now().AddDate(0, 0, -44)
+	risky.LastReviewed = &last
+	risky.IntervalDays = 30
+	risky.Done = true
+	risky.NextReviewAt = last.AddDate(0, 0, 30)
+	if err := store.SaveExercise(&risky); err != nil {
+		t.Fatalf("save exercise: %v", err)
+	}
+
+	dash := app.get("/")
+	assertStatus(t, dash, http.StatusOK)
+	assertContains(t, dash, "AT_RISK", "Consistent hashing", "source=at_risk")
+
+	start := app.get("/session/start?energy=2&source=at_risk")
+	assertStatus(t, start, http.StatusSeeOther)
+	if loc := start.Header().Get("Location"); !strings.HasPrefix(loc, fmt.Sprintf("/exercise/%d?", risky.ID)) {
+		t.Fatalf("Location = %q, want exercice à risque #%d", loc, risky.ID)
+	}
+}
+
+func TestResumeInterruptedSession(t *testing.T) {
+	app := newTestApp(t)
+	for i := range 3 {
+		app.seedExercise(fmt.Sprintf("Exercice %d", i+1), "Go", 2)
+	}
+
+	// 1. Démarre, répond au premier exercice puis "ferme l'onglet"
+	start := app.get("/session/start?energy=3")
+	assertStatus(t, start, http.StatusSeeOther)
+	first, _ := url.Parse(start.Header().Get("Location"))
+	sessionID := first.Query().Get("session")
+
+	review := app.htmxPost(fmt.Sprintf("%s/review?quality=2&%s", first.Path, first.RawQuery), nil)
+	assertStatus(t, review, http.StatusOK)
+	second := review.Header().Get("HX-Redirect")
+
+	// 2. Le dashboard propose la reprise au prochain exercice non complété
+	app.clock.Advance(20 * time.Minute)
+	dash := app.get("/")
+	assertStatus(t, dash, http.StatusOK)
+	assertContains(t, dash, "SESSION_EN_COURS", "1/3 exercices", "/session/"+sessionID+"/resume")
+
+	resume := app.get("/session/" + sessionID + "/resume")
+	assertStatus(t, resume, http.StatusSeeOther)
+	if loc := resume.Header().Get("Location"); loc != second {
+		t.Fatalf("Location = %q, want %q", loc, second)
+	}
+
+	// 3. Au-delà du délai d'inactivité : plus de bannière, session abandonnée
+	app.clock.Advance(3 * time.Hour)
+	assertNotContains(t, app.get("/"), "SESSION_EN_COURS")
+
+	late := app.get("/session/" + sessionID + "/resume")
+	assertStatus(t, late, http.StatusSeeOther)
+	if loc := late.Header().Get("Location"); loc != "/" {
+		t.Fatalf("Location = %q, want /", loc)
+	}
+
+	id, _ := strconv.ParseInt(sessionID, 10, 64)
+	status, err := store.GetSessionStatus(id)
+	if err != nil || status != session.StatusAbandoned {
+		t.Errorf("status = %q (%v), want abandoned", status, err)
+	}
+	if active, _ := store.GetActiveSession(); active != 0 {
+		t.Errorf("active session = %d, want 0", active)
+	}
+}
+
+// Un onglet resté ouvert sur une session abandonnée ne la rouvre pas
+func TestStaleTabAfterAbandon(t *testing.T) {
+	app := newTestApp(t)
+	for i := range 3 {
+		app.seedExercise(fmt.Sprintf("Exercice %d", i+1), "Go", 2)
+	}
+
+	start := app.get("/session/start?energy=3")
+	first, _ := url.Parse(start.Header().Get("Location"))
+	sessionID := first.Query().Get("session")
+	review := app.htmxPost(fmt.Sprintf("%s/review?quality=2&%s", first.Path, first.RawQuery), nil)
+	second, _ := url.Parse(review.Header().Get("HX-Redirect"))
+
+	// Inactivité puis reprise refusée : session abandonnée
+	app.clock.Advance(4 * time.Hour)
+	assertStatus(t, app.get("/session/"+sessionID+"/resume"), http.StatusSeeOther)
+
+	// L'onglet périmé répond encore : retour au dashboard, session inchangée
+	stale := app.htmxPost(fmt.Sprintf("%s/review?quality=2&%s", second.Path, second.RawQuery), nil)
+	assertStatus(t, stale, http.StatusOK)
+	if loc := stale.Header().Get("HX-Redirect"); loc != "/" {
+		t.Fatalf("HX-Redirect = %q, want /", loc)
+	}
+
+	id, _ := strconv.ParseInt(sessionID, 10, 64)
+	if status, err := store.GetSessionStatus(id); err != nil || status != session.StatusAbandoned {
+		t.Errorf("status = %q (%v), want abandoned", status, err)
+	}
+	if err := store.EndSession(id); err == nil {
+		t.Error("EndSession on abandoned session: want SessionClosedError")
+	}
+	if analytics, err := store.GetAnalytics(); err != nil || analytics["total_sessions"] != 0 {
+		t.Errorf("total_sessions = %v (%v), want 0 (abandon non compté)", analytics["total_sessions"], err)
+	}
+	detail := app.get("/session/" + sessionID)
+	assertContains(t, detail, "1/3 exercices")
+}
+
+func TestSessionBreaks(t *testing.T) {
+	app := newTestApp(t)
+	for i := range 8 {
+		app.seedExercise(fmt.Sprintf("Exercice %d", i+1), "Go", 2)
+	}
+
+	// answer : Répond à l'exercice courant, retourne le HX-Redirect
+	answer := func(next string) string {
+		t.Helper()
+		u, _ := url.Parse(next)
+		rec := app.htmxPost(fmt.Sprintf("%s/review?quality=2&%s", u.Path, u.RawQuery), nil)
+		assertStatus(t, rec, http.StatusOK)
+		return rec.Header().Get("HX-Redirect")
+	}
+
+	// 1. Standard : pause de 5 min après 2 exercices
+	start := app.get("/session/start?energy=2")
+	first, _ := url.Parse(start.Header().Get("Location"))
+	sessionID := first.Query().Get("session")
+	breakURL := "/session/" + sessionID + "/break"
+
+	if next := answer(answer(first.String())); next != breakURL {
+		t.Fatalf("après 2 exercices : redirect %q, want %q", next, breakURL)
+	}
+	page := app.get(breakURL)
+	assertStatus(t, page, http.StatusOK)
+	assertContains(t, page, "05:00", breakURL+"/timer", "Passer la pause")
+
+	// 2. Fin refusée tant que le compte à rebours court
+	early := app.htmxPost(breakURL+"/end", nil)
+	assertStatus(t, early, http.StatusSeeOther)
+	if loc := early.Header().Get("Location"); loc != breakURL {
+		t.Fatalf("fin anticipée : Location = %q, want %q", loc, breakURL)
+	}
+
+	app.clock.Advance(5 * time.Minute)
+	assertContains(t, app.get(breakURL+"/timer"), "Reprendre la session")
+
+	end := app.htmxPost(breakURL+"/end", nil)
+	assertStatus(t, end, http.StatusSeeOther)
+	resume := app.get(end.Header().Get("Location"))
+	assertStatus(t, resume, http.StatusSeeOther)
+	next := resume.Header().Get("Location")
+	if !strings.Contains(next, "from=session") {
+		t.Fatalf("après la pause : Location = %q, want exercice suivant", next)
+	}
+
+	// 3. Pas de seconde pause : les 2 derniers exercices mènent à la fin
+	if done := answer(answer(next)); !strings.HasPrefix(done, "/session/complete") {
+		t.Fatalf("fin de session : redirect %q", done)
+	}
+
+	// 4. Seconde session : pause sautée
+	start = app.get("/session/start?energy=2")
+	first, _ = url.Parse(start.Header().Get("Location"))
+	breakURL = "/session/" + first.Query().Get("session") + "/break"
+	if next := answer(answer(first.String())); next != breakURL {
+		t.Fatalf("seconde session : redirect %q, want %q", next, breakURL)
+	}
+	skip := app.htmxPost(breakURL+"/skip", nil)
+	assertStatus(t, skip, http.StatusSeeOther)
+
+	analytics, err := store.GetAnalytics()
+	if err != nil {
+		t.Fatalf("analytics: %v", err)
+	}
+	if analytics["breaks_taken"] != 1 || analytics["breaks_skipped"] != 1 {
+		t.Errorf("breaks taken/skipped = %v/%v, want 1/1", analytics["breaks_taken"], analytics["breaks_skipped"])
+	}
+}
+
+func TestSessionBuilderFocus(t *testing.T) {
+	app := newTestApp(t)
+	for i := range 3 {
+		app.seedExercise(fmt.Sprintf("Go %d", i+1), "Go", 2)
+	}
+	app.seedExercise("SQL facile", "SQL", 1)
+	sql4 := app.seedExercise("SQL difficile", "SQL", 4)
+
+	builder := app.get("/session/builder")
+	assertStatus(t, builder, http.StatusOK)
+	assertContains(t, builder, `name="domain" value="Go"`, `name="domain" value="SQL"`, `name="energy"`)
+
+	// Domaine + difficulté : seul "SQL difficile" reste
+	start := app.get("/session/start?energy=3&domain=SQL&min_difficulty=3")
+	assertStatus(t, start, http.StatusSeeOther)
+	if loc := start.Header().Get("Location"); !strings.HasPrefix(loc, fmt.Sprintf("/exercise/%d?", sql4.ID)) {
+		t.Fatalf("Location = %q, want exercice #%d", loc, sql4.ID)
+	}
+	open, err := store.GetOpenSessions()
+	if err != nil || len(open) != 1 || open[0].Total != 1 {
+		t.Fatalf("open sessions = %+v (%v), want 1 session d'un exercice", open, err)
+	}
+	if err := store.EndSession(open[0].ID); err != nil {
+		t.Fatalf("end session: %v", err)
+	}
+
+	// Nombre explicite au-delà du max de l'énergie
+	start = app.get("/session/start?energy=1&count=4&domain=Go&domain=SQL")
+	assertStatus(t, start, http.StatusSeeOther)
+	open, _ = store.GetOpenSessions()
+	if len(open) != 1 || open[0].Total != 4 {
+		t.Errorf("open sessions = %+v, want 4 exercices", open)
+	}
+
+	// Focus incohérent : builder réaffiché avec l'erreur
+	bad := app.get("/session/start?energy=2&min_difficulty=4&max_difficulty=2")
+	assertStatus(t, bad, http.StatusOK)
+	assertContains(t, bad, "Erreur de validation", "difficulty")
+}
+
+func TestSessionBuilderTagFocus(t *testing.T) {
+	app := newTestApp(t)
+	app.seedExercise("Go sans tag", "Go", 2)
+	app.seedExercise("SQL sans tag", "SQL", 2)
+
+	// Tags saisis dans le formulaire : normalisés et persistés
+	rec := app.htmxPost("/exercises/create", url.Values{
+		"title":      {"Worker pool"},
+		"domain":     {"Go"},
+		"difficulty": {"3"},
+		"tags":       {" Concurrence, channels,concurrence "},
+		"content":    {"## Worker pool"},
+	})
+	assertStatus(t, rec, http.StatusOK)
+	id, err := strconv.Atoi(strings.TrimPrefix(rec.Header().Get("HX-Redirect"), "/exercise/"))
+	if err != nil {
+		t.Fatalf("HX-Redirect = %q, want /exercise/{id}", rec.Header().Get("HX-Redirect"))
+	}
+	ex, err := store.FindExercise(id)
+	if err != nil || ex == nil || !slices.Equal(ex.Tags, []string{"concurrence", "channels"}) {
+		t.Fatalf("exercise = %+v (%v), want tags [concurrence channels]", ex, err)
+	}
+
+	builder := app.get("/session/builder")
+	assertStatus(t, builder, http.StatusOK)
+	assertContains(t, builder, `name="tag" value="channels"`, `name="tag" value="concurrence"`)
+
+	// Focus sur un tag : seul l'exercice tagué est retenu
+	start := app.get("/session/start?energy=3&tag=concurrence")
+	assertStatus(t, start, http.StatusSeeOther)
+	if loc := start.Header().Get("Location"); !strings.HasPrefix(loc, fmt.Sprintf("/exercise/%d?", id)) {
+		t.Fatalf("Location = %q, want exercice #%d", loc, id)
+	}
+	open, err := store.GetOpenSessions()
+	if err != nil || len(open) != 1 || open[0].Total != 1 {
+		t.Fatalf("open sessions = %+v (%v), want 1 session d'un exercice", open, err)
+	}
+}
+
+func TestSessionOrdering(t *testing.T) {
+	app := newTestApp(t)
+	domains := map[int]string{}
+	for i, d := range []string{"Go", "Go", "Go", "SQL", "SQL"} {
+		ex := app.seedExercise(fmt.Sprintf("%s %d", d, i+1), d, 2)
+		domains[ex.ID] = d
+	}
+
+	builder := app.get("/session/builder")
+	assertContains(t, builder, `name="ordering"`, `value="interleave_domain"`, "Alterner les domaines")
+
+	start := app.get("/session/start?energy=3&count=5&ordering=interleave_domain")
+	assertStatus(t, start, http.StatusSeeOther)
+	open, err := store.GetOpenSessions()
+	if err != nil || len(open) != 1 || open[0].Ordering != "interleave_domain" {
+		t.Fatalf("open sessions = %+v (%v), want ordering interleave_domain", open, err)
+	}
+
+	// Ordre de passage : domaines alternés tant que possible
+	var got []string
+	for {
+		id, err := store.GetNextSessionExercise(open[0].ID)
+		if err != nil || id == 0 {
+			break
+		}
+		got = append(got, domains[id])
+		if err := store.CompleteSessionExercise(open[0].ID, id, 2, time.Minute); err != nil {
+			t.Fatalf("complete: %v", err)
+		}
+	}
+	if want := []string{"Go", "SQL", "Go", "SQL", "Go"}; !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+
+	bad := app.get("/session/start?energy=2&ordering=alphabetical")
+	assertStatus(t, bad, http.StatusOK)
+	assertContains(t, bad, "Erreur de validation", "ordering")
+}
+
+func TestSessionHistory(t *testing.T) {
+	app := newTestApp(t)
+	app.seedExercise("Channels", "Go", 2)
+	app.seedExercise("Jointures", "SQL", 3)
+
+	// Session micro : deux réponses "Bien" puis fin
+	start := app.get("/session/start?energy=1")
+	first, _ := url.Parse(start.Header().Get("Location"))
+	sessionID := first.Query().Get("session")
+	next := first.String()
+	for range 2 {
+		u, _ := url.Parse(next)
+		rec := app.htmxPost(fmt.Sprintf("%s/review?quality=2&%s", u.Path, u.RawQuery), nil)
+		assertStatus(t, rec, http.StatusOK)
+		next = rec.Header().Get("HX-Redirect")
+	}
+
+	list := app.get("/sessions")
+	assertStatus(t, list, http.StatusOK)
+	assertContains(t, list, "/session/"+sessionID, "2/2", "SESSIONS / SEMAINE", "QUALITÉ MOYENNE / MODE")
+	assertNotContains(t, app.get("/sessions?mode=deep"), "/session/"+sessionID+`"`)
+
+	detail := app.get("/session/" + sessionID)
+	assertStatus(t, detail, http.StatusOK)
+	assertContains(t, detail, "Channels", "Jointures", "😊 Bien", "nouveau → ")
+
+	assertStatus(t, app.get("/session/9999"), http.StatusNotFound)
+	assertContains(t, app.get("/sessions?mode=turbo"), "Erreur de validation", "mode inconnu")
+	assertContains(t, app.get("/sessions?from=2026-13-01"), "date attendue")
+}
+
+func TestTimedSession(t *testing.T) {
+	app := newTestApp(t)
+	for i := range 4 {
+		app.seedExercise(fmt.Sprintf("Exo %d", i+1), "Go", 2)
+	}
+
+	builder := app.get("/session/builder")
+	assertContains(t, builder, `name="timer"`, `value="25"`)
+
+	start := app.get("/session/start?energy=2&timer=25")
+	assertStatus(t, start, http.StatusSeeOther)
+	first, _ := url.Parse(start.Header().Get("Location"))
+	sessionID := first.Query().Get("session")
+	assertContains(t, app.get(first.String()), "/session/"+sessionID+"/timer")
+
+	timer := app.get("/session/" + sessionID + "/timer")
+	assertStatus(t, timer, http.StatusOK)
+	assertContains(t, timer, "25:00", "BLOC 1", `hx-trigger="every 1s"`)
+
+	// Deux réponses (10 min actives) puis pause de 5 min non décomptée
+	next := first.String()
+	for range 2 {
+		app.clock.Advance(5 * time.Minute)
+		u, _ := url.Parse(next)
+		rec := app.htmxPost(fmt.Sprintf("%s/review?quality=2&%s", u.Path, u.RawQuery), nil)
+		next = rec.Header().Get("HX-Redirect")
+	}
+	if next != "/session/"+sessionID+"/break" {
+		t.Fatalf("redirect = %q, want break", next)
+	}
+	app.clock.Advance(5 * time.Minute)
+	assertStatus(t, app.htmxPost("/session/"+sessionID+"/break/end", nil), http.StatusSeeOther)
+
+	resume := app.get("/session/" + sessionID + "/resume")
+	assertStatus(t, resume, http.StatusSeeOther)
+	assertContains(t, app.get("/session/"+sessionID+"/timer"), "15:00", "BLOC 2")
+
+	// Échéance en plein exercice : fin de session, file restante intacte
+	app.clock.Advance(16 * time.Minute)
+	expired := app.get("/session/" + sessionID + "/timer")
+	assertContains(t, expired, "TEMPS ÉCOULÉ", "/session/"+sessionID+"/timeout")
+
+	timeout := app.htmxPost("/session/"+sessionID+"/timeout", nil)
+	assertStatus(t, timeout, http.StatusOK)
+	if got := timeout.Header().Get("HX-Redirect"); got != "/session/complete?id="+sessionID {
+		t.Fatalf("HX-Redirect = %q, want complete page", got)
+	}
+
+	id, _ := strconv.ParseInt(sessionID, 10, 64)
+	nextID, err := store.GetNextSessionExercise(id)
+	if err != nil || nextID == 0 {
+		t.Fatalf("next exercise = %d (%v), want unfinished exercise kept", nextID, err)
+	}
+	ex, err := store.FindExercise(nextID)
+	if err != nil || ex.LastReviewed != nil {
+		t.Errorf("unfinished exercise reviewed: %+v (%v)", ex, err)
+	}
+
+	result, err := store.GetSessionResult(id)
+	if err != nil || result.CompletedCount != 2 || result.Duration != 26*time.Minute {
+		t.Errorf("result = %+v (%v), want 2 exercises in 26 active minutes", result, err)
+	}
+	assertStatus(t, app.get("/session/"+sessionID+"/timer"), 286)
+	assertContains(t, app.get("/session/"+sessionID), "26 min actives", "2 bloc(s)", "minuteur 25 min")
+}
+
+func TestEnergyAdapter(t *testing.T) {
+	app := newTestApp(t)
+	for i := range 8 {
+		app.seedExercise(fmt.Sprintf("Exo %d", i+1), "Go", 2)
+	}
+	assertContains(t, app.get("/session/builder"), "pas encore assez de sessions")
+
+	start := app.get("/session/start?energy=3")
+	first, _ := url.Parse(start.Header().Get("Location"))
+	sessionID := first.Query().Get("session")
+
+	// Qualités 3 → 2 → 1 : passage en énergie moyenne, file 5 → 2
+	next := first.String()
+	for _, quality := range []int{3, 2, 1} {
+		u, _ := url.Parse(next)
+		rec := app.htmxPost(fmt.Sprintf("%s/review?quality=%d&%s", u.Path, quality, u.RawQuery), nil)
+		assertStatus(t, rec, http.StatusOK)
+		next = rec.Header().Get("HX-Redirect")
+	}
+	// Le template deep reste celui de la session : sa pause après 3 exercices s'applique
+	if next != "/session/"+sessionID+"/break" {
+		t.Fatalf("redirect = %q, want deep template break", next)
+	}
+
+	id, _ := strconv.ParseInt(sessionID, 10, 64)
+	open, err := store.GetOpenSessions()
+	if err != nil || len(open) != 1 || open[0].Total != 5 || open[0].Mode != "deep" {
+		t.Fatalf("open sessions = %+v (%v), want 5 exercises, mode deep kept", open, err)
+	}
+	assertContains(t, app.get("/session/"+sessionID), "énergie abaissée (Élevé → Moyen)")
+
+	exercises, err := store.GetSessionExercises(id)
+	if err != nil {
+		t.Fatalf("session exercises: %v", err)
+	}
+	kept := map[int]bool{}
+	for _, ex := range exercises {
+		kept[ex.ExerciseID] = true
+	}
+	for i := 1; i <= 8; i++ {
+		if kept[i] {
+			continue
+		}
+		if ex, err := store.FindExercise(i); err != nil || ex.LastReviewed != nil {
+			t.Errorf("dropped exercise %d touched: %+v (%v)", i, ex, err)
+		}
+	}
+}
+
+func TestSessionTemplates(t *testing.T) {
+	app := newTestApp(t)
+	for i := range 3 {
+		app.seedExercise(fmt.Sprintf("SQL %d", i+1), "SQL", 2)
+		app.seedExercise(fmt.Sprintf("Go %d", i+1), "Go", 2)
+	}
+	assertContains(t, app.get("/templates"), "Micro", "Standard", "Deep", "intégré")
+
+	form := url.Values{
+		"slug": {"sql-sprint"}, "name": {"Sprint SQL"}, "energy_level": {"1"},
+		"duration": {"10"}, "max_exercises": {"2"}, "break_every": {"1"}, "break_schedule": {"2"},
+		"ordering": {"priority"}, "domains": {"SQL"},
+	}
+	created := app.htmxPost("/templates", form)
+	assertStatus(t, created, http.StatusOK)
+	if got := created.Header().Get("HX-Redirect"); got != "/templates" {
+		t.Fatalf("HX-Redirect = %q, want /templates", got)
+	}
+	assertContains(t, app.htmxPost("/templates", form), "identifiant déjà utilisé")
+	form.Set("slug", "Bad Slug")
+	assertContains(t, app.htmxPost("/templates", form), "slug")
+
+	assertContains(t, app.get("/session/builder"), "Sprint SQL", `value="sql-sprint"`)
+	assertContains(t, app.get("/session/start?template=nope"), "Template de session inconnu")
+
+	// Session depuis le template : domaines et maximum du template, pause après chaque exercice
+	start := app.get("/session/start?template=sql-sprint")
+	assertStatus(t, start, http.StatusSeeOther)
+	first, _ := url.Parse(start.Header().Get("Location"))
+	sessionID := first.Query().Get("session")
+	id, _ := strconv.ParseInt(sessionID, 10, 64)
+
+	exercises, err := store.GetSessionExercises(id)
+	if err != nil || len(exercises) != 2 {
+		t.Fatalf("session exercises = %+v (%v), want 2", exercises, err)
+	}
+	for _, ex := range exercises {
+		if ex.Domain != "SQL" {
+			t.Errorf("exercise %d in domain %s, want SQL", ex.ExerciseID, ex.Domain)
+		}
+	}
+	review := app.htmxPost(fmt.Sprintf("%s/review?quality=3&%s", first.Path, first.RawQuery), nil)
+	if got := review.Header().Get("HX-Redirect"); got != "/session/"+sessionID+"/break" {
+		t.Fatalf("HX-Redirect = %q, want break", got)
+	}
+	assertContains(t, app.get("/session/"+sessionID+"/break"), "02:00")
+
+	// Template utilisé : modifiable, pas supprimable
+	templates, err := store.ListTemplates()
+	if err != nil || len(templates) != 4 {
+		t.Fatalf("templates = %+v (%v), want 4", templates, err)
+	}
+	custom := templates[3]
+	form.Set("name", "Sprint SQL du soir")
+	assertStatus(t, app.htmxPost(fmt.Sprintf("/templates/%d", custom.ID), form), http.StatusOK)
+	assertContains(t, app.get("/templates"), "Sprint SQL du soir", "sql-sprint")
+	assertContains(t, app.htmxPost(fmt.Sprintf("/templates/%d/delete", custom.ID), nil), "utilisé par 1 session")
+	assertContains(t, app.htmxPost(fmt.Sprintf("/templates/%d/delete", templates[0].ID), nil), "non supprimable")
+
+	// Template jamais utilisé : supprimé
+	form.Set("slug", "jamais")
+	form.Set("name", "Jamais servi")
+	assertStatus(t, app.htmxPost("/templates", form), http.StatusOK)
+	unused, ok := session.GetTemplate("jamais")
+	if !ok {
+		t.Fatal("template jamais not loaded")
+	}
+	deleted := app.htmxPost(fmt.Sprintf("/templates/%d/delete", unused.ID), nil)
+	if got := deleted.Header().Get("HX-Redirect"); got != "/templates" {
+		t.Fatalf("delete HX-Redirect = %q, want /templates", got)
+	}
+	assertNotContains(t, app.get("/session/builder"), `value="jamais"`)
+}
+
+func TestPracticeSession(t *testing.T) {
+	app := newTestApp(t)
+	reviewed := app.seedExercise("Mutex", "Go", 2)
+	fresh := app.seedExercise("Index", "SQL", 2)
+
+	// Mutex révisé "Facile" : plus dû avant 4 jours
+	assertStatus(t, app.htmxPost(fmt.Sprintf("/exercise/%d/review?quality=3", reviewed.ID), nil), http.StatusOK)
+	before, err := store.FindExercise(reviewed.ID)
+	if err != nil {
+		t.Fatalf("find exercise: %v", err)
+	}
+
+	assertContains(t, app.get("/session/builder"), `name="practice"`, "Entraînement")
+
+	// Entraînement : exercices non dus inclus, réponses "À revoir"
+	start := app.get("/session/start?energy=3&practice=on")
+	assertStatus(t, start, http.StatusSeeOther)
+	next := start.Header().Get("Location")
+	sessionID := ""
+	answered := 0
+	for !strings.HasPrefix(next, "/session/complete") {
+		if answered > 2 {
+			t.Fatalf("session sans fin (dernier redirect %q)", next)
+		}
+		u, _ := url.Parse(next)
+		sessionID = u.Query().Get("session")
+		rec := app.htmxPost(fmt.Sprintf("%s/review?quality=0&%s", u.Path, u.RawQuery), nil)
+		assertStatus(t, rec, http.StatusOK)
+		next = rec.Header().Get("HX-Redirect")
+		answered++
+	}
+	if answered != 2 {
+		t.Errorf("%d exercice(s) répondus, want 2 (exercices non dus inclus)", answered)
+	}
+
+	// Planification SRS inchangée
+	after, _ := store.FindExercise(reviewed.ID)
+	if !after.NextReviewAt.Equal(before.NextReviewAt) || after.Repetitions != before.Repetitions ||
+		after.EaseFactor != before.EaseFactor {
+		t.Errorf("SRS modifié par l'entraînement : %+v → %+v", before, after)
+	}
+	if untouched, _ := store.FindExercise(fresh.ID); untouched.LastReviewed != nil || untouched.Done {
+		t.Errorf("exercice neuf modifié : LastReviewed=%v Done=%v", untouched.LastReviewed, untouched.Done)
+	}
+
+	done := app.get(next)
+	assertStatus(t, done, http.StatusOK)
+	assertContains(t, done, "Entraînement terminé !", "Résultats d'entraînement", "0%")
+
+	// Drill-down : la review libre de Mutex juste avant n'est pas attribuée à l'entraînement
+	detail := app.get("/session/" + sessionID)
+	assertContains(t, detail, "🎯 entraînement", "Mutex")
+	assertNotContains(t, detail, "nouveau → ")
+}
@@ -0,0 +1,126 @@
+package handlers_test
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"maestro/internal/config"
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/clock"
+	"maestro/internal/handlers"
+	"maestro/internal/models"
+	"maestro/internal/service"
+	"maestro/internal/store"
+)
+
+// ============================================
+// HARNESS : Routes réelles + SQLite en mémoire
+// ============================================
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard) // Les handlers loggent chaque requête
+	os.Exit(m.Run())
+}
+
+type testApp struct {
+	t       *testing.T
+	handler http.Handler
+	clock   *clock.Fixed
+}
+
+// newTestApp : Base neuve + horloge figée (UTC, 10h) pour chaque test
+func newTestApp(t *testing.T) *testApp {
+	t.Helper()
+
+	if err := store.InitDB(store.MemoryDSN); err != nil {
+		t.Fatalf("init db: %v", err)
+	}
+	t.Cleanup(func() { store.CloseDB() })
+
+	clk := clock.NewFixed(time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC))
+	previous := calendar.Current()
+	calendar.Configure(calendar.Calendar{Location: time.UTC, Clock: clk})
+	t.Cleanup(func() { calendar.Configure(previous) })
+
+	// Abonnements branchés par main en production
+	t.Cleanup(handlers.SubscribeDashboardCache())
+	t.Cleanup(service.NewAchievementService().Subscribe())
+
+	return &testApp{t: t, handler: config.Routes(), clock: clk}
+}
+
+// do : Exécute une requête sur le routeur complet
+func (a *testApp) do(req *http.Request) *httptest.ResponseRecorder {
+	a.t.Helper()
+	rec := httptest.NewRecorder()
+	a.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// get : GET classique (navigation)
+func (a *testApp) get(path string) *httptest.ResponseRecorder {
+	return a.do(httptest.NewRequest(http.MethodGet, path, nil))
+}
+
+// htmxPost : POST formulaire envoyé par HTMX
+func (a *testApp) htmxPost(path string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("HX-Request", "true")
+	return a.do(req)
+}
+
+// seedExercise : Fixture exercice neuf (dû aujourd'hui)
+func (a *testApp) seedExercise(title, domain string, difficulty int) models.Exercise {
+	a.t.Helper()
+	ex := models.Exercise{
+		Title:      title,
+		Domain:     domain,
+		Difficulty: difficulty,
+		Steps:      []string{"Lire", "Coder"},
+		Content:    "# " + title,
+		EaseFactor: 2.5,
+	}
+	if err := store.CreateExercise(&ex); err != nil {
+		a.t.Fatalf("seed exercise %q: %v", title, err)
+	}
+	return ex
+}
+
+// ============================================
+// ASSERTIONS
+// ============================================
+
+func assertStatus(t *testing.T, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rec.Code != want {
+		t.Fatalf("status = %d, want %d\n%s", rec.Code, want, rec.Body.String())
+	}
+}
+
+func assertContains(t *testing.T, rec *httptest.ResponseRecorder, fragments ...string) {
+	t.Helper()
+	body := rec.Body.String()
+	for _, f := range fragments {
+		if !strings.Contains(body, f) {
+			t.Errorf("body ne contient pas %q", f)
+		}
+	}
+}
+
+func assertNotContains(t *testing.T, rec *httptest.ResponseRecorder, fragments ...string) {
+	t.Helper()
+	body := rec.Body.String()
+	for _, f := range fragments {
+		if strings.Contains(body, f) {
+			t.Errorf("body contient %q", f)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/session"
+	"maestro/internal/models"
+	"maestro/internal/service"
+	"maestro/internal/views/pages"
+)
+
+// ============================================
+// SERVICE GLOBAL
+// ============================================
+
+var historyService *service.HistoryService
+
+func init() {
+	historyService = service.NewHistoryService()
+}
+
+// historyWeeks : Semaines du graphique "sessions par semaine"
+const historyWeeks = 12
+
+// ============================================
+// 1️⃣ HISTORIQUE (/sessions)
+// ============================================
+
+func HandleSessionHistory(w http.ResponseWriter, r *http.Request) {
+	errMsg := ""
+	filter, err := parseHistoryFilter(r)
+	var page models.SessionHistoryPage
+	if err == nil {
+		page, err = historyService.GetHistory(filter)
+	}
+
+	var invalid *session.InvalidHistoryFilterError
+	if errors.As(err, &invalid) {
+		log.Printf("⚠️ Invalid history filter: %v", invalid)
+		errMsg = invalid.Error()
+		page, err = historyService.GetHistory(models.SessionHistoryFilter{})
+	}
+	if err != nil {
+		log.Printf("❌ GetHistory failed: %v", err)
+		http.Error(w, "Erreur serveur", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.SessionHistoryPage(page, historyService.GetStats(historyWeeks), errMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		log.Printf("❌ Render error: %v", err)
+		http.Error(w, "Erreur affichage", http.StatusInternalServerError)
+	}
+}
+
+// parseHistoryFilter : mode, from / to (AAAA-MM-JJ), page (vide = aucun filtre)
+func parseHistoryFilter(r *http.Request) (models.SessionHistoryFilter, error) {
+	q := r.URL.Query()
+	filter := models.SessionHistoryFilter{Mode: strings.TrimSpace(q.Get("mode"))}
+
+	if p := strings.TrimSpace(q.Get("page")); p != "" {
+		page, err := strconv.Atoi(p)
+		if err != nil {
+			return filter, &session.InvalidHistoryFilterError{Field: "page", Reason: "nombre attendu"}
+		}
+		filter.Page = page
+	}
+
+	var err error
+	if filter.From, err = parseDayParam(q.Get("from")); err != nil {
+		return filter, &session.InvalidHistoryFilterError{Field: "from", Reason: "date attendue (AAAA-MM-JJ)"}
+	}
+	if filter.To, err = parseDayParam(q.Get("to")); err != nil {
+		return filter, &session.InvalidHistoryFilterError{Field: "to", Reason: "date attendue (AAAA-MM-JJ)"}
+	}
+	return filter, nil
+}
+
+// parseDayParam : Jour utilisateur "AAAA-MM-JJ" (vide = zéro)
+func parseDayParam(v string) (time.Time, error) {
+	if v = strings.TrimSpace(v); v == "" {
+		return time.Time{}, nil
+	}
+	return calendar.Current().ParseDay(v)
+}
+
+// ============================================
+// 2️⃣ DÉTAIL (/session/{id})
+// ============================================
+
+func HandleSessionDetail(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		log.Printf("❌ Invalid session ID: %s", r.PathValue("id"))
+		http.Error(w, "ID de session invalide", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := historyService.GetDetail(sessionID)
+	var notFound *session.SessionNotFoundError
+	if errors.As(err, &notFound) {
+		http.Error(w, "Session introuvable", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("❌ GetDetail failed: %v", err)
+		http.Error(w, "Erreur serveur", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.SessionDetailPage(*detail)
+	if err := component.Render(r.Context(), w); err != nil {
+		log.Printf("❌ Render error: %v", err)
+		http.Error(w, "Erreur affichage", http.StatusInternalServerError)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"maestro/internal/domain/calendar"
 	"maestro/internal/service"
 	"maestro/internal/views/components"
 	"maestro/internal/views/pages"
@@ -26,7 +27,7 @@ func init() {
 // ============================================
 
 func HandlePlannerPage(w http.ResponseWriter, r *http.Request) {
-	today := time.Now()
+	today := calendar.Current().TodayStart()
 
 	log.Printf("🔍 PlannerPage: date=%s", today.Format("2006-01-02"))
 
@@ -53,11 +54,12 @@ func HandlePlannerPage(w http.ResponseWriter, r *http.Request) {
 
 func HandlePlannerDay(w http.ResponseWriter, r *http.Request) {
 	// Parse date
+	cal := calendar.Current()
 	dateStr := r.URL.Query().Get("date")
-	date, err := time.Parse("2006-01-02", dateStr)
+	date, err := cal.ParseDay(dateStr)
 	if err != nil {
 		log.Printf("⚠️ Invalid date '%s', using today", dateStr)
-		date = time.Now()
+		date = cal.TodayStart()
 	}
 
 	log.Printf("🔍 PlannerDay: date=%s", date.Format("2006-01-02"))
@@ -82,11 +84,12 @@ func HandlePlannerDay(w http.ResponseWriter, r *http.Request) {
 
 func HandlePlannerWeek(w http.ResponseWriter, r *http.Request) {
 	// Parse week query param
+	cal := calendar.Current()
 	weekStr := r.URL.Query().Get("week")
 	var startDate time.Time
 
 	if weekStr != "" {
-		parsed, err := time.Parse("2006-01-02", weekStr)
+		parsed, err := cal.ParseDay(weekStr)
 		if err != nil {
 			log.Printf("⚠️ Invalid week date '%s': %v", weekStr, err)
 		} else {
@@ -96,7 +99,7 @@ func HandlePlannerWeek(w http.ResponseWriter, r *http.Request) {
 
 	// Fallback : début de semaine courante (lundi)
 	if startDate.IsZero() {
-		now := time.Now()
+		now := cal.TodayStart()
 		weekday := int(now.Weekday())
 
 		// Dimanche = 0 → 7 (ISO week)
@@ -128,7 +131,8 @@ func HandlePlannerMonth(w http.ResponseWriter, r *http.Request) {
 	yearStr := r.URL.Query().Get("year")
 	monthStr := r.URL.Query().Get("month")
 
-	now := time.Now()
+	cal := calendar.Current()
+	now := cal.TodayStart()
 	year := now.Year()
 	month := now.Month()
 
@@ -142,7 +146,7 @@ func HandlePlannerMonth(w http.ResponseWriter, r *http.Request) {
 	log.Printf("🔍 PlannerMonth: year=%d, month=%d", year, month)
 
 	// Build date for this month
-	currentDate := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+	currentDate := cal.Date(year, month, 1)
 
 	log.Printf("✅ Month view: %s", currentDate.Format("January 2006"))
 
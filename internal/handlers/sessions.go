@@ -1,16 +1,18 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"maestro/internal/domain/session"
 	"maestro/internal/models"
 	"maestro/internal/service"
-	"maestro/internal/store"
+	"maestro/internal/views/components"
 	"maestro/internal/views/pages"
 )
 
@@ -30,16 +32,25 @@ func init() {
 
 func HandleSessionBuilder(w http.ResponseWriter, r *http.Request) {
 	log.Println("🔍 SessionBuilder: show energy selection")
+	focus := session.DefaultFocus()
+	focus.Ordering = "" // Ordre du template choisi
+	renderSessionBuilder(w, r, focus, "")
+}
+
+// renderSessionBuilder : Page builder (focus conservé si erreur de validation)
+func renderSessionBuilder(w http.ResponseWriter, r *http.Request, focus session.Focus, errMsg string) {
+	// Templates de session (intégrés + utilisateur)
+	configs := session.Templates()
 
-	// ✅ Utilise domain configs au lieu de models
-	configs := []session.Config{
-		session.GetConfig(models.EnergyLow),
-		session.GetConfig(models.EnergyMedium),
-		session.GetConfig(models.EnergyHigh),
+	// Durées estimées depuis les temps de réponse réels
+	estimates := make(map[string]time.Duration, len(configs))
+	for _, c := range configs {
+		estimates[c.Mode] = sessionService.EstimateForTemplate(c)
 	}
 
 	// ✅ CHANGEMENT : Render avec templ
-	component := pages.SessionBuilder(configs)
+	domains, tags := sessionService.BuilderOptions()
+	component := pages.SessionBuilder(configs, estimates, sessionService.RecommendEnergy(), domains, tags, sessionService.NewCardStatus(7), focus, errMsg)
 
 	if err := component.Render(r.Context(), w); err != nil {
 		log.Printf("❌ Render error: %v", err)
@@ -47,34 +58,120 @@ func HandleSessionBuilder(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// parseFocus : Critères du formulaire builder (champ vide = valeur du template)
+func parseFocus(r *http.Request, base session.Focus) (session.Focus, error) {
+	q := r.URL.Query()
+	focus := base
+	var domains []string
+	for _, d := range q["domain"] {
+		if d = strings.TrimSpace(d); d != "" {
+			domains = append(domains, d)
+		}
+	}
+	if len(domains) > 0 {
+		focus.Domains = domains
+	}
+	var tags []string
+	for _, t := range q["tag"] {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	if len(tags) > 0 {
+		focus.Tags = tags
+	}
+
+	budget, timer := 0, 0
+	fields := []struct {
+		key string
+		dst *int
+	}{
+		{"min_difficulty", &focus.MinDifficulty},
+		{"max_difficulty", &focus.MaxDifficulty},
+		{"new_ratio", &focus.NewRatio},
+		{"count", &focus.Count},
+		{"budget", &budget},
+		{"timer", &timer},
+	}
+	for _, f := range fields {
+		v := strings.TrimSpace(q.Get(f.key))
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return focus, &session.InvalidFocusError{Field: f.key, Reason: "nombre attendu"}
+		}
+		*f.dst = n
+	}
+	focus.TimeBudget = time.Duration(budget) * time.Minute
+	focus.Timer = time.Duration(timer) * time.Minute
+	if o := strings.TrimSpace(q.Get("ordering")); o != "" {
+		focus.Ordering = session.Ordering(o) // Validé par le domain
+	}
+	focus.Practice = q.Get("practice") == "on"
+	return focus, nil
+}
+
 // ============================================
 // 2️⃣ SESSION START (Démarrage)
 // ============================================
 
-func HandleStartSession(w http.ResponseWriter, r *http.Request) {
-	// 1. PARSE energy (LOGIQUE IDENTIQUE)
-	energyStr := r.URL.Query().Get("energy")
-	energy, err := strconv.Atoi(energyStr)
+// startTemplate : Template demandé (template=slug), sinon template intégré
+// du niveau d'énergie (energy=1..3, moyen par défaut)
+func startTemplate(r *http.Request) (session.Config, bool) {
+	if slug := r.URL.Query().Get("template"); slug != "" {
+		return session.GetTemplate(slug)
+	}
+
+	energy, err := strconv.Atoi(r.URL.Query().Get("energy"))
 	if err != nil || energy < 1 || energy > 3 {
 		energy = 2 // Default medium
 	}
+	return session.GetConfig(models.EnergyLevel(energy)), true
+}
 
-	energyLevel := models.EnergyLevel(energy)
-	log.Printf("🔍 START SESSION: energy=%d", energy)
+func HandleStartSession(w http.ResponseWriter, r *http.Request) {
+	// 1. TEMPLATE (énergie, max exercices, pauses, filtres par défaut)
+	config, ok := startTemplate(r)
+	if !ok {
+		log.Printf("⚠️ Unknown session template: %s", r.URL.Query().Get("template"))
+		renderSessionBuilder(w, r, session.DefaultFocus(), "Template de session inconnu")
+		return
+	}
+	log.Printf("🔍 START SESSION: template=%s energy=%d", config.Mode, config.Level)
+
+	// 2. SÉLECTION : focus du builder (domaines, difficulté, ratio, nombre / budget)
+	focus, err := parseFocus(r, config.BaseFocus())
+	var report models.SessionReport
+	var limitedIDs []int
+	if err == nil {
+		report, limitedIDs, err = sessionService.SelectExercises(config, focus)
+	}
 
-	// 2. RÉCUPÈRE EXERCICES DISPONIBLES (LOGIQUE IDENTIQUE)
-	report, exercises, err := store.GetTodayReport()
+	var invalid *session.InvalidFocusError
+	if errors.As(err, &invalid) {
+		log.Printf("⚠️ Invalid focus: %v", invalid)
+		renderSessionBuilder(w, r, focus, invalid.Error())
+		return
+	}
 	if err != nil {
-		log.Printf("❌ GetTodayReport failed: %v", err)
+		log.Printf("❌ SelectExercises failed: %v", err)
 		http.Error(w, "Erreur serveur", http.StatusInternalServerError)
 		return
 	}
 
-	log.Printf("🔍 [SESSION] Disponibles: %d dus + %d nouveaux = %d total",
-		report.TodayDue, report.TodayNew, len(exercises))
+	log.Printf("🔍 [SESSION] Disponibles: %d dus + %d nouveaux, retenus %d (focus %+v)",
+		report.TodayDue, report.TodayNew, len(limitedIDs), focus)
+
+	// Source optionnelle : file "à risque" (rappel prédit bientôt sous le seuil)
+	if r.URL.Query().Get("source") == "at_risk" {
+		limitedIDs = config.Limit(decayService.AtRiskIDs())
+		log.Printf("🔍 [SESSION] Source à risque: %d exercices", len(limitedIDs))
+	}
 
 	// 3. AUCUN EXERCICE ? Affiche rapport (LOGIQUE IDENTIQUE)
-	if len(exercises) == 0 {
+	if len(limitedIDs) == 0 {
 		component := pages.NoExercisesToday(report)
 		if err := component.Render(r.Context(), w); err != nil {
 			log.Printf("❌ Render error: %v", err)
@@ -83,22 +180,8 @@ func HandleStartSession(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 4. ✅ APPLIQUE LIMITE ÉNERGIE (LOGIQUE IDENTIQUE)
-	exerciseIDs := make([]int, len(exercises))
-	for i, ex := range exercises {
-		exerciseIDs[i] = ex.ID
-	}
-
-	limitedIDs := session.LimitExercises(exerciseIDs, energyLevel)
-
-	log.Printf("🔍 [SESSION] Limité à %d exercices (max=%d pour energy=%d)",
-		len(limitedIDs),
-		session.GetMaxExercises(energyLevel),
-		energy,
-	)
-
 	// 5. CRÉE SESSION (LOGIQUE IDENTIQUE)
-	sessionID, sessionData, err := sessionService.StartSession(energyLevel, limitedIDs)
+	sessionID, sessionData, err := sessionService.StartSession(config, limitedIDs, focus)
 	if err != nil {
 		log.Printf("❌ StartSession failed: %v", err)
 		http.Error(w, "Erreur création session", http.StatusInternalServerError)
@@ -170,6 +253,7 @@ func HandleSessionComplete(w http.ResponseWriter, r *http.Request) {
 		result.Duration.Round(time.Second),
 		result.CompletedAt,
 		result.Exercises,
+		result.PracticeStats,
 	)
 
 	if err := component.Render(r.Context(), w); err != nil {
@@ -191,8 +275,11 @@ func HandleStopSession(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Termine la session (LOGIQUE IDENTIQUE)
-	if err := sessionService.StopSession(sessionID); err != nil {
+	// Termine la session (déjà fermée : rien à faire)
+	var closed *session.SessionClosedError
+	if err := sessionService.StopSession(sessionID); errors.As(err, &closed) {
+		log.Printf("⚠️ Stop ignored: %v", closed)
+	} else if err != nil {
 		log.Printf("❌ StopSession failed: %v", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -201,3 +288,214 @@ func HandleStopSession(w http.ResponseWriter, r *http.Request) {
 	log.Printf("✅ Session %d stopped manually", sessionID)
 	http.Redirect(w, r, "/", http.StatusSeeOther)
 }
+
+// ============================================
+// 5️⃣ SESSION RESUME (Reprise après interruption)
+// ============================================
+
+func HandleResumeSession(w http.ResponseWriter, r *http.Request) {
+	sessionIDStr := r.PathValue("id")
+	sessionID, err := strconv.ParseInt(sessionIDStr, 10, 64)
+	if err != nil {
+		log.Printf("❌ Invalid session ID: %s", sessionIDStr)
+		http.Error(w, "ID de session invalide", http.StatusBadRequest)
+		return
+	}
+
+	// Pause en cours : l'écran de pause d'abord
+	if brk, err := sessionService.GetOpenBreak(sessionID); err == nil && brk != nil {
+		http.Redirect(w, r, fmt.Sprintf("/session/%d/break", sessionID), http.StatusSeeOther)
+		return
+	}
+
+	exerciseID, err := sessionService.ResumeSession(sessionID)
+
+	var notFound *session.SessionNotFoundError
+	var closed *session.SessionClosedError
+	switch {
+	case errors.As(err, &notFound):
+		http.Error(w, "Session introuvable", http.StatusNotFound)
+		return
+	case errors.As(err, &closed):
+		// Terminée ou abandonnée entre-temps : retour au dashboard
+		log.Printf("⚠️ Resume refused: %v", closed)
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	case err != nil:
+		log.Printf("❌ ResumeSession failed: %v", err)
+		http.Error(w, "Erreur reprise session", http.StatusInternalServerError)
+		return
+	}
+
+	// Tous les exercices étaient faits : session terminée par ResumeSession
+	if exerciseID == 0 {
+		http.Redirect(w, r, fmt.Sprintf("/session/complete?id=%d", sessionID), http.StatusSeeOther)
+		return
+	}
+
+	log.Printf("▶️ Session %d resumed → exo #%d", sessionID, exerciseID)
+	http.Redirect(w, r, fmt.Sprintf("/exercise/%d?from=session&session=%d", exerciseID, sessionID), http.StatusSeeOther)
+}
+
+// ============================================
+// 6️⃣ SESSION BREAK (Pause imposée)
+// ============================================
+
+func HandleSessionBreak(w http.ResponseWriter, r *http.Request) {
+	sessionID, brk, ok := openBreak(w, r)
+	if !ok {
+		return
+	}
+	if brk == nil {
+		http.Redirect(w, r, fmt.Sprintf("/session/%d/resume", sessionID), http.StatusSeeOther)
+		return
+	}
+
+	component := pages.SessionBreakPage(*brk)
+	if err := component.Render(r.Context(), w); err != nil {
+		log.Printf("❌ Render error: %v", err)
+		http.Error(w, "Erreur affichage", http.StatusInternalServerError)
+	}
+}
+
+// HandleBreakTimer : Fragment HTMX du compte à rebours
+func HandleBreakTimer(w http.ResponseWriter, r *http.Request) {
+	sessionID, brk, ok := openBreak(w, r)
+	if !ok {
+		return
+	}
+	if brk == nil {
+		// Pause close ailleurs (autre onglet) : retour à la session
+		w.Header().Set("HX-Redirect", fmt.Sprintf("/session/%d/resume", sessionID))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	component := components.BreakTimer(*brk)
+	if err := component.Render(r.Context(), w); err != nil {
+		log.Printf("❌ Render error: %v", err)
+		http.Error(w, "Erreur affichage", http.StatusInternalServerError)
+	}
+}
+
+// HandleEndBreak : Fin de pause (refusée tant que le compte à rebours court)
+func HandleEndBreak(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "ID de session invalide", http.StatusBadRequest)
+		return
+	}
+
+	err = sessionService.EndBreak(sessionID)
+	var notOver *session.BreakNotOverError
+	switch {
+	case errors.As(err, &notOver):
+		log.Printf("⚠️ Break not over: %v", notOver)
+		http.Redirect(w, r, fmt.Sprintf("/session/%d/break", sessionID), http.StatusSeeOther)
+		return
+	case err != nil:
+		log.Printf("❌ EndBreak failed: %v", err)
+		http.Error(w, "Erreur pause", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("☕ Session %d: break over", sessionID)
+	http.Redirect(w, r, fmt.Sprintf("/session/%d/resume", sessionID), http.StatusSeeOther)
+}
+
+// HandleSkipBreak : Pause écourtée (comptée dans analytics)
+func HandleSkipBreak(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "ID de session invalide", http.StatusBadRequest)
+		return
+	}
+
+	if err := sessionService.SkipBreak(sessionID); err != nil {
+		log.Printf("❌ SkipBreak failed: %v", err)
+		http.Error(w, "Erreur pause", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("⏭ Session %d: break skipped", sessionID)
+	http.Redirect(w, r, fmt.Sprintf("/session/%d/resume", sessionID), http.StatusSeeOther)
+}
+
+// openBreak : ID de session + pause en cours (ok = false si réponse déjà écrite)
+func openBreak(w http.ResponseWriter, r *http.Request) (int64, *models.SessionBreak, bool) {
+	sessionID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "ID de session invalide", http.StatusBadRequest)
+		return 0, nil, false
+	}
+
+	brk, err := sessionService.GetOpenBreak(sessionID)
+	if err != nil {
+		log.Printf("❌ GetOpenBreak failed: %v", err)
+		http.Error(w, "Erreur pause", http.StatusInternalServerError)
+		return 0, nil, false
+	}
+	return sessionID, brk, true
+}
+
+// ============================================
+// 7️⃣ SESSION TIMER (Mode concentration chronométré)
+// ============================================
+
+// HandleSessionTimer : Fragment HTMX du minuteur (286 = arrêt du polling si
+// la session n'est pas chronométrée ou déjà terminée)
+func HandleSessionTimer(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "ID de session invalide", http.StatusBadRequest)
+		return
+	}
+
+	timer, err := sessionService.GetTimer(sessionID)
+	var notFound *session.SessionNotFoundError
+	if errors.As(err, &notFound) {
+		http.Error(w, "Session introuvable", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("❌ GetTimer failed: %v", err)
+		http.Error(w, "Erreur minuteur", http.StatusInternalServerError)
+		return
+	}
+	if timer.Budget == 0 || timer.Closed {
+		w.WriteHeader(286)
+		return
+	}
+
+	component := components.FocusTimer(*timer)
+	if err := component.Render(r.Context(), w); err != nil {
+		log.Printf("❌ Render error: %v", err)
+		http.Error(w, "Erreur affichage", http.StatusInternalServerError)
+	}
+}
+
+// HandleSessionTimeout : Fin de session à l'échéance du minuteur (exercices
+// restants laissés dans la file)
+func HandleSessionTimeout(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "ID de session invalide", http.StatusBadRequest)
+		return
+	}
+
+	expired, err := sessionService.ExpireIfDue(sessionID)
+	if err != nil {
+		log.Printf("❌ ExpireIfDue failed: %v", err)
+		http.Error(w, "Erreur minuteur", http.StatusInternalServerError)
+		return
+	}
+	if !expired {
+		// Pas encore écoulé (horloge client en avance) : le polling continue
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	log.Printf("⏰ Session %d: timer expired", sessionID)
+	w.Header().Set("HX-Redirect", fmt.Sprintf("/session/complete?id=%d", sessionID))
+	w.WriteHeader(http.StatusOK)
+}
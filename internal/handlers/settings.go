@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"maestro/internal/models"
+	"maestro/internal/service"
+	"maestro/internal/views/components"
+	"maestro/internal/views/pages"
+)
+
+// ============================================
+// SERVICE GLOBAL
+// ============================================
+
+var settingsService *service.SettingsService
+
+func init() {
+	settingsService = service.NewSettingsService()
+}
+
+// ============================================
+// 1️⃣ PAGE RÉGLAGES
+// ============================================
+
+func HandleSettingsPage(w http.ResponseWriter, r *http.Request) {
+	saved := r.URL.Query().Get("saved") == "1"
+
+	component := pages.SettingsPage(settingsService.GetSettings(), saved)
+
+	if err := component.Render(r.Context(), w); err != nil {
+		log.Printf("❌ Render error: %v", err)
+		http.Error(w, "Erreur affichage", http.StatusInternalServerError)
+	}
+}
+
+// ============================================
+// 2️⃣ ACTION : Enregistrer les réglages
+// ============================================
+
+func HandleSettingsUpdate(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		log.Printf("❌ Parse form error: %v", err)
+		http.Error(w, "Erreur formulaire", http.StatusBadRequest)
+		return
+	}
+
+	rolloverHour, err := strconv.Atoi(r.FormValue("day_rollover_hour"))
+	if err != nil {
+		rolloverHour = -1 // Rejeté par la validation domain
+	}
+
+	goalTarget, err := strconv.Atoi(r.FormValue("daily_goal_target"))
+	if err != nil {
+		goalTarget = 0 // Rejeté par la validation domain
+	}
+
+	atRiskRecall, err := strconv.Atoi(r.FormValue("at_risk_recall"))
+	if err != nil {
+		atRiskRecall = 0 // Rejeté par la validation domain
+	}
+
+	atRiskDays, err := strconv.Atoi(r.FormValue("at_risk_days"))
+	if err != nil {
+		atRiskDays = 0 // Rejeté par la validation domain
+	}
+
+	idleMin, err := strconv.Atoi(r.FormValue("session_idle_minutes"))
+	if err != nil {
+		idleMin = 0 // Rejeté par la validation domain
+	}
+
+	newCardsPerDay, err := strconv.Atoi(r.FormValue("new_cards_per_day"))
+	if err != nil {
+		newCardsPerDay = -1 // Rejeté par la validation domain
+	}
+
+	newCardRatio, err := strconv.Atoi(r.FormValue("new_card_ratio"))
+	if err != nil {
+		newCardRatio = -1 // Rejeté par la validation domain
+	}
+
+	settings := models.UserSettings{
+		Timezone:        r.FormValue("timezone"),
+		DayRolloverHour: rolloverHour,
+		DailyGoalKind:   r.FormValue("daily_goal_kind"),
+		DailyGoalTarget: goalTarget,
+		ReportDir:       r.FormValue("report_dir"),
+		AtRiskRecall:    atRiskRecall,
+		AtRiskDays:      atRiskDays,
+		SessionIdleMin:  idleMin,
+		NewCardsPerDay:  newCardsPerDay,
+		NewCardRatio:    newCardRatio,
+		EnergyAdapter:   r.FormValue("energy_adapter") == "on",
+	}
+
+	if err := settingsService.UpdateSettings(settings); err != nil {
+		log.Printf("❌ UpdateSettings error: %v", err)
+
+		component := components.FormError(err.Error())
+		if renderErr := component.Render(r.Context(), w); renderErr != nil {
+			http.Error(w, "Erreur réglages", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	log.Printf("✅ Settings updated: tz=%s, rollover=%dh, goal=%d %s",
+		settings.Timezone, settings.DayRolloverHour, settings.DailyGoalTarget, settings.DailyGoalKind)
+
+	w.Header().Set("HX-Redirect", "/settings?saved=1")
+	w.WriteHeader(http.StatusOK)
+}
@@ -5,9 +5,12 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"maestro/internal/domain/exercise"
+	"maestro/internal/domain/session"
 	"maestro/internal/domain/srs"
+	"maestro/internal/models"
 	"maestro/internal/store"
 	"maestro/internal/views/components"
 	"maestro/internal/views/pages"
@@ -37,47 +40,128 @@ func HandleReview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 4. Applique algorithme SRS (LOGIQUE IDENTIQUE)
-	ex, err := exerciseService.ReviewExercise(id, srs.ReviewQuality(quality))
-	if err != nil {
-		log.Printf("❌ ReviewExercise error: %v", err)
-		http.Error(w, "Erreur serveur", http.StatusInternalServerError)
-		return
+	// 4. Temps de réponse (session uniquement : affichage horodaté)
+	var sessionID int64
+	var duration time.Duration
+	if fromSession && sessionIDStr != "" {
+		sessionID, _ = strconv.ParseInt(sessionIDStr, 10, 64)
+		duration = sessionService.AnswerDuration(sessionID, id)
 	}
 
-	log.Printf("✅ Review applied: ease=%.2f, nextReview=%s",
-		ex.EaseFactor, ex.NextReviewAt.Format("2006-01-02"))
+	// 5. Entraînement : réponse enregistrée dans la session uniquement
+	// (ni mise à jour SRS, ni progress_log, ni statut done)
+	practice := false
+	if sessionID != 0 {
+		var err error
+		if practice, err = sessionService.IsPractice(sessionID); err != nil {
+			// Pas de repli sur le SRS : une réponse d'entraînement ne doit jamais le modifier
+			log.Printf("❌ IsPractice error: %v", err)
+			http.Error(w, "Erreur serveur", http.StatusInternalServerError)
+			return
+		}
+	}
 
-	// 5. Marque DONE si quality >= 1 (LOGIQUE IDENTIQUE)
-	if quality >= 1 {
-		ex.Done = true
-		if err := store.SaveExercise(ex); err != nil {
-			log.Printf("❌ SaveExercise error: %v", err)
-			http.Error(w, "Erreur sauvegarde", http.StatusInternalServerError)
+	var ex *models.Exercise
+	if practice {
+		var err error
+		if ex, err = store.FindExercise(id); err != nil {
+			log.Printf("❌ FindExercise error: %v", err)
+			http.Error(w, "Erreur serveur", http.StatusInternalServerError)
 			return
 		}
-		log.Printf("✅ Exercise marked DONE")
+		log.Printf("🎯 Practice answer: quality=%d, SRS untouched", quality)
+	} else {
+		// Applique algorithme SRS (LOGIQUE IDENTIQUE)
+		var err error
+		ex, err = exerciseService.ReviewExercise(id, srs.ReviewQuality(quality), duration)
+		if err != nil {
+			log.Printf("❌ ReviewExercise error: %v", err)
+			http.Error(w, "Erreur serveur", http.StatusInternalServerError)
+			return
+		}
+
+		log.Printf("✅ Review applied: ease=%.2f, nextReview=%s, duration=%v",
+			ex.EaseFactor, ex.NextReviewAt.Format("2006-01-02"), duration)
+
+		// 6. Marque DONE si quality >= 1 (LOGIQUE IDENTIQUE)
+		if quality >= 1 {
+			ex.Done = true
+			if err := store.SaveExercise(ex); err != nil {
+				log.Printf("❌ SaveExercise error: %v", err)
+				http.Error(w, "Erreur sauvegarde", http.StatusInternalServerError)
+				return
+			}
+			log.Printf("✅ Exercise marked DONE")
+		}
 	}
 
-	// 6. MODE SESSION : Flow exercice suivant (LOGIQUE IDENTIQUE)
+	// 7. MODE SESSION : Flow exercice suivant (LOGIQUE IDENTIQUE)
 	if fromSession && sessionIDStr != "" {
-		sessionID, _ := strconv.ParseInt(sessionIDStr, 10, 64)
-
 		log.Printf("🔄 Session mode: sessionID=%d", sessionID)
 
+		// Onglet périmé : session terminée ou abandonnée entre-temps
+		status, err := store.GetSessionStatus(sessionID)
+		if err != nil {
+			log.Printf("❌ GetSessionStatus error: %v", err)
+			http.Error(w, "Erreur serveur", http.StatusInternalServerError)
+			return
+		}
+		if status != session.StatusActive {
+			log.Printf("⚠️ Review on %s session %d: not recorded in session", status, sessionID)
+			redirect := "/"
+			if status == session.StatusCompleted {
+				redirect = fmt.Sprintf("/session/complete?id=%d", sessionID)
+			}
+			w.Header().Set("HX-Redirect", redirect)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
 		// a) Enregistre dans session
-		if err := sessionService.CompleteExercise(sessionID, id, quality); err != nil {
+		if err := sessionService.CompleteExercise(sessionID, id, quality, duration); err != nil {
 			log.Printf("❌ CompleteExercise error: %v", err)
 		} else {
 			log.Printf("✅ Exercise completed in session")
 		}
 
-		// b) Prochain exercice
+		// b) Minuteur écoulé : fin de session, la suite de la file reste intacte
+		if expired, err := sessionService.ExpireIfDue(sessionID); err != nil {
+			log.Printf("❌ ExpireIfDue error: %v", err)
+		} else if expired {
+			log.Printf("⏰ Session %d: timer expired", sessionID)
+			w.Header().Set("HX-Redirect", fmt.Sprintf("/session/complete?id=%d", sessionID))
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		// c) Qualité en chute : énergie abaissée, file raccourcie
+		if adapted, err := sessionService.AdaptIfDeclining(sessionID); err != nil {
+			log.Printf("❌ AdaptIfDeclining error: %v", err)
+		} else if adapted != nil {
+			log.Printf("📉 Session %d: energy %d → %d, %d exercise(s) dropped",
+				sessionID, adapted.From, adapted.To, adapted.Dropped)
+		}
+
+		// d) Prochain exercice
 		nextEx, err := sessionService.GetNextExercise(sessionID)
 		if err != nil {
 			log.Printf("❌ GetNextExercise error: %v", err)
 		}
 
+		// e) Pause imposée par la règle d'énergie (avant l'exercice suivant)
+		if nextEx != nil {
+			brk, err := sessionService.BreakIfDue(sessionID)
+			if err != nil {
+				log.Printf("❌ BreakIfDue error: %v", err)
+			}
+			if brk != nil {
+				log.Printf("☕ Break %v after %d exercises", brk.Planned, brk.AfterCount)
+				w.Header().Set("HX-Redirect", fmt.Sprintf("/session/%d/break", sessionID))
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+
 		if nextEx != nil {
 			// → Redirection HTMX vers exercice suivant
 			redirectURL := fmt.Sprintf("/exercise/%d?from=session&session=%d",
@@ -101,7 +185,7 @@ func HandleReview(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// 7. MODE LIBRE : soit fragment HTMX, soit full page
+	// 8. MODE LIBRE : soit fragment HTMX, soit full page
 	log.Println("🔄 Free mode, reload detail")
 
 	// Requête HTMX ? (clic sur bouton Review avec hx-post)
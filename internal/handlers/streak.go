@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/service"
+	"maestro/internal/views/components"
+)
+
+// ============================================
+// SERVICE GLOBAL
+// ============================================
+
+var streakService *service.StreakService
+
+func init() {
+	streakService = service.NewStreakService()
+}
+
+// ============================================
+// 1️⃣ ACTION : Planifier un jour de congé
+// ============================================
+
+func HandleAddStreakFreeze(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		log.Printf("❌ Parse form error: %v", err)
+		http.Error(w, "Erreur formulaire", http.StatusBadRequest)
+		return
+	}
+
+	errMsg := ""
+	day, err := calendar.Current().ParseDay(r.FormValue("day"))
+	if err == nil {
+		err = streakService.AddFreeze(day)
+	}
+	if err != nil {
+		log.Printf("❌ AddFreeze error: %v", err)
+		errMsg = err.Error()
+	} else {
+		log.Printf("❄️ Streak freeze planned: %s", day.Format("2006-01-02"))
+	}
+
+	renderGoalProgress(w, r, errMsg)
+}
+
+// ============================================
+// 2️⃣ ACTION : Annuler un jour de congé
+// ============================================
+
+func HandleDeleteStreakFreeze(w http.ResponseWriter, r *http.Request) {
+	errMsg := ""
+	day, err := calendar.Current().ParseDay(r.PathValue("day"))
+	if err == nil {
+		err = streakService.RemoveFreeze(day)
+	}
+	if err != nil {
+		log.Printf("❌ RemoveFreeze error: %v", err)
+		errMsg = err.Error()
+	}
+
+	renderGoalProgress(w, r, errMsg)
+}
+
+// renderGoalProgress : Fragment HTMX de la carte objectif
+func renderGoalProgress(w http.ResponseWriter, r *http.Request, errMsg string) {
+	component := components.GoalProgressCard(streakService.GetGoalProgress(), errMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		log.Printf("❌ Render error: %v", err)
+		http.Error(w, "Erreur affichage", http.StatusInternalServerError)
+	}
+}
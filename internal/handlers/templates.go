@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"maestro/internal/domain/session"
+	"maestro/internal/models"
+	"maestro/internal/service"
+	"maestro/internal/views/components"
+	"maestro/internal/views/pages"
+)
+
+// ============================================
+// SERVICE GLOBAL
+// ============================================
+
+var templateService *service.TemplateService
+
+func init() {
+	templateService = service.NewTemplateService()
+}
+
+// ============================================
+// 1️⃣ LISTE DES TEMPLATES
+// ============================================
+
+func HandleTemplatesPage(w http.ResponseWriter, r *http.Request) {
+	templates, usage, err := templateService.ListTemplates()
+	if err != nil {
+		log.Printf("❌ ListTemplates failed: %v", err)
+		http.Error(w, "Erreur serveur", http.StatusInternalServerError)
+		return
+	}
+
+	if err := pages.SessionTemplatesPage(templates, usage).Render(r.Context(), w); err != nil {
+		log.Printf("❌ Render error: %v", err)
+		http.Error(w, "Erreur affichage", http.StatusInternalServerError)
+	}
+}
+
+// ============================================
+// 2️⃣ CRÉATION
+// ============================================
+
+func HandleTemplateNew(w http.ResponseWriter, r *http.Request) {
+	// Valeurs de départ : template standard
+	base := session.GetConfig(models.EnergyMedium)
+	base.ID, base.Mode, base.Name, base.Description, base.Builtin = 0, "", "", "", false
+
+	if err := pages.SessionTemplateForm(base).Render(r.Context(), w); err != nil {
+		log.Printf("❌ Render error: %v", err)
+		http.Error(w, "Erreur affichage", http.StatusInternalServerError)
+	}
+}
+
+func HandleTemplateCreate(w http.ResponseWriter, r *http.Request) {
+	config, err := parseTemplateForm(r)
+	if err == nil {
+		_, err = templateService.CreateTemplate(config)
+	}
+	if err != nil {
+		renderTemplateError(w, r, err)
+		return
+	}
+
+	log.Printf("✅ Session template created: %s", config.Mode)
+	w.Header().Set("HX-Redirect", "/templates")
+	w.WriteHeader(http.StatusOK)
+}
+
+// ============================================
+// 3️⃣ ÉDITION
+// ============================================
+
+func HandleTemplateEdit(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "ID invalide", http.StatusBadRequest)
+		return
+	}
+
+	config, err := templateService.GetTemplate(id)
+	if err != nil {
+		log.Printf("❌ Template #%d not found: %v", id, err)
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := pages.SessionTemplateForm(config).Render(r.Context(), w); err != nil {
+		log.Printf("❌ Render error: %v", err)
+		http.Error(w, "Erreur affichage", http.StatusInternalServerError)
+	}
+}
+
+func HandleTemplateUpdate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "ID invalide", http.StatusBadRequest)
+		return
+	}
+
+	config, err := parseTemplateForm(r)
+	if err == nil {
+		err = templateService.UpdateTemplate(id, config)
+	}
+	if err != nil {
+		renderTemplateError(w, r, err)
+		return
+	}
+
+	log.Printf("✅ Session template #%d updated", id)
+	w.Header().Set("HX-Redirect", "/templates")
+	w.WriteHeader(http.StatusOK)
+}
+
+// ============================================
+// 4️⃣ SUPPRESSION
+// ============================================
+
+func HandleTemplateDelete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "ID invalide", http.StatusBadRequest)
+		return
+	}
+
+	if err := templateService.DeleteTemplate(id); err != nil {
+		renderTemplateError(w, r, err)
+		return
+	}
+
+	log.Printf("🗑️ Session template #%d deleted", id)
+	w.Header().Set("HX-Redirect", "/templates")
+	w.WriteHeader(http.StatusOK)
+}
+
+// ============================================
+// 🔧 HELPERS
+// ============================================
+
+// renderTemplateError : Erreur métier affichée dans le formulaire, sinon 500
+func renderTemplateError(w http.ResponseWriter, r *http.Request, err error) {
+	var invalid *session.InvalidTemplateError
+	var notFound *session.TemplateNotFoundError
+	var inUse *session.TemplateInUseError
+	var builtin *session.BuiltinTemplateError
+	switch {
+	case errors.As(err, &notFound):
+		http.NotFound(w, r)
+		return
+	case errors.As(err, &invalid), errors.As(err, &inUse), errors.As(err, &builtin):
+		log.Printf("⚠️ Session template rejected: %v", err)
+	default:
+		log.Printf("❌ Session template error: %v", err)
+		http.Error(w, "Erreur serveur", http.StatusInternalServerError)
+		return
+	}
+
+	if renderErr := components.FormError(err.Error()).Render(r.Context(), w); renderErr != nil {
+		http.Error(w, "Erreur affichage", http.StatusInternalServerError)
+	}
+}
+
+// parseTemplateForm : Formulaire template → session.Config (validé par le domain)
+func parseTemplateForm(r *http.Request) (session.Config, error) {
+	config := session.Config{
+		Mode:        r.FormValue("slug"),
+		Name:        r.FormValue("name"),
+		Description: r.FormValue("description"),
+		Ordering:    session.Ordering(r.FormValue("ordering")),
+		Domains:     strings.Split(r.FormValue("domains"), ","),
+		NewRatio:    session.RatioAuto,
+	}
+
+	var level, duration int
+	fields := []struct {
+		key string
+		dst *int
+	}{
+		{"energy_level", &level},
+		{"duration", &duration},
+		{"max_exercises", &config.MaxExercises},
+		{"break_every", &config.BreakEvery},
+		{"min_difficulty", &config.MinDifficulty},
+		{"max_difficulty", &config.MaxDifficulty},
+		{"new_ratio", &config.NewRatio},
+	}
+	for _, f := range fields {
+		v := strings.TrimSpace(r.FormValue(f.key))
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return config, &session.InvalidTemplateError{Field: f.key, Reason: "nombre attendu"}
+		}
+		*f.dst = n
+	}
+	config.Level = models.EnergyLevel(level)
+	config.Duration = time.Duration(duration) * time.Minute
+
+	// Pauses : minutes séparées par des virgules ("5, 10, 15")
+	for _, v := range strings.Split(r.FormValue("break_schedule"), ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return config, &session.InvalidTemplateError{Field: "break_schedule", Reason: "minutes séparées par des virgules"}
+		}
+		config.BreakSchedule = append(config.BreakSchedule, time.Duration(n)*time.Minute)
+	}
+	return config, nil
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// ============================================
+// SUCCÈS (achievements)
+// ============================================
+
+// Achievement : Succès du catalogue (débloqué ou non)
+type Achievement struct {
+	Key         string // "streak_30", "domain_mature:Go"
+	Title       string
+	Description string
+	Icon        string
+	Scope       string // Domaine pour les succès par domaine
+	Unlocked    bool
+	UnlockedAt  time.Time
+}
+
+// AchievementSummary : Carte dashboard
+type AchievementSummary struct {
+	Unlocked int
+	Total    int // Succès du catalogue (un succès par domaine compte une fois)
+	Recent   []Achievement
+}
@@ -7,15 +7,13 @@ import "time"
 type DashboardStats struct {
 	// Core metrics
 	TotalExercises    int
-	CompletedCount    int
 	InProgressCount   int
 	TodoCount         int
 	OverdueCount      int
-	TotalMastered     int
 	StreakDays        int
+	LongestStreak     int
 	WeeklyReviews     int
 	AvgSessionTime    time.Duration
-	CompletionRate    int
 	AverageInterval   int
 	NextReviewDate    time.Time
 	TotalSessionTime  time.Duration
@@ -24,9 +22,14 @@ type DashboardStats struct {
 	TopDomain         string
 	DomainBreakdown   map[string]int
 
+	// Maîtrise (intervalle + rappel prédit, cf. srs.Mastery)
+	Mastery      MasteryCounts
+	MasteredRate int // % d'exercices à l'état mastered
+	MasteryScore int // % pondéré par état (new 0 → mastered 100)
+
 	// Advanced analytics
 	AverageEaseFactor float64
-	RetentionRate     int // % de reviews réussies (quality >= 2)
+	RetentionRate     int // % de reviews réussies (quality >= 2, 30d)
 
 	// ✅ NEW: Learning velocity
 	TotalReviews     int     // Total reviews (30d)
@@ -39,6 +42,31 @@ type DashboardStats struct {
 	WeakestDomainCount     int
 	ShortIntervalCount     int // Exercises with interval < 7d
 	LowEaseCount           int // Exercises with ease < 2.3
+
+	// Historique réel (progress_log)
+	LapseCount int // Reviews oubliées (quality 0, 30d)
+}
+
+// ExerciseTotals - Agrégats SQL sur la table exercises
+type ExerciseTotals struct {
+	Total         int
+	InProgress    int
+	Todo          int
+	Overdue       int
+	AvgDifficulty float64
+	AvgInterval   int
+	AvgEase       float64
+	ShortInterval int
+	LowEase       int
+	NextReviewDay int // YYYYMMDD (0 si aucune)
+}
+
+// ReviewTotals - Agrégats SQL sur progress_log (fenêtre 30j)
+type ReviewTotals struct {
+	Reviews    int // Toutes les reviews de la fenêtre
+	Successful int // quality >= 2
+	Lapses     int // quality = 0
+	LastWeek   int // Reviews des 7 derniers jours
 }
 
 // FailurePattern - Pattern d'échecs répétés
@@ -63,6 +91,144 @@ type DomainStrength struct {
 	Name            string
 	TotalCount      int
 	MasteredCount   int
+	Mastery         MasteryCounts
 	AvgEaseFactor   float64
 	StrengthPercent int
 }
+
+// MasteryCounts - Nombre d'exercices par état de maîtrise
+type MasteryCounts struct {
+	New      int
+	Learning int
+	Young    int
+	Mature   int
+	Mastered int
+}
+
+// RetentionBucket - Rappel observé vs prédit pour une tranche d'intervalle
+type RetentionBucket struct {
+	Label     string
+	Reviews   int     // Paires (review précédente → review) dans la tranche
+	Observed  float64 // Part de rappels réussis (0-1)
+	Predicted float64 // Rappel moyen prédit par le scheduler (0-1)
+}
+
+// RetentionCurve - Courbe de rétention (filtrable par domaine/difficulté)
+type RetentionCurve struct {
+	Domain     string // "" = tous
+	Difficulty int    // 0 = toutes
+	Buckets    []RetentionBucket
+	TotalPairs int
+}
+
+// RecallPairGroup - Paires de reviews agrégées (heures écoulées, intervalle prévu)
+type RecallPairGroup struct {
+	ElapsedHours int
+	PrevInterval int
+	Count        int
+	Recalled     int // Reviews suivantes réussies (quality >= 1)
+}
+
+// GoalProgress - Objectif quotidien + streak persisté
+type GoalProgress struct {
+	Kind          string // "reviews" | "minutes"
+	Target        int
+	Done          int // Avancement du jour (même unité que Target)
+	Percent       int // 0-100
+	Met           bool
+	CurrentStreak int
+	LongestStreak int
+	FrozenToday   bool
+	Freezes       []time.Time // Gels planifiés (aujourd'hui inclus)
+}
+
+// SlotStat - Performance d'une heure ou d'un jour de semaine
+type SlotStat struct {
+	Label          string
+	Reviews        int
+	SuccessRate    float64 // quality >= 2 (0-1)
+	AvgResponseSec int     // Temps de réponse moyen (progress_log.duration_sec)
+}
+
+// TimePerformance - Résultat du job d'analyse horaire (analytics)
+type TimePerformance struct {
+	Hours           []SlotStat // 0h → 23h (heure locale)
+	Weekdays        []SlotStat // Lundi → dimanche (jour utilisateur)
+	BestSlot        string     // "09h-12h" ("" si données insuffisantes)
+	BestSlotRate    float64
+	BestWeekday     string
+	OverallRate     float64
+	DifficultyRates map[int]float64 // difficulty_success_rate recalibré
+	AnalyzedAt      time.Time
+}
+
+// ExerciseTiming - Temps de réponse moyen d'un exercice (progress_log.duration_sec)
+type ExerciseTiming struct {
+	ID       int
+	Title    string
+	Domain   string
+	TotalSec int
+	AvgSec   int
+	Samples  int
+}
+
+// DomainTiming - Temps investi par domaine
+type DomainTiming struct {
+	Domain   string
+	Reviews  int // Reviews chronométrées
+	TotalSec int
+	AvgSec   int
+}
+
+// TimeInvestment - Temps par exercice / domaine (dashboard)
+type TimeInvestment struct {
+	AvgAnswerSec int
+	Timed        int // Reviews chronométrées
+	Slowest      []ExerciseTiming
+	Domains      []DomainTiming
+}
+
+// AtRiskExercise - Exercice mature dont le rappel prédit va passer sous le seuil
+type AtRiskExercise struct {
+	ID           int
+	Title        string
+	Domain       string
+	IntervalDays int
+	Recall       float64   // Rappel prédit maintenant (0-1)
+	CrossesAt    time.Time // Passage sous le seuil
+	Overdue      bool      // Échéance SRS déjà dépassée
+}
+
+// AtRiskQueue - File "à risque" + politique appliquée
+type AtRiskQueue struct {
+	Threshold   int // %
+	HorizonDays int
+	Total       int              // Avant limite
+	Exercises   []AtRiskExercise // Plus urgents d'abord
+}
+
+// DashboardSnapshot - Toutes les données de la page dashboard (mises en cache)
+type DashboardSnapshot struct {
+	Stats           DashboardStats
+	TodayReviews    []Exercise
+	OverdueReviews  []Exercise
+	UpcomingReviews []Exercise
+	Retention       RetentionCurve
+	Goal            GoalProgress
+	TimeSlots       TimePerformance
+	Timing          TimeInvestment
+	AtRisk          AtRiskQueue
+	Achievements    AchievementSummary
+	BuiltAt         time.Time
+}
+
+// CacheMetrics - Compteurs du cache dashboard
+type CacheMetrics struct {
+	Hits          int64     `json:"hits"`
+	Misses        int64     `json:"misses"`
+	Invalidations int64     `json:"invalidations"`
+	HitRate       int       `json:"hit_rate"` // %
+	LastBuildMs   int64     `json:"last_build_ms"`
+	BuiltAt       time.Time `json:"built_at"`
+	Cached        bool      `json:"cached"` // Snapshot valide en mémoire
+}
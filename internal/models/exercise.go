@@ -20,6 +20,7 @@ type Exercise struct {
 	Domain      string   `json:"domain"`
 	Difficulty  int      `json:"difficulty"`
 	Steps       []string `json:"steps"`
+	Tags        []string `json:"tags"`
 	Content     string   `json:"content"`
 
 	// Visuels pédagogiques (nouveau format structuré)
@@ -0,0 +1,86 @@
+// internal/models/history.go
+package models
+
+import "time"
+
+// ============================================
+// HISTORIQUE DES SESSIONS
+// ============================================
+
+// SessionHistoryFilter : Filtres de /sessions (valeur zéro = aucun filtre)
+type SessionHistoryFilter struct {
+	Mode string    // "micro" | "standard" | "deep"
+	From time.Time // Début de jour inclus
+	To   time.Time // Début de jour inclus (jusqu'à la fin de ce jour)
+	Page int       // À partir de 1
+}
+
+// SessionSummary : Ligne de l'historique
+type SessionSummary struct {
+	ID          int64
+	StartedAt   time.Time
+	EndedAt     *time.Time
+	Energy      EnergyLevel
+	Mode        string
+	Ordering    string
+	Status      string // "active" | "completed" | "abandoned"
+	Completed   int
+	Total       int
+	Duration    time.Duration // Temps actif enregistré à la fermeture (0 si ouverte)
+	AvgQuality  float64       // Moyenne des qualités (0-3), 0 si aucune review
+	TimeBudget  time.Duration // Minuteur (0 = non chronométrée)
+	FocusBlocks int           // Blocs de concentration
+	AdaptedFrom EnergyLevel   // Énergie de départ si abaissée en cours de session (0 sinon)
+	Practice    bool          // Entraînement (planification SRS inchangée)
+}
+
+// SessionHistoryPage : Page de l'historique filtré
+type SessionHistoryPage struct {
+	Sessions   []SessionSummary
+	Filter     SessionHistoryFilter
+	Total      int
+	TotalPages int
+}
+
+// SessionExerciseDetail : Exercice d'une session (drill-down)
+type SessionExerciseDetail struct {
+	ExerciseID     int
+	Title          string
+	Domain         string
+	Position       int
+	Completed      bool
+	Quality        int
+	Duration       time.Duration // 0 si non mesurée
+	HasSRS         bool          // Review retrouvée dans progress_log
+	FirstReview    bool          // Aucune review antérieure
+	IntervalBefore int
+	IntervalAfter  int
+	EaseBefore     float64
+	EaseAfter      float64
+}
+
+// SessionDetail : Page /session/{id}
+type SessionDetail struct {
+	Summary   SessionSummary
+	Exercises []SessionExerciseDetail
+}
+
+// WeeklySessions : Sessions d'une semaine (graphique)
+type WeeklySessions struct {
+	WeekStart time.Time
+	Sessions  int
+}
+
+// ModeQuality : Qualité moyenne des reviews par mode (graphique)
+type ModeQuality struct {
+	Mode       string
+	Sessions   int
+	Reviews    int
+	AvgQuality float64
+}
+
+// SessionHistoryStats : Agrégats affichés au-dessus de l'historique
+type SessionHistoryStats struct {
+	Weeks []WeeklySessions
+	Modes []ModeQuality
+}
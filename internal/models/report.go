@@ -0,0 +1,50 @@
+// internal/models/report.go
+package models
+
+import "time"
+
+// ============================================
+// RAPPORT DE PROGRESSION (hebdo / mensuel)
+// ============================================
+
+// ProgressReport : Résumé écrit d'une période terminée
+type ProgressReport struct {
+	Kind        string // "weekly" | "monthly"
+	Label       string // "2026-W15" / "2026-03"
+	From        time.Time
+	To          time.Time // Exclu
+	GeneratedAt time.Time
+
+	// Activité
+	Reviews       int
+	Lapses        int
+	RetentionRate int // % quality >= 2
+	ActiveDays    int
+	PeriodDays    int
+	TimeSpent     time.Duration // Sessions terminées dans la période
+	CurrentStreak int
+	LongestStreak int
+
+	// Comparaison avec la période précédente
+	PrevReviews       int
+	PrevRetentionRate int
+
+	// Apprentissage
+	NewLearnedCount int
+	NewLearned      []Exercise // Premiers exemples (titre, domaine)
+
+	// Points faibles
+	WeakestDomains []DomainStrength
+	Leeches        []FailurePattern
+
+	// Charge à venir (7 prochains jours)
+	OverdueCount  int
+	Upcoming      []WorkloadDay
+	UpcomingTotal int
+}
+
+// WorkloadDay : Révisions prévues un jour donné
+type WorkloadDay struct {
+	Date  time.Time
+	Count int
+}
@@ -24,8 +24,10 @@ type AdaptiveSession struct {
 	ID            int64
 	Mode          string
 	EnergyLevel   EnergyLevel
+	Ordering      string // Stratégie d'enchaînement (session.Ordering)
 	EstimatedTime time.Duration
-	Exercises     []int // IDs des exercices
+	TimeBudget    time.Duration // Minuteur (0 = non chronométrée)
+	Exercises     []int         // IDs des exercices
 	BreakSchedule []time.Duration
 	StartedAt     time.Time
 	CurrentIndex  int
@@ -38,8 +40,83 @@ type SessionResult struct {
 	CompletedCount int
 	Duration       time.Duration
 	CompletedAt    time.Time
-	Exercises      []int       // IDs des exercices complétés
-	Qualities      map[int]int // exerciseID → quality
+	Exercises      []int            // IDs des exercices complétés
+	Qualities      map[int]int      // exerciseID → quality
+	Practice       bool             // Entraînement : planification SRS inchangée
+	PracticeStats  *PracticeSummary // Bilan d'entraînement (nil hors entraînement)
+}
+
+// PracticeSummary : Bilan d'une session d'entraînement (rapporté à part)
+type PracticeSummary struct {
+	Reviewed   int
+	Successes  int     // Qualité ≥ Bien
+	AvgQuality float64 // 0-3
+	ByQuality  [4]int  // Échec, Difficile, Bien, Facile
+}
+
+// SessionBreak : Pause imposée entre deux exercices
+type SessionBreak struct {
+	ID         int64
+	SessionID  int64
+	AfterCount int // Exercices complétés au début de la pause
+	Planned    time.Duration
+	StartedAt  time.Time
+	EndedAt    *time.Time
+	Skipped    bool
+	Remaining  time.Duration // Calculé (service)
+}
+
+// SessionTimer : Minuteur d'une session (mode concentration chronométré)
+type SessionTimer struct {
+	SessionID      int64
+	Budget         time.Duration // 0 = session non chronométrée
+	Active         time.Duration // Temps actif (blocs clos + bloc en cours)
+	Remaining      time.Duration // Calculé (service)
+	Blocks         int           // Blocs de concentration commencés
+	BlockStartedAt time.Time     // Zéro = aucun bloc ouvert (pause)
+	LastActivity   time.Time
+	Closed         bool // Session terminée
+}
+
+// EnergyFactor : Contribution d'un critère à la recommandation (-1 fatigue … +1 forme)
+type EnergyFactor struct {
+	Name   string
+	Score  float64
+	Detail string
+}
+
+// EnergyRecommendation : Niveau d'énergie conseillé dans le builder
+type EnergyRecommendation struct {
+	Level     EnergyLevel
+	Score     float64 // -1 … +1
+	Factors   []EnergyFactor
+	Confident bool // Assez de sessions récentes pour conseiller
+	Sessions  int  // Sessions analysées
+}
+
+// SessionAdaptation : Passage à un niveau d'énergie inférieur en cours de session
+type SessionAdaptation struct {
+	From    EnergyLevel
+	To      EnergyLevel
+	Dropped int // Exercices retirés de la file
+}
+
+// BuilderDomain : Domaine ou tag proposé dans le session builder (disponibles aujourd'hui)
+type BuilderDomain struct {
+	Name string
+	Due  int // Révisions dues ou en retard
+	New  int // Jamais révisés
+}
+
+// OpenSession : Session non terminée (reprise, fermeture après inactivité)
+type OpenSession struct {
+	ID           int64
+	Mode         string
+	Ordering     string // Stratégie d'enchaînement (session.Ordering)
+	StartedAt    time.Time
+	LastActivity time.Time // Début, dernier affichage ou dernière review
+	Completed    int
+	Total        int
 }
 
 // ============================================
@@ -49,12 +126,30 @@ type SessionResult struct {
 // SessionReport : Rapport de disponibilité des exercices
 type SessionReport struct {
 	TodayDue        int              `json:"today_due"`
-	TodayNew        int              `json:"today_new"`
+	TodayNew        int              `json:"today_new"`      // Nouveaux encore introductibles aujourd'hui
+	NewQueued       int              `json:"new_queued"`     // File complète des jamais révisés
+	NewIntroduced   int              `json:"new_introduced"` // Nouveaux déjà introduits aujourd'hui
+	NewLimit        int              `json:"new_limit"`      // Quota quotidien
 	TotalAvailable  int              `json:"total_available"`
 	NextReviewDate  time.Time        `json:"next_review_date"`
 	UpcomingReviews []UpcomingReview `json:"upcoming_reviews"`
 }
 
+// NewCardDay : Nouveaux introduits un jour donné
+type NewCardDay struct {
+	Date       time.Time `json:"date"`
+	Introduced int       `json:"introduced"`
+}
+
+// NewCardStatus : Quota de nouveaux du jour (session builder)
+type NewCardStatus struct {
+	Introduced int          `json:"introduced"`
+	Limit      int          `json:"limit"`
+	Ratio      int          `json:"ratio"`  // Part visée en mode "Auto" (%)
+	Queued     int          `json:"queued"` // Jamais révisés en attente
+	History    []NewCardDay `json:"history"`
+}
+
 // UpcomingReview : Exercice à réviser dans le futur
 type UpcomingReview struct {
 	Date          time.Time `json:"date"`
@@ -0,0 +1,20 @@
+package models
+
+// ============================================
+// RÉGLAGES UTILISATEUR
+// ============================================
+
+// UserSettings : Réglages éditables depuis /settings
+type UserSettings struct {
+	Timezone        string // Nom IANA ("Europe/Paris") ou "Local"
+	DayRolloverHour int    // Heure (0-23) à laquelle un nouveau jour commence
+	DailyGoalKind   string // "reviews" | "minutes"
+	DailyGoalTarget int    // Seuil d'un "jour de streak"
+	ReportDir       string // Dossier des rapports planifiés ("" = désactivé)
+	AtRiskRecall    int    // Seuil de rappel surveillé (%)
+	AtRiskDays      int    // Horizon d'alerte "à risque" (jours)
+	SessionIdleMin  int    // Inactivité avant abandon automatique d'une session (minutes)
+	NewCardsPerDay  int    // Nouveaux exercices introduits max par jour
+	NewCardRatio    int    // Part de nouveaux (%) en mode "Auto" du builder
+	EnergyAdapter   bool   // Abaisse l'énergie en cours de session si la qualité chute
+}
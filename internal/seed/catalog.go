@@ -0,0 +1,47 @@
+// internal/seed/catalog.go
+package seed
+
+import (
+	"math/rand/v2"
+
+	"maestro/internal/models"
+)
+
+// ============================================
+// CATALOGUE (Domaines + sujets réalistes)
+// ============================================
+
+type domainTopics struct {
+	name   string
+	topics []string
+}
+
+var domains = []domainTopics{
+	{"Go", []string{"Goroutines", "Channels", "Context", "Interfaces", "Generics", "Error wrapping", "sync.Pool", "Escape analysis"}},
+	{"Algorithms", []string{"Binary search", "Dijkstra", "Union-Find", "Dynamic programming", "Topological sort", "Sliding window", "Heap", "Trie"}},
+	{"Database", []string{"B-tree index", "Isolation levels", "WAL", "Query planner", "Normalisation", "Window functions", "MVCC"}},
+	{"Architecture", []string{"Hexagonal", "CQRS", "Event sourcing", "Circuit breaker", "Saga", "Bounded context"}},
+	{"Security", []string{"CSRF", "XSS", "JWT", "OAuth2 PKCE", "SQL injection", "Argon2"}},
+	{"DevOps", []string{"Dockerfile multi-stage", "Kubernetes probes", "Blue/green", "Terraform state", "CI cache"}},
+	{"Frontend", []string{"HTMX swaps", "CSS grid", "Event delegation", "Web components", "Accessibility"}},
+	{"Networking", []string{"TCP handshake", "TLS 1.3", "HTTP/2 streams", "DNS resolution", "Load balancing"}},
+}
+
+// successRate : Probabilité de réussite initiale par difficulté
+// (aligné sur analytics.difficulty_success_rate)
+var successRate = map[int]float64{1: 0.95, 2: 0.85, 3: 0.70, 4: 0.50, 5: 0.30}
+
+var energyLabels = map[models.EnergyLevel]string{
+	models.EnergyLow:    "low",
+	models.EnergyMedium: "medium",
+	models.EnergyHigh:   "high",
+}
+
+func topicFor(domain string, rng *rand.Rand) string {
+	for _, d := range domains {
+		if d.name == domain {
+			return d.topics[rng.IntN(len(d.topics))]
+		}
+	}
+	return domain
+}
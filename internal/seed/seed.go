@@ -0,0 +1,399 @@
+// internal/seed/seed.go
+package seed
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/clock"
+	"maestro/internal/domain/session"
+	"maestro/internal/domain/srs"
+	"maestro/internal/domain/streak"
+	"maestro/internal/models"
+)
+
+// ============================================
+// GÉNÉRATEUR DE COLLECTIONS SYNTHÉTIQUES
+// ============================================
+
+// Config : Paramètres de génération (mêmes paramètres → même base)
+type Config struct {
+	Seed      uint64
+	Exercises int       // Nombre d'exercices
+	Reviews   int       // Nombre cible de révisions (progress_log)
+	Years     int       // Profondeur d'historique
+	Until     time.Time // Fin de l'historique (jour exclu)
+}
+
+// DefaultConfig : 10k exercices, 500k révisions sur 3 ans
+func DefaultConfig(until time.Time) Config {
+	return Config{
+		Seed:      42,
+		Exercises: 10_000,
+		Reviews:   500_000,
+		Years:     3,
+		Until:     until,
+	}
+}
+
+// Stats : Résumé de ce qui a été écrit
+type Stats struct {
+	Exercises int
+	Reviews   int
+	Sessions  int
+	Days      int
+}
+
+// card : État SRS simulé d'un exercice
+type card struct {
+	id           int
+	domain       string
+	difficulty   int
+	createdDay   int
+	introduced   bool
+	lastReviewed time.Time
+	lastQuality  int
+	nextDay      int
+	ease         float64
+	interval     int
+	reps         int
+}
+
+// Generate : Remplit une base vide (schema déjà appliqué) en rejouant
+// l'historique jour par jour avec le vrai scheduler SRS.
+func Generate(db *sql.DB, cal calendar.Calendar, cfg Config) (Stats, error) {
+	if cfg.Exercises <= 0 || cfg.Years <= 0 {
+		return Stats{}, fmt.Errorf("seed: exercises et years doivent être > 0")
+	}
+
+	rng := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed^0x9e3779b97f4a7c15))
+	end := cal.DayStart(cfg.Until)
+	start := end.AddDate(-cfg.Years, 0, 0)
+	days := int(end.Sub(start).Hours()/24 + 0.5)
+
+	cards := makeCards(rng, cfg.Exercises, days)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return Stats{}, fmt.Errorf("begin seed: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Une session est écrite à sa fin, après ses lignes : FK vérifiées au commit
+	if _, err := tx.Exec("PRAGMA defer_foreign_keys = ON"); err != nil {
+		return Stats{}, fmt.Errorf("defer foreign keys: %w", err)
+	}
+
+	w, err := newWriter(tx)
+	if err != nil {
+		return Stats{}, err
+	}
+	defer w.close()
+
+	stats := Stats{Exercises: len(cards), Days: days}
+	if err := w.insertExercises(rng, cards, cal, start); err != nil {
+		return Stats{}, err
+	}
+
+	// Budget quotidien moyen (±40%, 10% de jours off) pour atteindre la cible
+	dailyBudget := float64(cfg.Reviews) / (float64(days) * 0.9)
+	due := make(map[int][]*card) // jour → cartes dues
+	var backlog []*card          // cartes en retard non traitées
+	clk := clock.NewFixed(start)
+	newCursor := 0
+	var streakState streak.State // Objectif par défaut : 1 review/jour
+
+	for day := range days {
+		dayStart := cal.Date(start.Year(), start.Month(), start.Day()+day)
+
+		// Cartes dues aujourd'hui + retard accumulé
+		backlog = append(backlog, due[day]...)
+		delete(due, day)
+
+		budget := int(dailyBudget * (0.6 + 0.8*rng.Float64()))
+		if rng.Float64() < 0.1 {
+			budget = 0 // Jour off
+		}
+
+		// Complète avec des nouveaux exercices déjà créés
+		for len(backlog) < budget && newCursor < len(cards) && cards[newCursor].createdDay <= day {
+			c := cards[newCursor]
+			c.introduced = true
+			backlog = append(backlog, c)
+			newCursor++
+		}
+
+		todays := backlog
+		if len(todays) > budget {
+			todays = backlog[:budget]
+		}
+		backlog = backlog[len(todays):]
+		if len(todays) > 0 {
+			streakState = streak.Record(cal, streakState, cal.DayKey(dayStart), nil)
+		}
+
+		// Découpe en sessions (taille selon énergie)
+		cursor := dayStart.Add(time.Duration(7+rng.IntN(3)) * time.Hour)
+		for len(todays) > 0 {
+			energy := models.EnergyLevel(1 + rng.IntN(3))
+			n := min(session.GetMaxExercises(energy), len(todays))
+			batch := todays[:n]
+			todays = todays[n:]
+
+			sessionStart := cursor
+			sessionID := int64(stats.Sessions + 1)
+
+			for pos, c := range batch {
+				// Temps de réponse : plus long sur les exercices difficiles
+				shownAt := cursor
+				cursor = cursor.Add(time.Duration(30*c.difficulty+rng.IntN(180)) * time.Second)
+				clk.Set(cursor)
+				quality := drawQuality(rng, c)
+				result := srs.CalculateNextReview(clk, srs.ReviewQuality(quality), c.interval, c.ease, c.reps)
+
+				c.lastReviewed = cursor
+				c.lastQuality = quality
+				c.ease = result.EaseFactor
+				c.interval = result.IntervalDays
+				c.reps = result.Repetitions
+				c.nextDay = max(day+1, day+result.IntervalDays)
+				due[c.nextDay] = append(due[c.nextDay], c)
+
+				if err := w.insertReview(sessionID, c, pos, quality, shownAt, cursor); err != nil {
+					return Stats{}, err
+				}
+				stats.Reviews++
+			}
+
+			if err := w.insertSession(cal, sessionID, energy, sessionStart, cursor, len(batch)); err != nil {
+				return Stats{}, err
+			}
+			stats.Sessions++
+			cursor = cursor.Add(time.Duration(10+rng.IntN(50)) * time.Minute)
+		}
+	}
+
+	if err := w.flush(); err != nil {
+		return Stats{}, err
+	}
+	if err := w.updateExercises(cards, cal, start); err != nil {
+		return Stats{}, err
+	}
+	if _, err := tx.Exec(`UPDATE analytics SET current_streak = ?, longest_streak = ?, last_goal_day = ? WHERE id = 1`,
+		streakState.Current, streakState.Longest, streakState.LastDay); err != nil {
+		return Stats{}, fmt.Errorf("update streak: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Stats{}, fmt.Errorf("commit seed: %w", err)
+	}
+	return stats, nil
+}
+
+// makeCards : Exercices répartis sur les domaines, créés au fil de l'historique
+func makeCards(rng *rand.Rand, n, days int) []*card {
+	cards := make([]*card, n)
+	for i := range cards {
+		// Moitié de la collection dès le départ, le reste arrive progressivement
+		created := 0
+		if i >= n/2 {
+			created = rng.IntN(days)
+		}
+		domain := domains[rng.IntN(len(domains))]
+		cards[i] = &card{
+			id:         i + 1,
+			domain:     domain.name,
+			difficulty: 1 + rng.IntN(5),
+			createdDay: created,
+			ease:       2.5,
+		}
+	}
+	// Les nouveaux sont introduits par ordre de création
+	sortByCreated(cards)
+	return cards
+}
+
+func sortByCreated(cards []*card) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cards[i].createdDay < cards[j].createdDay
+	})
+}
+
+// drawQuality : Probabilité de succès selon difficulté et ancienneté
+func drawQuality(rng *rand.Rand, c *card) int {
+	base := successRate[c.difficulty]
+	p := base + (1-base)*float64(min(c.reps, 6))/10
+	if rng.Float64() > p {
+		return int(srs.Again)
+	}
+	switch r := rng.Float64(); {
+	case r < 0.25:
+		return int(srs.Hard)
+	case r < 0.8:
+		return int(srs.Good)
+	default:
+		return int(srs.Easy)
+	}
+}
+
+// ============================================
+// ÉCRITURE SQL (INSERT multi-lignes, une transaction)
+// ============================================
+
+// batchRows : Nombre de lignes par INSERT groupé
+const batchRows = 50
+
+// batch : Accumule des lignes pour un INSERT multi-lignes
+type batch struct {
+	prefix string // "INSERT INTO t (a, b) VALUES "
+	row    string // "(?, ?)"
+	args   []any
+	count  int
+}
+
+func (b *batch) add(tx *sql.Tx, values ...any) error {
+	b.args = append(b.args, values...)
+	b.count++
+	if b.count >= batchRows {
+		return b.flush(tx)
+	}
+	return nil
+}
+
+func (b *batch) flush(tx *sql.Tx) error {
+	if b.count == 0 {
+		return nil
+	}
+	query := b.prefix + strings.Repeat(b.row+",", b.count-1) + b.row
+	if _, err := tx.Exec(query, b.args...); err != nil {
+		return fmt.Errorf("%s: %w", b.prefix, err)
+	}
+	b.args = b.args[:0]
+	b.count = 0
+	return nil
+}
+
+type writer struct {
+	tx         *sql.Tx
+	exercises  *batch
+	sessions   *batch
+	sessionEx  *batch
+	progress   *batch
+	finalState *sql.Stmt
+}
+
+func newWriter(tx *sql.Tx) (*writer, error) {
+	finalState, err := tx.Prepare(`UPDATE exercises SET
+            done = ?, last_reviewed_date = ?, next_review_date = ?,
+            ease_factor = ?, interval_days = ?, repetitions = ?, updated_at = ?
+        WHERE id = ?`)
+	if err != nil {
+		return nil, fmt.Errorf("prepare seed statements: %w", err)
+	}
+
+	return &writer{
+		tx: tx,
+		exercises: &batch{
+			prefix: `INSERT INTO exercises (id, title, description, domain, difficulty, content, mnemonic,
+                conceptual_visuals, steps, completed_steps, done, next_review_date,
+                ease_factor, interval_days, repetitions, deleted, created_at, updated_at) VALUES `,
+			row: "(?, ?, ?, ?, ?, ?, '', '[]', ?, '[]', 0, ?, 2.5, 0, 0, 0, ?, ?)",
+		},
+		sessions: &batch{
+			prefix: `INSERT INTO sessions (id, started_at, ended_at, energy_level, mode,
+                completed_count, duration_min, created_at) VALUES `,
+			row: "(?, ?, ?, ?, ?, ?, ?, ?)",
+		},
+		sessionEx: &batch{
+			prefix: `INSERT INTO session_exercises (session_id, exercise_id, position, completed, quality,
+                reviewed_at, shown_at, duration_sec) VALUES `,
+			row: "(?, ?, ?, 1, ?, ?, ?, ?)",
+		},
+		progress: &batch{
+			prefix: `INSERT INTO progress_log (exercise_id, reviewed_at, quality, ease_factor, interval_days,
+                repetitions, duration_sec) VALUES `,
+			row: "(?, ?, ?, ?, ?, ?, ?)",
+		},
+		finalState: finalState,
+	}, nil
+}
+
+func (w *writer) close() {
+	w.finalState.Close()
+}
+
+// flush : Vide tous les lots restants
+func (w *writer) flush() error {
+	for _, b := range []*batch{w.exercises, w.sessions, w.sessionEx, w.progress} {
+		if err := b.flush(w.tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *writer) insertExercises(rng *rand.Rand, cards []*card, cal calendar.Calendar, start time.Time) error {
+	steps, _ := json.Marshal([]string{"Lire l'énoncé", "Implémenter", "Tester les cas limites"})
+	for _, c := range cards {
+		topic := topicFor(c.domain, rng)
+		title := fmt.Sprintf("%s #%d", topic, c.id)
+		created := start.AddDate(0, 0, c.createdDay).Add(time.Duration(rng.IntN(12*3600)) * time.Second)
+
+		err := w.exercises.add(w.tx,
+			c.id, title, "Exercice généré ("+c.domain+")", c.domain, c.difficulty,
+			"## "+title+"\n\nÉnoncé synthétique.", string(steps),
+			cal.DayKey(created), created.Unix(), created.Unix(),
+		)
+		if err != nil {
+			return fmt.Errorf("insert exercise %d: %w", c.id, err)
+		}
+	}
+	return w.exercises.flush(w.tx)
+}
+
+func (w *writer) insertSession(cal calendar.Calendar, id int64, energy models.EnergyLevel, startedAt, endedAt time.Time, completed int) error {
+	err := w.sessions.add(w.tx,
+		id, startedAt.Unix(), endedAt.Unix(), energyLabels[energy], session.GetConfig(energy).Mode,
+		completed, int(endedAt.Sub(startedAt).Minutes()), startedAt.Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("insert session %d: %w", id, err)
+	}
+	return nil
+}
+
+func (w *writer) insertReview(sessionID int64, c *card, position, quality int, shownAt, at time.Time) error {
+	durationSec := int64(at.Sub(shownAt).Seconds())
+	if err := w.sessionEx.add(w.tx, sessionID, c.id, position, quality, at.Unix(), shownAt.Unix(), durationSec); err != nil {
+		return fmt.Errorf("insert session exercise %d: %w", c.id, err)
+	}
+	if err := w.progress.add(w.tx, c.id, at.Unix(), quality, c.ease, c.interval, c.reps, durationSec); err != nil {
+		return fmt.Errorf("insert progress %d: %w", c.id, err)
+	}
+	return nil
+}
+
+// updateExercises : Écrit l'état SRS final de chaque exercice
+func (w *writer) updateExercises(cards []*card, cal calendar.Calendar, start time.Time) error {
+	for _, c := range cards {
+		if c.lastReviewed.IsZero() {
+			continue // Jamais révisé : reste "nouveau"
+		}
+		done := c.lastQuality >= int(srs.Hard)
+		next := cal.DayKey(start.AddDate(0, 0, c.nextDay))
+		_, err := w.finalState.Exec(
+			done, c.lastReviewed.Unix(), next,
+			c.ease, c.interval, c.reps, c.lastReviewed.Unix(), c.id,
+		)
+		if err != nil {
+			return fmt.Errorf("update exercise %d: %w", c.id, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package seed
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/store"
+)
+
+// snapshot : Empreinte de la base générée
+func snapshot(t *testing.T, db *sql.DB) [4]int64 {
+	t.Helper()
+	var s [4]int64
+	err := db.QueryRow(`SELECT
+            (SELECT COUNT(*) FROM progress_log),
+            (SELECT SUM(quality * reviewed_at % 1000003) FROM progress_log),
+            (SELECT SUM(next_review_date + repetitions) FROM exercises),
+            (SELECT COUNT(*) FROM sessions)`).Scan(&s[0], &s[1], &s[2], &s[3])
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	return s
+}
+
+func generate(t *testing.T, seedValue uint64) [4]int64 {
+	t.Helper()
+	if err := store.InitDB(store.MemoryDSN); err != nil {
+		t.Fatalf("init db: %v", err)
+	}
+	defer store.CloseDB()
+
+	cal := calendar.Calendar{Location: time.UTC}
+	cfg := Config{Seed: seedValue, Exercises: 200, Reviews: 5_000, Years: 1, Until: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	stats, err := Generate(store.GetDB(), cal, cfg)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if stats.Reviews < cfg.Reviews*8/10 {
+		t.Errorf("%d révisions, want ~%d", stats.Reviews, cfg.Reviews)
+	}
+	return snapshot(t, store.GetDB())
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	a := generate(t, 7)
+	b := generate(t, 7)
+	if a != b {
+		t.Fatalf("même seed, bases différentes: %v vs %v", a, b)
+	}
+	if c := generate(t, 8); c == a {
+		t.Fatalf("seeds différentes, bases identiques: %v", c)
+	}
+}
@@ -0,0 +1,228 @@
+// internal/service/achievement.go
+package service
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"maestro/internal/domain/achievement"
+	"maestro/internal/domain/calendar"
+	"maestro/internal/events"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+// AchievementService : Succès débloqués à partir des événements métier
+type AchievementService struct{}
+
+func NewAchievementService() *AchievementService {
+	return &AchievementService{}
+}
+
+// Subscribe : Branche les règles sur le bus (retourne le désabonnement)
+func (s *AchievementService) Subscribe() func() {
+	unsubscribe := []func(){
+		events.Subscribe(events.ReviewRecorded, s.onReview),
+		events.Subscribe(events.SessionEnded, s.onSessionEnded),
+		events.Subscribe(events.ExerciseCreated, s.onExerciseCreated),
+	}
+	return func() {
+		for _, u := range unsubscribe {
+			u()
+		}
+	}
+}
+
+// CheckAll : Rattrapage des succès mérités avant l'abonnement (démarrage)
+func (s *AchievementService) CheckAll() {
+	now := calendar.Current().Now()
+	s.checkReviews(now)
+	s.checkStreak(now)
+	s.checkLibrary(now)
+	s.checkDomains("", now)
+}
+
+// ============================================
+// ABONNÉS
+// ============================================
+
+func (s *AchievementService) onReview(e events.Event) {
+	s.checkReviews(e.At)
+	s.checkStreak(e.At)
+	s.checkDomains(e.Domain, e.At)
+}
+
+func (s *AchievementService) onSessionEnded(e events.Event) {
+	s.checkStreak(e.At) // Objectif en minutes : atteint en fin de session
+	s.checkPerfectSession(e.SessionID, e.At)
+}
+
+func (s *AchievementService) onExerciseCreated(e events.Event) {
+	s.checkLibrary(e.At)
+}
+
+// ============================================
+// RÈGLES (domain) → DÉBLOCAGES (store)
+// ============================================
+
+func (s *AchievementService) checkReviews(at time.Time) {
+	total, err := store.CountReviews()
+	if err != nil {
+		log.Printf("❌ [Achievements] %v", err)
+		return
+	}
+	s.unlockAll(achievement.ReviewMilestones(total), at)
+}
+
+func (s *AchievementService) checkStreak(at time.Time) {
+	state, err := store.GetStreakState()
+	if err != nil {
+		log.Printf("❌ [Achievements] %v", err)
+		return
+	}
+	s.unlockAll(achievement.StreakMilestones(max(state.Current, state.Longest)), at)
+}
+
+func (s *AchievementService) checkLibrary(at time.Time) {
+	count, err := store.CountExercises()
+	if err != nil {
+		log.Printf("❌ [Achievements] %v", err)
+		return
+	}
+	s.unlockAll(achievement.LibraryMilestones(count), at)
+}
+
+// checkDomains : Domaine entièrement mature ("" = tous les domaines)
+func (s *AchievementService) checkDomains(domain string, at time.Time) {
+	domains, err := store.GetDomainTotals()
+	if err != nil {
+		log.Printf("❌ [Achievements] %v", err)
+		return
+	}
+	for _, ds := range domains {
+		if domain != "" && ds.Name != domain {
+			continue
+		}
+		if achievement.DomainFullyMature(ds.TotalCount, ds.Mastery.Mature+ds.Mastery.Mastered) {
+			s.unlock(achievement.Key(achievement.DomainMature, ds.Name), at)
+		}
+	}
+}
+
+// checkPerfectSession : Session deep parfaite (hors entraînement)
+func (s *AchievementService) checkPerfectSession(sessionID int64, at time.Time) {
+	practice, err := store.IsPracticeSession(sessionID)
+	if err != nil {
+		log.Printf("❌ [Achievements] %v", err)
+		return
+	}
+	if practice {
+		return
+	}
+
+	mode, planned, completed, good, err := store.GetSessionOutcome(sessionID, achievement.PerfectQuality)
+	if err != nil {
+		log.Printf("❌ [Achievements] %v", err)
+		return
+	}
+	if achievement.PerfectSession(mode, planned, completed, good) {
+		s.unlock(achievement.Key(achievement.PerfectDeep, ""), at)
+	}
+}
+
+func (s *AchievementService) unlockAll(ids []achievement.ID, at time.Time) {
+	for _, id := range ids {
+		s.unlock(achievement.Key(id, ""), at)
+	}
+}
+
+func (s *AchievementService) unlock(key string, at time.Time) {
+	unlocked, err := store.UnlockAchievement(key, at)
+	if err != nil {
+		log.Printf("❌ [Achievements] %v", err)
+		return
+	}
+	if unlocked {
+		log.Printf("🏆 Succès débloqué: %s", key)
+	}
+}
+
+// ============================================
+// LECTURE (page + dashboard)
+// ============================================
+
+// GetAchievements : Catalogue complet, débloqués en premier (récents d'abord).
+// Un succès par domaine apparaît une fois par domaine débloqué, sinon verrouillé.
+func (s *AchievementService) GetAchievements() []models.Achievement {
+	unlocked, err := store.GetUnlockedAchievements()
+	if err != nil {
+		log.Printf("❌ [Achievements] %v", err)
+	}
+
+	// Portées débloquées par succès
+	scopes := make(map[achievement.ID][]string)
+	for key := range unlocked {
+		if def, scope, ok := achievement.Lookup(key); ok && def.Scoped {
+			scopes[def.ID] = append(scopes[def.ID], scope)
+		}
+	}
+
+	var list []models.Achievement
+	for _, def := range achievement.Catalog {
+		if !def.Scoped {
+			at, ok := unlocked[string(def.ID)]
+			list = append(list, newAchievement(def, "", at, ok))
+			continue
+		}
+		sort.Strings(scopes[def.ID])
+		if len(scopes[def.ID]) == 0 {
+			list = append(list, newAchievement(def, "", time.Time{}, false))
+		}
+		for _, scope := range scopes[def.ID] {
+			list = append(list, newAchievement(def, scope, unlocked[achievement.Key(def.ID, scope)], true))
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Unlocked != list[j].Unlocked {
+			return list[i].Unlocked
+		}
+		return list[i].UnlockedAt.After(list[j].UnlockedAt)
+	})
+	return list
+}
+
+// GetSummary : Compteur + derniers succès débloqués
+func (s *AchievementService) GetSummary(recent int) models.AchievementSummary {
+	summary := models.AchievementSummary{Total: len(achievement.Catalog)}
+	seen := make(map[string]bool)
+	for _, a := range s.GetAchievements() {
+		if !a.Unlocked {
+			continue
+		}
+		if def, _, ok := achievement.Lookup(a.Key); ok && !seen[string(def.ID)] {
+			seen[string(def.ID)] = true
+			summary.Unlocked++
+		}
+		if len(summary.Recent) < recent {
+			summary.Recent = append(summary.Recent, a)
+		}
+	}
+	return summary
+}
+
+func newAchievement(def achievement.Definition, scope string, at time.Time, unlocked bool) models.Achievement {
+	a := models.Achievement{
+		Key:         achievement.Key(def.ID, scope),
+		Title:       def.Title,
+		Description: def.Description,
+		Icon:        def.Icon,
+		Scope:       scope,
+		Unlocked:    unlocked,
+	}
+	if unlocked {
+		a.UnlockedAt = at
+	}
+	return a
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"maestro/internal/domain/achievement"
+	"maestro/internal/domain/session"
+	"maestro/internal/domain/srs"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+// Les événements publiés par les services débloquent les succès une seule fois
+func TestAchievementsUnlockFromEvents(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	newTestStore(t, now, time.UTC)
+
+	defer NewAchievementService().Subscribe()()
+
+	exercises := NewExerciseService()
+	sessions := NewSessionService()
+
+	var ids []int
+	for i := 0; i < 3; i++ {
+		ex := models.Exercise{Title: "Ex" + string(rune('A'+i)), Domain: "Go", Difficulty: 2}
+		if err := exercises.CreateExercise(&ex); err != nil {
+			t.Fatalf("create exercise: %v", err)
+		}
+		ids = append(ids, ex.ID)
+	}
+
+	sessionID, _, err := sessions.StartSession(session.GetConfig(models.EnergyHigh), ids, session.DefaultFocus())
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+	for _, id := range ids {
+		if err := sessions.CompleteExercise(sessionID, id, int(srs.Good), time.Minute); err != nil {
+			t.Fatalf("complete exercise: %v", err)
+		}
+		if _, err := exercises.ReviewExercise(id, srs.Good, time.Minute); err != nil {
+			t.Fatalf("review exercise: %v", err)
+		}
+	}
+	if err := sessions.EndSession(sessionID); err != nil {
+		t.Fatalf("end session: %v", err)
+	}
+
+	unlocked, err := store.GetUnlockedAchievements()
+	if err != nil {
+		t.Fatalf("get achievements: %v", err)
+	}
+	for _, id := range []achievement.ID{achievement.FirstReview, achievement.PerfectDeep} {
+		at, ok := unlocked[string(id)]
+		if !ok {
+			t.Errorf("%s not unlocked", id)
+		} else if !at.Equal(now) {
+			t.Errorf("%s unlocked at %v, want %v", id, at, now)
+		}
+	}
+	if _, ok := unlocked[string(achievement.Library10)]; ok {
+		t.Errorf("library_10 unlocked with 3 exercises")
+	}
+
+	// Idempotent : un second débloquage ne remplace pas la date
+	if ok, err := store.UnlockAchievement(string(achievement.FirstReview), now.Add(time.Hour)); err != nil || ok {
+		t.Errorf("relock first_review = %v, %v; want false, nil", ok, err)
+	}
+}
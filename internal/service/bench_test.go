@@ -0,0 +1,117 @@
+package service
+
+import (
+	"io"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/clock"
+	"maestro/internal/models"
+	"maestro/internal/seed"
+	"maestro/internal/store"
+)
+
+// ============================================
+// BENCHMARKS (10k exercices, ~500k révisions)
+// ============================================
+// go test -bench . -run ^$ ./internal/service/
+// MAESTRO_BENCH_DB=data/seed.db réutilise une base produite par cmd/seed.
+
+var benchUntil = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var benchOnce sync.Once
+
+func setupBenchDB(b *testing.B) {
+	b.Helper()
+	benchOnce.Do(func() {
+		log.SetOutput(io.Discard)
+		// Horloge figée à la fin de l'historique généré
+		calendar.Configure(calendar.Calendar{Location: time.UTC, Clock: clock.NewFixed(benchUntil)})
+
+		if path := os.Getenv("MAESTRO_BENCH_DB"); path != "" {
+			if err := store.InitDB(path); err != nil {
+				b.Fatalf("init db %s: %v", path, err)
+			}
+			return
+		}
+
+		if err := store.InitDB(store.MemoryDSN); err != nil {
+			b.Fatalf("init db: %v", err)
+		}
+		cfg := seed.DefaultConfig(benchUntil)
+		if testing.Short() {
+			cfg.Exercises, cfg.Reviews = 1_000, 50_000
+		}
+		if _, err := seed.Generate(store.GetDB(), calendar.Current(), cfg); err != nil {
+			b.Fatalf("seed: %v", err)
+		}
+	})
+	b.ResetTimer()
+}
+
+func BenchmarkGetDashboardStats(b *testing.B) {
+	setupBenchDB(b)
+	s := NewDashboardService()
+	for b.Loop() {
+		s.GetDashboardStats()
+	}
+}
+
+func BenchmarkGetFiltered(b *testing.B) {
+	setupBenchDB(b)
+	s := NewExerciseService()
+
+	filters := map[string]models.ExerciseFilter{
+		"all":        {},
+		"domain":     {Domain: "Go"},
+		"mastered":   {Status: "mastered", Difficulty: 3},
+		"query+sort": {Query: "tree", Sort: "difficulty"},
+	}
+	for name, filter := range filters {
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				if _, err := s.GetFilteredExercises(filter); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetMonthSchedule(b *testing.B) {
+	setupBenchDB(b)
+	s := NewPlannerService()
+	for b.Loop() {
+		s.GetMonthSchedule(2025, time.December)
+	}
+}
+
+func BenchmarkGetRetentionCurve(b *testing.B) {
+	setupBenchDB(b)
+	s := NewRetentionService()
+	for b.Loop() {
+		s.GetRetentionCurve("", 0)
+	}
+}
+
+func BenchmarkTimeSlotAnalyze(b *testing.B) {
+	setupBenchDB(b)
+	s := NewTimeSlotService()
+	for b.Loop() {
+		if _, err := s.Analyze(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetTimeInvestment(b *testing.B) {
+	setupBenchDB(b)
+	s := NewDashboardService()
+	for b.Loop() {
+		s.GetTimeInvestment(5)
+	}
+}
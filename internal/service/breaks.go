@@ -0,0 +1,78 @@
+// internal/service/breaks.go
+package service
+
+import (
+	"fmt"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/session"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+// ============================================
+// PAUSES IMPOSÉES (SessionService)
+// ============================================
+
+// BreakIfDue : Ouvre la pause prévue par le template de la session après le
+// dernier exercice complété (nil si aucune pause due ou déjà prise)
+func (s *SessionService) BreakIfDue(sessionID int64) (*models.SessionBreak, error) {
+	energy, mode, completed, err := store.GetSessionProgress(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("break rule for session %d: %w", sessionID, err)
+	}
+
+	// Template de la session (intégré du niveau d'énergie à défaut)
+	config, ok := session.GetTemplate(mode)
+	if !ok {
+		config = session.GetConfig(energy)
+	}
+	if !config.ShouldTakeBreak(completed) {
+		return nil, nil
+	}
+
+	brk, err := store.StartBreak(sessionID, completed, config.BreakDuration(completed))
+	if err != nil {
+		return nil, fmt.Errorf("start break in session %d: %w", sessionID, err)
+	}
+	if brk.EndedAt != nil {
+		return nil, nil // Pause déjà prise (review rejouée)
+	}
+	return withRemaining(brk), nil
+}
+
+// GetOpenBreak : Pause en cours avec le temps restant (nil si aucune)
+func (s *SessionService) GetOpenBreak(sessionID int64) (*models.SessionBreak, error) {
+	brk, err := store.GetOpenBreak(sessionID)
+	if err != nil || brk == nil {
+		return nil, err
+	}
+	return withRemaining(brk), nil
+}
+
+// EndBreak : Termine la pause si sa durée est écoulée (BreakNotOverError sinon)
+func (s *SessionService) EndBreak(sessionID int64) error {
+	brk, err := s.GetOpenBreak(sessionID)
+	if err != nil || brk == nil {
+		return err
+	}
+	if brk.Remaining > 0 {
+		return &session.BreakNotOverError{Remaining: brk.Remaining}
+	}
+	return store.FinishBreak(brk.ID, false)
+}
+
+// SkipBreak : Écourte la pause en cours (comptée dans analytics.breaks_skipped
+// si du temps restait)
+func (s *SessionService) SkipBreak(sessionID int64) error {
+	brk, err := s.GetOpenBreak(sessionID)
+	if err != nil || brk == nil {
+		return err
+	}
+	return store.FinishBreak(brk.ID, brk.Remaining > 0)
+}
+
+func withRemaining(brk *models.SessionBreak) *models.SessionBreak {
+	brk.Remaining = session.BreakRemaining(brk.StartedAt, brk.Planned, calendar.Current().Now())
+	return brk
+}
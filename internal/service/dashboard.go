@@ -2,8 +2,12 @@
 package service
 
 import (
+	"log"
+	"sort"
 	"time"
 
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/srs"
 	"maestro/internal/models"
 	"maestro/internal/store"
 	"maestro/internal/views/logic"
@@ -15,220 +19,133 @@ func NewDashboardService() *DashboardService {
 	return &DashboardService{}
 }
 
-// GetDashboardStats - Stats principales avec tous les champs
+// GetDashboardStats - Stats principales (agrégats SQL sur exercises + progress_log)
 func (s *DashboardService) GetDashboardStats() models.DashboardStats {
-	allExercises := store.GetAll()
-	now := time.Now()
+	cal := calendar.Current()
+	today := cal.Today()
 
 	stats := models.DashboardStats{
-		TotalExercises:  len(allExercises),
 		DomainBreakdown: make(map[string]int),
 	}
 
-	var totalDifficulty int
-	var nextReviews []time.Time
-	var totalInterval int
-	var intervalCount int
-	var totalEaseFactor float64
-	var easeCount int
-	var successfulReviews int
-	var totalReviews int
-	weekAgo := now.AddDate(0, 0, -7)
-	var weeklyReviewCount int
-
-	// ✅ NEW: Velocity tracking
-	thirtyDaysAgo := now.AddDate(0, 0, -30)
-	dailyReviews := make(map[string]int)
-
-	// ✅ NEW: Domain analysis
-	domainStats := make(map[string]struct {
-		total     int
-		mastered  int
-		totalEase float64
-	})
-
-	for _, ex := range allExercises {
-		// Compte les états
-		if ex.Done {
-			stats.CompletedCount++
-			stats.TotalMastered++
-		} else if len(ex.CompletedSteps) > 0 {
-			stats.InProgressCount++
-		} else {
-			stats.TodoCount++
-		}
-
-		// Overdue
-		if !ex.NextReviewAt.IsZero() && ex.NextReviewAt.Before(now) && !ex.Done {
-			stats.OverdueCount++
-		}
-
-		// Prochaines révisions
-		if !ex.NextReviewAt.IsZero() && ex.NextReviewAt.After(now) {
-			nextReviews = append(nextReviews, ex.NextReviewAt)
-		}
-
-		// Weekly reviews
-		if ex.LastReviewed != nil && !ex.LastReviewed.IsZero() && ex.LastReviewed.After(weekAgo) {
-			weeklyReviewCount++
-		}
-
-		// ✅ NEW: Velocity calculation (30d)
-		if ex.LastReviewed != nil && !ex.LastReviewed.IsZero() &&
-			ex.LastReviewed.After(thirtyDaysAgo) {
-			dateKey := ex.LastReviewed.Format("2006-01-02")
-			dailyReviews[dateKey]++
-			stats.TotalReviews++
-		}
-
-		totalDifficulty += ex.Difficulty
-
-		// Intervalle SRS
-		if ex.IntervalDays > 0 {
-			totalInterval += ex.IntervalDays
-			intervalCount++
-		}
-
-		// ✅ Short intervals
-		if ex.IntervalDays > 0 && ex.IntervalDays < 7 && !ex.Done {
-			stats.ShortIntervalCount++
-		}
-
-		// EaseFactor moyen
-		if ex.EaseFactor > 0 {
-			totalEaseFactor += ex.EaseFactor
-			easeCount++
-
-			// ✅ Low ease count
-			if ex.EaseFactor < 2.3 && !ex.Done {
-				stats.LowEaseCount++
-			}
-		}
-
-		// Retention rate (reviews réussies)
-		if ex.Repetitions > 0 {
-			totalReviews += ex.Repetitions
-			// Approximation: si EF >= 2.5, c'est "réussi"
-			if ex.EaseFactor >= 2.5 {
-				successfulReviews += ex.Repetitions
-			}
-		}
-
-		stats.DomainBreakdown[ex.Domain]++
-
-		// ✅ NEW: Domain strength tracking
-		ds := domainStats[ex.Domain]
-		ds.total++
-		if ex.Done {
-			ds.mastered++
-		}
-		if ex.EaseFactor > 0 {
-			ds.totalEase += ex.EaseFactor
-		}
-		domainStats[ex.Domain] = ds
+	// 1. Snapshot des exercices (état SRS courant)
+	totals, err := store.GetExerciseTotals(today)
+	if err != nil {
+		log.Printf("❌ [Dashboard] %v", err)
 	}
-
-	// Calcule les moyennes
-	if stats.TotalExercises > 0 {
-		stats.CompletionRate = (stats.CompletedCount * 100) / stats.TotalExercises
-		stats.AverageDifficulty = float64(totalDifficulty) / float64(stats.TotalExercises)
+	stats.TotalExercises = totals.Total
+	stats.InProgressCount = totals.InProgress
+	stats.TodoCount = totals.Todo
+	stats.OverdueCount = totals.Overdue
+	stats.AverageDifficulty = totals.AvgDifficulty
+	stats.AverageInterval = totals.AvgInterval
+	stats.AverageEaseFactor = totals.AvgEase
+	stats.ShortIntervalCount = totals.ShortInterval
+	stats.LowEaseCount = totals.LowEase
+	if totals.NextReviewDay > 0 {
+		stats.NextReviewDate = cal.FromDayKey(totals.NextReviewDay)
 	}
 
-	if intervalCount > 0 {
-		stats.AverageInterval = totalInterval / intervalCount
-	}
+	// 2. Historique réel des reviews (chaque review compte)
+	weekStart := cal.FromDayKey(cal.AddDays(today, -6)).Unix()
+	monthStart := cal.FromDayKey(cal.AddDays(today, -29)).Unix()
 
-	if easeCount > 0 {
-		stats.AverageEaseFactor = totalEaseFactor / float64(easeCount)
+	reviews, err := store.GetReviewTotals(monthStart, weekStart)
+	if err != nil {
+		log.Printf("❌ [Dashboard] %v", err)
 	}
-
-	if totalReviews > 0 {
-		stats.RetentionRate = (successfulReviews * 100) / totalReviews
+	stats.WeeklyReviews = reviews.LastWeek
+	stats.TotalReviews = reviews.Reviews
+	stats.LapseCount = reviews.Lapses
+	if reviews.Reviews > 0 {
+		stats.RetentionRate = (reviews.Successful * 100) / reviews.Reviews
 	}
 
-	// ✅ NEW: Peak daily reviews
-	for _, count := range dailyReviews {
-		if count > stats.PeakDailyReviews {
-			stats.PeakDailyReviews = count
-		}
+	// 3. Velocity (30j) : reviews par jour utilisateur
+	dailyReviews, err := store.GetDailyReviewCounts(cal, monthStart)
+	if err != nil {
+		log.Printf("❌ [Dashboard] %v", err)
 	}
 
-	// ✅ NEW: Velocity trend (compare first 15d vs last 15d)
 	firstHalfSum := 0
 	secondHalfSum := 0
-	dayCount := 0
-
-	for dateStr, count := range dailyReviews {
-		date, _ := time.Parse("2006-01-02", dateStr)
-		fifteenDaysAgo := now.AddDate(0, 0, -15)
-
-		if date.After(fifteenDaysAgo) {
+	monthStartDay := cal.AddDays(today, -29)
+	fifteenDaysAgo := cal.AddDays(today, -15)
+	for dayKey, count := range dailyReviews {
+		if dayKey < monthStartDay {
+			continue
+		}
+		if count > stats.PeakDailyReviews {
+			stats.PeakDailyReviews = count
+		}
+		if dayKey > fifteenDaysAgo {
 			secondHalfSum += count
 		} else {
 			firstHalfSum += count
 		}
-		dayCount++
 	}
 
 	if firstHalfSum > 0 {
 		stats.VelocityTrend = float64(secondHalfSum-firstHalfSum) / float64(firstHalfSum) * 100
 	}
 
-	// ✅ NEW: Find weakest domain
+	// Streak persisté (objectif quotidien + gels)
+	goal := NewStreakService().GetGoalProgress()
+	stats.StreakDays = goal.CurrentStreak
+	stats.LongestStreak = goal.LongestStreak
+
+	// 4. Domaines (répartition, maîtrise, top, plus faible)
+	maxCount := 0
 	weakestRetention := 100
-	for domain, ds := range domainStats {
-		if ds.total == 0 {
-			continue
+	for _, ds := range s.GetDomainStrengths() {
+		stats.DomainBreakdown[ds.Name] = ds.TotalCount
+		stats.Mastery.New += ds.Mastery.New
+		stats.Mastery.Learning += ds.Mastery.Learning
+		stats.Mastery.Young += ds.Mastery.Young
+		stats.Mastery.Mature += ds.Mastery.Mature
+		stats.Mastery.Mastered += ds.Mastery.Mastered
+
+		if ds.TotalCount > maxCount {
+			maxCount = ds.TotalCount
+			stats.TopDomain = ds.Name
 		}
 
-		retention := (ds.mastered * 100) / ds.total
-		if retention < weakestRetention {
-			weakestRetention = retention
-			stats.WeakestDomain = domain
-			stats.WeakestDomainRetention = retention
-			stats.WeakestDomainCount = ds.total - ds.mastered
+		if ds.StrengthPercent < weakestRetention {
+			weakestRetention = ds.StrengthPercent
+			stats.WeakestDomain = ds.Name
+			stats.WeakestDomainRetention = ds.StrengthPercent
+			stats.WeakestDomainCount = ds.TotalCount - ds.MasteredCount
 		}
 	}
 
-	stats.WeeklyReviews = weeklyReviewCount
-	stats.StreakDays = calculateStreak(allExercises)
+	if stats.TotalExercises > 0 {
+		stats.MasteredRate = stats.Mastery.Mastered * 100 / stats.TotalExercises
+	}
+	stats.MasteryScore = masteryScore(stats.Mastery, stats.TotalExercises)
+
+	// 5. Sessions
 	stats.SessionCount, stats.TotalSessionTime = getSessionStats()
 
 	if stats.SessionCount > 0 {
 		stats.AvgSessionTime = stats.TotalSessionTime / time.Duration(stats.SessionCount)
 	}
 
-	// Prochaine révision
-	if len(nextReviews) > 0 {
-		stats.NextReviewDate = nextReviews[0]
-	}
-
-	// Top domaine
-	maxCount := 0
-	for domain, count := range stats.DomainBreakdown {
-		if count > maxCount {
-			maxCount = count
-			stats.TopDomain = domain
-		}
-	}
-
 	return stats
 }
 
-// GetHeatmapData - Données pour le heatmap GitHub-style
+// GetHeatmapData - Données pour le heatmap GitHub-style (vraies reviews/jour)
 func (s *DashboardService) GetHeatmapData(weeks int) []logic.HeatmapDay {
-	allExercises := store.GetAll()
+	cal := calendar.Current()
+	since := cal.FromDayKey(cal.AddDays(cal.Today(), -(weeks * 7))).Unix()
 
-	// Compte les reviews par date (last N weeks)
-	reviewCounts := make(map[string]int)
-	cutoffDate := time.Now().AddDate(0, 0, -(weeks * 7))
+	dailyReviews, err := store.GetDailyReviewCounts(cal, since)
+	if err != nil {
+		log.Printf("❌ [Heatmap] %v", err)
+	}
 
-	for _, ex := range allExercises {
-		if ex.LastReviewed != nil && !ex.LastReviewed.IsZero() &&
-			ex.LastReviewed.After(cutoffDate) {
-			dateKey := ex.LastReviewed.Format("2006-01-02")
-			reviewCounts[dateKey]++
-		}
+	reviewCounts := make(map[string]int, len(dailyReviews))
+	for dayKey, count := range dailyReviews {
+		reviewCounts[cal.FromDayKey(dayKey).Format("2006-01-02")] = count
 	}
 
 	// Génère les jours via logic helper
@@ -237,141 +154,102 @@ func (s *DashboardService) GetHeatmapData(weeks int) []logic.HeatmapDay {
 
 // GetWeakExercises - Exercices avec EaseFactor faible
 func (s *DashboardService) GetWeakExercises(limit int) []models.Exercise {
-	allExercises := store.GetAll()
-	var weak []models.Exercise
-
-	// Seuil: EaseFactor < 2.3 ET pas encore maîtrisé
-	for _, ex := range allExercises {
-		if !ex.Done && ex.EaseFactor < 2.3 && ex.EaseFactor > 0 {
-			weak = append(weak, ex)
-		}
+	weak, err := store.GetWeakExercises(limit)
+	if err != nil {
+		log.Printf("❌ [WeakExercises] %v", err)
 	}
+	return weak
+}
 
-	// Trie par EaseFactor croissant (plus faibles en premier)
-	for i := 0; i < len(weak)-1; i++ {
-		for j := i + 1; j < len(weak); j++ {
-			if weak[i].EaseFactor > weak[j].EaseFactor {
-				weak[i], weak[j] = weak[j], weak[i]
-			}
-		}
+// GetFailurePatterns - Exercices avec échecs répétés (≥ 3 reviews quality 0-1)
+func (s *DashboardService) GetFailurePatterns(limit int) []models.FailurePattern {
+	patterns, err := store.GetFailurePatterns(3, limit)
+	if err != nil {
+		log.Printf("❌ [FailurePatterns] %v", err)
 	}
+	return patterns
+}
 
-	// Limite à N résultats
-	if len(weak) > limit {
-		weak = weak[:limit]
+// GetRepetitionStats - Exercices les plus révisés
+func (s *DashboardService) GetRepetitionStats(limit int) []models.RepetitionStat {
+	stats, err := store.GetRepetitionStats(limit)
+	if err != nil {
+		log.Printf("❌ [RepetitionStats] %v", err)
 	}
-
-	return weak
+	return stats
 }
 
-// GetFailurePatterns - Exercices avec échecs répétés
-func (s *DashboardService) GetFailurePatterns(limit int) []models.FailurePattern {
-	allExercises := store.GetAll()
-	var patterns []models.FailurePattern
-
-	for _, ex := range allExercises {
-		// Critère: EaseFactor bas + répétitions élevées = échecs répétés
-		if !ex.Done && ex.EaseFactor < 2.3 && ex.Repetitions >= 3 {
-			failCount := ex.Repetitions // Approximation
-			patterns = append(patterns, models.FailurePattern{
-				ExerciseID: ex.ID,
-				Title:      ex.Title,
-				Domain:     ex.Domain,
-				FailCount:  failCount,
-				EaseFactor: ex.EaseFactor,
-			})
-		}
+// GetTimeInvestment - Temps de réponse : exercices les plus lents + temps par domaine
+func (s *DashboardService) GetTimeInvestment(limit int) models.TimeInvestment {
+	var ti models.TimeInvestment
+
+	timings, err := store.GetExerciseTimings()
+	if err != nil {
+		log.Printf("❌ [TimeInvestment] %v", err)
+		return ti
 	}
 
-	// Trie par FailCount décroissant
-	for i := 0; i < len(patterns)-1; i++ {
-		for j := i + 1; j < len(patterns); j++ {
-			if patterns[i].FailCount < patterns[j].FailCount {
-				patterns[i], patterns[j] = patterns[j], patterns[i]
-			}
+	// 1. Totaux + cumul par domaine
+	totalSec := 0
+	byDomain := make(map[string]*models.DomainTiming)
+	for _, t := range timings {
+		ti.Timed += t.Samples
+		totalSec += t.TotalSec
+
+		d, ok := byDomain[t.Domain]
+		if !ok {
+			d = &models.DomainTiming{Domain: t.Domain}
+			byDomain[t.Domain] = d
 		}
+		d.Reviews += t.Samples
+		d.TotalSec += t.TotalSec
 	}
-
-	if len(patterns) > limit {
-		patterns = patterns[:limit]
+	if ti.Timed > 0 {
+		ti.AvgAnswerSec = totalSec / ti.Timed
 	}
 
-	return patterns
-}
-
-// GetRepetitionStats - Exercices les plus révisés
-func (s *DashboardService) GetRepetitionStats(limit int) []models.RepetitionStat {
-	allExercises := store.GetAll()
-	var stats []models.RepetitionStat
-
-	for _, ex := range allExercises {
-		if ex.Repetitions > 0 {
-			stats = append(stats, models.RepetitionStat{
-				ExerciseID:  ex.ID,
-				Title:       ex.Title,
-				Domain:      ex.Domain,
-				ReviewCount: ex.Repetitions,
-			})
-		}
+	for _, d := range byDomain {
+		d.AvgSec = d.TotalSec / d.Reviews
+		ti.Domains = append(ti.Domains, *d)
 	}
+	sort.Slice(ti.Domains, func(i, j int) bool {
+		return ti.Domains[i].TotalSec > ti.Domains[j].TotalSec
+	})
 
-	// Trie par ReviewCount décroissant
-	for i := 0; i < len(stats)-1; i++ {
-		for j := i + 1; j < len(stats); j++ {
-			if stats[i].ReviewCount < stats[j].ReviewCount {
-				stats[i], stats[j] = stats[j], stats[i]
-			}
+	// 2. Plus lents (au moins 2 mesures : une review isolée n'est pas un signal)
+	for _, t := range timings {
+		if t.Samples >= 2 {
+			ti.Slowest = append(ti.Slowest, t)
 		}
 	}
-
-	if len(stats) > limit {
-		stats = stats[:limit]
+	sort.SliceStable(ti.Slowest, func(i, j int) bool {
+		return ti.Slowest[i].AvgSec > ti.Slowest[j].AvgSec
+	})
+	if len(ti.Slowest) > limit {
+		ti.Slowest = ti.Slowest[:limit]
 	}
 
-	return stats
+	return ti
 }
 
 // GetDomainStrengths - Analyse force par domaine
 func (s *DashboardService) GetDomainStrengths() []models.DomainStrength {
-	allExercises := store.GetAll()
-	domainMap := make(map[string]*models.DomainStrength)
-
-	for _, ex := range allExercises {
-		if domainMap[ex.Domain] == nil {
-			domainMap[ex.Domain] = &models.DomainStrength{
-				Name: ex.Domain,
-			}
-		}
-
-		ds := domainMap[ex.Domain]
-		ds.TotalCount++
-
-		if ex.Done {
-			ds.MasteredCount++
-		}
-
-		if ex.EaseFactor > 0 {
-			ds.AvgEaseFactor += ex.EaseFactor
-		}
+	strengths, err := store.GetDomainTotals()
+	if err != nil {
+		log.Printf("❌ [DomainStrengths] %v", err)
 	}
 
-	var strengths []models.DomainStrength
-	for _, ds := range domainMap {
-		if ds.TotalCount > 0 {
-			ds.AvgEaseFactor /= float64(ds.TotalCount)
-			ds.StrengthPercent = (ds.MasteredCount * 100) / ds.TotalCount
-		}
-		strengths = append(strengths, *ds)
+	// Force = score de maîtrise pondéré (états calculés en SQL)
+	for i := range strengths {
+		ds := &strengths[i]
+		ds.MasteredCount = ds.Mastery.Mastered
+		ds.StrengthPercent = masteryScore(ds.Mastery, ds.TotalCount)
 	}
 
 	// Trie par StrengthPercent décroissant
-	for i := 0; i < len(strengths)-1; i++ {
-		for j := i + 1; j < len(strengths); j++ {
-			if strengths[i].StrengthPercent < strengths[j].StrengthPercent {
-				strengths[i], strengths[j] = strengths[j], strengths[i]
-			}
-		}
-	}
+	sort.SliceStable(strengths, func(i, j int) bool {
+		return strengths[i].StrengthPercent > strengths[j].StrengthPercent
+	})
 
 	return strengths
 }
@@ -380,40 +258,24 @@ func (s *DashboardService) GetDomainStrengths() []models.DomainStrength {
 // HELPER FUNCTIONS
 // ============================================
 
-func calculateStreak(exercises []models.Exercise) int {
-	if len(exercises) == 0 {
+// masteryScore : Moyenne pondérée des états de maîtrise, en %
+func masteryScore(c models.MasteryCounts, total int) int {
+	if total == 0 {
 		return 0
 	}
-
-	reviewDates := make(map[string]bool)
-
-	for _, ex := range exercises {
-		if ex.LastReviewed != nil && !ex.LastReviewed.IsZero() {
-			dateKey := ex.LastReviewed.Format("2006-01-02")
-			reviewDates[dateKey] = true
-		}
-	}
-
-	streak := 0
-	currentDate := time.Now()
-
-	for {
-		dateKey := currentDate.Format("2006-01-02")
-		if !reviewDates[dateKey] {
-			break
-		}
-		streak++
-		currentDate = currentDate.AddDate(0, 0, -1)
-
-		if streak > 365 {
-			break
-		}
-	}
-
-	return streak
+	score := float64(c.Learning)*srs.MasteryWeight(srs.StateLearning) +
+		float64(c.Young)*srs.MasteryWeight(srs.StateYoung) +
+		float64(c.Mature)*srs.MasteryWeight(srs.StateMature) +
+		float64(c.Mastered)*srs.MasteryWeight(srs.StateMastered)
+	return int(score * 100 / float64(total))
 }
 
+// getSessionStats : Nombre de sessions terminées et temps cumulé
 func getSessionStats() (int, time.Duration) {
-	// Version simple sans table sessions
-	return 0, 0
+	count, totalMin, err := store.GetSessionTotals()
+	if err != nil {
+		log.Printf("❌ [SessionStats] %v", err)
+		return 0, 0
+	}
+	return count, time.Duration(totalMin) * time.Minute
 }
@@ -0,0 +1,129 @@
+// internal/service/dashboard_cache.go
+package service
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/events"
+	"maestro/internal/models"
+)
+
+// ============================================
+// SNAPSHOT DASHBOARD
+// ============================================
+
+// BuildSnapshot : Calcule toutes les données de la page dashboard (sans cache)
+func (s *DashboardService) BuildSnapshot() models.DashboardSnapshot {
+	cal := calendar.Current()
+	planner := NewPlannerService()
+
+	return models.DashboardSnapshot{
+		Stats:           s.GetDashboardStats(),
+		TodayReviews:    planner.GetReviewsForDate(cal.TodayStart()),
+		OverdueReviews:  planner.GetOverdueReviews(),
+		UpcomingReviews: planner.GetUpcomingReviews(5),
+		Retention:       NewRetentionService().GetRetentionCurve("", 0),
+		Goal:            NewStreakService().GetGoalProgress(),
+		TimeSlots:       NewTimeSlotService().GetTimePerformance(),
+		Timing:          s.GetTimeInvestment(5),
+		AtRisk:          NewDecayService().GetAtRisk(5),
+		Achievements:    NewAchievementService().GetSummary(3),
+		BuiltAt:         cal.Now(),
+	}
+}
+
+// ============================================
+// CACHE (invalidé par events.DataChanged)
+// ============================================
+
+// DashboardCache : Snapshot partagé par une rafale de requêtes. Périmé après
+// une écriture en base, un changement de jour ou ttl écoulé (heure calendrier)
+type DashboardCache struct {
+	ttl   time.Duration
+	build func() models.DashboardSnapshot
+
+	mu       sync.Mutex // Un seul calcul à la fois, les requêtes concurrentes attendent
+	snapshot *models.DashboardSnapshot
+	snapGen  uint64 // Génération au début du calcul
+	snapDay  int
+	builtAt  time.Time
+
+	generation    atomic.Uint64 // Incrémentée à chaque écriture (sans verrou)
+	hits          atomic.Int64
+	misses        atomic.Int64
+	invalidations atomic.Int64
+	lastBuild     atomic.Int64 // Durée du dernier calcul (ns)
+}
+
+func NewDashboardCache(ttl time.Duration) *DashboardCache {
+	return &DashboardCache{ttl: ttl, build: NewDashboardService().BuildSnapshot}
+}
+
+// Subscribe : Invalide le snapshot à chaque écriture (retourne le désabonnement)
+func (c *DashboardCache) Subscribe() func() {
+	return events.Subscribe(events.DataChanged, func(events.Event) { c.Invalidate() })
+}
+
+// Invalidate : Périme le snapshot courant (un calcul en cours ne sera pas conservé)
+func (c *DashboardCache) Invalidate() {
+	c.generation.Add(1)
+	c.invalidations.Add(1)
+}
+
+// Get : Snapshot valide, recalculé si nécessaire
+func (c *DashboardCache) Get() models.DashboardSnapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cal := calendar.Current()
+	if c.fresh(cal) {
+		c.hits.Add(1)
+		return *c.snapshot
+	}
+	c.misses.Add(1)
+
+	gen := c.generation.Load() // Avant le calcul : une écriture concurrente le périme
+	start := time.Now()
+	snap := c.build()
+	elapsed := time.Since(start)
+	c.lastBuild.Store(int64(elapsed))
+
+	c.snapshot = &snap
+	c.snapGen = gen
+	c.snapDay = cal.Today()
+	c.builtAt = cal.Now()
+
+	log.Printf("🗃 Dashboard snapshot calculé en %v", elapsed.Round(time.Millisecond))
+	return snap
+}
+
+// fresh : Snapshot présent, sans écriture depuis, même jour, ttl non écoulé
+func (c *DashboardCache) fresh(cal calendar.Calendar) bool {
+	return c.snapshot != nil &&
+		c.snapGen == c.generation.Load() &&
+		c.snapDay == cal.Today() &&
+		cal.Now().Sub(c.builtAt) < c.ttl
+}
+
+// Metrics : Compteurs depuis le démarrage
+func (c *DashboardCache) Metrics() models.CacheMetrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m := models.CacheMetrics{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Invalidations: c.invalidations.Load(),
+		LastBuildMs:   time.Duration(c.lastBuild.Load()).Milliseconds(),
+		BuiltAt:       c.builtAt,
+		Cached:        c.fresh(calendar.Current()),
+	}
+	if total := m.Hits + m.Misses; total > 0 {
+		m.HitRate = int(m.Hits * 100 / total)
+	}
+	return m
+}
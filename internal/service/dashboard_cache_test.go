@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+// Le snapshot est partagé jusqu'à une écriture, un changement de jour ou le ttl
+func TestDashboardCacheInvalidation(t *testing.T) {
+	clk := newTestStore(t, time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC), time.UTC)
+
+	cache := NewDashboardCache(time.Minute)
+	builds := 0
+	cache.build = func() models.DashboardSnapshot {
+		builds++
+		return models.DashboardSnapshot{}
+	}
+	defer cache.Subscribe()()
+
+	steps := []struct {
+		name   string
+		before func()
+		builds int
+	}{
+		{"premier calcul", func() {}, 1},
+		{"rafale", func() {}, 1},
+		{"création", func() {
+			ex := models.Exercise{Title: "Ex", Domain: "Go", Difficulty: 2}
+			if err := store.CreateExercise(&ex); err != nil {
+				t.Fatalf("create exercise: %v", err)
+			}
+		}, 2},
+		{"réglage", func() {
+			if err := store.SetSetting(store.SettingAtRiskDays, "5"); err != nil {
+				t.Fatalf("set setting: %v", err)
+			}
+		}, 3},
+		{"ttl non écoulé", func() { clk.Advance(30 * time.Second) }, 3},
+		{"ttl écoulé", func() { clk.Advance(time.Minute) }, 4},
+	}
+	for _, s := range steps {
+		s.before()
+		cache.Get()
+		if builds != s.builds {
+			t.Errorf("%s: builds = %d, want %d", s.name, builds, s.builds)
+		}
+	}
+
+	m := cache.Metrics()
+	if m.Hits != 2 || m.Misses != 4 || m.HitRate != 33 || !m.Cached {
+		t.Errorf("metrics = %+v, want 2 hits, 4 misses, 33%%, cached", m)
+	}
+	if m.Invalidations < 2 {
+		t.Errorf("invalidations = %d, want >= 2", m.Invalidations)
+	}
+}
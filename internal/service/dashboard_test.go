@@ -0,0 +1,84 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"maestro/internal/domain/session"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+func TestGetDashboardStatsCountsEveryReview(t *testing.T) {
+	clk := newTestStore(t, time.Date(2026, 9, 10, 9, 0, 0, 0, time.UTC), time.UTC)
+
+	ex := models.Exercise{Title: "Trie", Domain: "Algorithms", Difficulty: 2, EaseFactor: 2.5}
+	if err := store.CreateExercise(&ex); err != nil {
+		t.Fatalf("create exercise: %v", err)
+	}
+
+	// 5 reviews du même exercice sur 3 jours (dont 2 oublis)
+	history := []struct {
+		daysAgo int
+		quality int
+	}{{2, 0}, {2, 2}, {1, 0}, {0, 1}, {0, 3}}
+	for _, l := range history {
+		clk.Set(time.Date(2026, 9, 10-l.daysAgo, 8, 0, 0, 0, time.UTC))
+		if err := store.LogProgress(ex.ID, l.quality, &ex, 0); err != nil {
+			t.Fatalf("log progress: %v", err)
+		}
+		if err := NewStreakService().RecordActivity(); err != nil {
+			t.Fatalf("record activity: %v", err)
+		}
+	}
+	clk.Set(time.Date(2026, 9, 10, 9, 0, 0, 0, time.UTC))
+
+	stats := NewDashboardService().GetDashboardStats()
+
+	if stats.WeeklyReviews != 5 || stats.TotalReviews != 5 {
+		t.Errorf("reviews = %d (7j) / %d (30j), want 5 / 5", stats.WeeklyReviews, stats.TotalReviews)
+	}
+	if stats.RetentionRate != 40 {
+		t.Errorf("RetentionRate = %d, want 40 (2 reviews quality >= 2 sur 5)", stats.RetentionRate)
+	}
+	if stats.LapseCount != 2 {
+		t.Errorf("LapseCount = %d, want 2", stats.LapseCount)
+	}
+	if stats.PeakDailyReviews != 2 {
+		t.Errorf("PeakDailyReviews = %d, want 2", stats.PeakDailyReviews)
+	}
+	if stats.StreakDays != 3 {
+		t.Errorf("StreakDays = %d, want 3", stats.StreakDays)
+	}
+}
+
+// Sessions comptées depuis session_exercises : une session terminée sans
+// exercice complété n'entre pas dans les totaux
+func TestGetDashboardStatsSessionsFromSessionExercises(t *testing.T) {
+	newTestStore(t, time.Date(2026, 9, 10, 9, 0, 0, 0, time.UTC), time.UTC)
+
+	ex := models.Exercise{Title: "Heap", Domain: "Algorithms", Difficulty: 2, EaseFactor: 2.5}
+	if err := store.CreateExercise(&ex); err != nil {
+		t.Fatalf("create exercise: %v", err)
+	}
+
+	config := session.GetConfig(models.EnergyMedium)
+	for _, complete := range []bool{true, false} {
+		id, err := store.StartSession(config, []models.Exercise{ex}, session.OrderPriority, 0, false)
+		if err != nil {
+			t.Fatalf("start session: %v", err)
+		}
+		if complete {
+			if err := store.CompleteSessionExercise(id, ex.ID, 2, time.Minute); err != nil {
+				t.Fatalf("complete: %v", err)
+			}
+		}
+		if err := store.EndSession(id); err != nil {
+			t.Fatalf("end session: %v", err)
+		}
+	}
+
+	if stats := NewDashboardService().GetDashboardStats(); stats.SessionCount != 1 {
+		t.Errorf("SessionCount = %d, want 1", stats.SessionCount)
+	}
+}
@@ -0,0 +1,73 @@
+// internal/service/decay.go
+package service
+
+import (
+	"log"
+	"sort"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/srs"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+// DecayService : Rappel prédit par exercice et file "à risque"
+type DecayService struct{}
+
+func NewDecayService() *DecayService {
+	return &DecayService{}
+}
+
+// GetAtRisk : Exercices matures qui passent sous le seuil de rappel dans
+// l'horizon configuré, du plus urgent au moins urgent (limit <= 0 : tous)
+func (s *DecayService) GetAtRisk(limit int) models.AtRiskQueue {
+	cal := calendar.Current()
+	now := cal.Now()
+	policy := store.GetRiskPolicy()
+
+	queue := models.AtRiskQueue{
+		Threshold:   int(policy.Threshold*100 + 0.5),
+		HorizonDays: policy.HorizonDays,
+	}
+
+	candidates, err := store.GetDecayCandidates(policy, now.Unix())
+	if err != nil {
+		log.Printf("❌ [AtRisk] %v", err)
+		return queue
+	}
+
+	today := cal.TodayStart()
+	for _, ex := range candidates {
+		if !policy.AtRisk(ex.LastReviewed, ex.IntervalDays, now) {
+			continue
+		}
+		queue.Exercises = append(queue.Exercises, models.AtRiskExercise{
+			ID:           ex.ID,
+			Title:        ex.Title,
+			Domain:       ex.Domain,
+			IntervalDays: ex.IntervalDays,
+			Recall:       srs.Retrievability(ex.LastReviewed, ex.IntervalDays, now),
+			CrossesAt:    srs.CrossingTime(*ex.LastReviewed, ex.IntervalDays, policy.Threshold),
+			Overdue:      ex.NextReviewAt.Before(today),
+		})
+	}
+
+	sort.SliceStable(queue.Exercises, func(i, j int) bool {
+		return queue.Exercises[i].CrossesAt.Before(queue.Exercises[j].CrossesAt)
+	})
+	queue.Total = len(queue.Exercises)
+	if limit > 0 && queue.Total > limit {
+		queue.Exercises = queue.Exercises[:limit]
+	}
+	return queue
+}
+
+// AtRiskIDs : IDs de la file "à risque" (source de session)
+func (s *DecayService) AtRiskIDs() []int {
+	queue := s.GetAtRisk(0)
+	ids := make([]int, len(queue.Exercises))
+	for i, ex := range queue.Exercises {
+		ids[i] = ex.ID
+	}
+	return ids
+}
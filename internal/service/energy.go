@@ -0,0 +1,76 @@
+// internal/service/energy.go
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/session"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+// ============================================
+// ÉNERGIE : RECOMMANDATION / ADAPTATION (SessionService)
+// ============================================
+
+// RecommendEnergy : Niveau conseillé depuis les dernières sessions, le
+// rythme de réponse et la réussite au créneau horaire courant
+func (s *SessionService) RecommendEnergy() models.EnergyRecommendation {
+	recent, err := store.GetRecentSessionSignals(session.RecentSessions)
+	if err != nil {
+		fmt.Printf("⚠️ Recent sessions failed: %v\n", err)
+	}
+
+	ctx := session.EnergyContext{Recent: recent, Hour: calendar.Current().Now().Hour()}
+	if _, avgSec, err := store.GetAnswerTimeTotals(); err != nil {
+		fmt.Printf("⚠️ Answer time totals failed: %v\n", err)
+	} else {
+		ctx.BaselineAnswer = time.Duration(avgSec) * time.Second
+	}
+
+	// Créneau horaire : dernière analyse persistée (job timeslot)
+	if perf, err := store.GetTimePerformance(); err != nil {
+		fmt.Printf("⚠️ Time performance failed: %v\n", err)
+	} else if len(perf.Hours) == 24 {
+		ctx.HourRate = perf.Hours[ctx.Hour].SuccessRate
+		ctx.HourReviews = perf.Hours[ctx.Hour].Reviews
+		ctx.OverallRate = perf.OverallRate
+	}
+
+	return session.RecommendEnergy(ctx)
+}
+
+// AdaptIfDeclining : Abaisse l'énergie de la session quand la qualité chute
+// (réglage energy_adapter) et raccourcit la file restante en conséquence.
+// nil si aucune adaptation.
+func (s *SessionService) AdaptIfDeclining(sessionID int64) (*models.SessionAdaptation, error) {
+	if !store.GetEnergyAdapter() {
+		return nil, nil
+	}
+
+	energy, mode, qualities, remaining, err := store.GetSessionAdaptState(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("adapt session %d: %w", sessionID, err)
+	}
+	if !session.IsDeclining(qualities) {
+		return nil, nil
+	}
+	lower, ok := session.Downshift(energy)
+	if !ok {
+		return nil, nil
+	}
+
+	// Template de la session (intégré du niveau d'énergie à défaut)
+	config, found := session.GetTemplate(mode)
+	if !found {
+		config = session.GetConfig(energy)
+	}
+	keep := config.ShortenedRemaining(energy, lower, remaining)
+	dropped, err := store.DownshiftSession(sessionID, lower, keep)
+	if err != nil {
+		return nil, fmt.Errorf("adapt session %d: %w", sessionID, err)
+	}
+	return &models.SessionAdaptation{From: energy, To: lower, Dropped: dropped}, nil
+}
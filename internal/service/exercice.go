@@ -5,8 +5,10 @@ import (
 	"strings"
 	"time"
 
+	"maestro/internal/domain/calendar"
 	"maestro/internal/domain/exercise"
 	"maestro/internal/domain/srs"
+	"maestro/internal/events"
 	"maestro/internal/models"
 	"maestro/internal/store"
 )
@@ -43,8 +45,8 @@ func (s *ExerciseService) CreateExercise(ex *models.Exercise) error {
 	ex.IntervalDays = 0
 	ex.Repetitions = 0
 	ex.Done = false
-	ex.NextReviewAt = time.Now() // Disponible immédiatement
-	ex.CompletedSteps = []int{}  // Aucune étape complétée
+	ex.NextReviewAt = calendar.Current().Now() // Disponible immédiatement
+	ex.CompletedSteps = []int{}                // Aucune étape complétée
 
 	// 5. Defaults visuals si vide
 	if ex.ConceptualVisuals == nil {
@@ -56,6 +58,14 @@ func (s *ExerciseService) CreateExercise(ex *models.Exercise) error {
 		return fmt.Errorf("create exercise in store: %w", err)
 	}
 
+	// 7. Événement (succès)
+	events.Publish(events.Event{
+		Kind:       events.ExerciseCreated,
+		At:         calendar.Current().Now(),
+		ExerciseID: ex.ID,
+		Domain:     ex.Domain,
+	})
+
 	return nil
 }
 
@@ -112,9 +122,11 @@ func (s *ExerciseService) UpdateExercise(ex *models.Exercise) error {
 }
 
 // ReviewExercise : Applique SRS + Log historique
+// (duration = temps de réponse mesuré en session, 0 si inconnu)
 func (s *ExerciseService) ReviewExercise(
 	exerciseID int,
 	quality srs.ReviewQuality,
+	duration time.Duration,
 ) (*models.Exercise, error) {
 	// 1. Récupère depuis store
 	ex, err := store.FindExercise(exerciseID)
@@ -123,7 +135,9 @@ func (s *ExerciseService) ReviewExercise(
 	}
 
 	// 2. Applique SRS (domain)
+	cal := calendar.Current()
 	result := srs.CalculateNextReview(
+		cal,
 		quality,
 		ex.IntervalDays,
 		ex.EaseFactor,
@@ -131,7 +145,8 @@ func (s *ExerciseService) ReviewExercise(
 	)
 
 	// 3. Met à jour modèle
-	now := time.Now()
+	wasNew := ex.LastReviewed == nil // Première révision : consomme le quota du jour
+	now := cal.Now()
 	ex.LastReviewed = &now
 	ex.IntervalDays = result.IntervalDays
 	ex.EaseFactor = result.EaseFactor
@@ -149,10 +164,29 @@ func (s *ExerciseService) ReviewExercise(
 	}
 
 	// 6. Log historique (non-bloquant)
-	if err := store.LogProgress(exerciseID, int(quality), ex); err != nil {
+	if err := store.LogProgress(exerciseID, int(quality), ex, duration); err != nil {
 		fmt.Printf("⚠️ Log progress failed: %v\n", err)
 	}
 
+	// 7. Quota de nouveaux (non-bloquant)
+	if wasNew {
+		if err := store.RecordNewCardIntroduced(cal.Today()); err != nil {
+			fmt.Printf("⚠️ Record new card failed: %v\n", err)
+		}
+	}
+
+	// 8. Streak (objectif du jour)
+	recordStreakActivity()
+
+	// 9. Événement (succès) : publié après le streak pour qu'il soit à jour
+	events.Publish(events.Event{
+		Kind:       events.ReviewRecorded,
+		At:         now,
+		ExerciseID: exerciseID,
+		Domain:     ex.Domain,
+		Quality:    int(quality),
+	})
+
 	return ex, nil
 }
 
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/clock"
+	"maestro/internal/store"
+)
+
+// ============================================
+// HARNESS : SQLite en mémoire + calendrier figé
+// ============================================
+
+// newTestStore : Base neuve et calendrier (fuseau loc) sur une horloge figée
+// à at ; tout est restauré en fin de test
+func newTestStore(t *testing.T, at time.Time, loc *time.Location) *clock.Fixed {
+	t.Helper()
+
+	if err := store.InitDB(store.MemoryDSN); err != nil {
+		t.Fatalf("init db: %v", err)
+	}
+	t.Cleanup(func() { store.CloseDB() })
+
+	clk := clock.NewFixed(at)
+	previous := calendar.Current()
+	calendar.Configure(calendar.Calendar{Location: loc, Clock: clk})
+	t.Cleanup(func() { calendar.Configure(previous) })
+	return clk
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/session"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+// HistoryPageSize : Sessions par page de /sessions
+const HistoryPageSize = 20
+
+type HistoryService struct{}
+
+func NewHistoryService() *HistoryService {
+	return &HistoryService{}
+}
+
+// GetHistory : Page de l'historique filtré (page hors bornes ramenée à la dernière)
+func (s *HistoryService) GetHistory(filter models.SessionHistoryFilter) (models.SessionHistoryPage, error) {
+	if filter.Mode != "" && !slices.Contains(session.Modes(), filter.Mode) {
+		return models.SessionHistoryPage{Filter: filter}, &session.InvalidHistoryFilterError{Field: "mode", Reason: "mode inconnu"}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return models.SessionHistoryPage{Filter: filter}, &session.InvalidHistoryFilterError{Field: "to", Reason: "avant la date de début"}
+	}
+
+	var from, to int64
+	if !filter.From.IsZero() {
+		from = filter.From.Unix()
+	}
+	if !filter.To.IsZero() {
+		to = filter.To.AddDate(0, 0, 1).Unix() // Jour "au" inclus
+	}
+
+	filter.Page = max(filter.Page, 1)
+	sessions, total, err := store.GetSessionHistory(filter.Mode, from, to, HistoryPageSize, (filter.Page-1)*HistoryPageSize)
+	if err != nil {
+		return models.SessionHistoryPage{Filter: filter}, fmt.Errorf("session history: %w", err)
+	}
+
+	pages := max((total+HistoryPageSize-1)/HistoryPageSize, 1)
+	if filter.Page > pages {
+		filter.Page = pages
+		return s.GetHistory(filter)
+	}
+
+	return models.SessionHistoryPage{
+		Sessions:   sessions,
+		Filter:     filter,
+		Total:      total,
+		TotalPages: pages,
+	}, nil
+}
+
+// GetDetail : Session et ses exercices (qualité, temps, évolution SRS)
+func (s *HistoryService) GetDetail(sessionID int64) (*models.SessionDetail, error) {
+	summary, err := store.GetSessionSummary(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("session %d: %w", sessionID, err)
+	}
+	if summary == nil {
+		return nil, &session.SessionNotFoundError{SessionID: sessionID}
+	}
+
+	exercises, err := store.GetSessionExercises(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("session %d exercises: %w", sessionID, err)
+	}
+	return &models.SessionDetail{Summary: *summary, Exercises: exercises}, nil
+}
+
+// GetStats : Sessions par semaine (weeks dernières, semaine courante incluse)
+// et qualité moyenne par mode
+func (s *HistoryService) GetStats(weeks int) models.SessionHistoryStats {
+	cal := calendar.Current()
+	current := cal.WeekStart(cal.Now())
+
+	stats := models.SessionHistoryStats{Weeks: make([]models.WeeklySessions, weeks)}
+	index := make(map[int]int, weeks)
+	for i := range stats.Weeks {
+		start := cal.Date(current.Year(), current.Month(), current.Day()-7*(weeks-1-i))
+		stats.Weeks[i].WeekStart = start
+		index[cal.DayKey(start)] = i
+	}
+
+	starts, err := store.GetSessionStartTimes(stats.Weeks[0].WeekStart.Unix())
+	if err != nil {
+		log.Printf("❌ [History] %v", err)
+	}
+	for _, t := range starts {
+		if i, ok := index[cal.DayKey(cal.WeekStart(t))]; ok {
+			stats.Weeks[i].Sessions++
+		}
+	}
+
+	modes, err := store.GetModeQuality()
+	if err != nil {
+		log.Printf("❌ [History] %v", err)
+	}
+	for _, mode := range session.Modes() {
+		i := slices.IndexFunc(modes, func(m models.ModeQuality) bool { return m.Mode == mode })
+		if i < 0 {
+			stats.Modes = append(stats.Modes, models.ModeQuality{Mode: mode})
+		} else {
+			stats.Modes = append(stats.Modes, modes[i])
+		}
+	}
+	return stats
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"maestro/internal/domain/srs"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+// Les états calculés en SQL (dashboard, filtre) doivent suivre srs.Mastery
+func TestMasteryStatesMatchDomain(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	newTestStore(t, now, time.UTC)
+
+	cases := []struct {
+		daysAgo  int // -1 = jamais révisé
+		interval int
+		done     bool
+	}{
+		{-1, 0, false},
+		{1, 3, true},
+		{5, 10, true},
+		{40, 10, true}, // Oublié : Done mais Learning
+		{10, 30, true},
+		{30, 90, true},
+		{90, 90, true},  // À l'échéance : encore Mastered
+		{120, 90, true}, // En retard : Mature
+	}
+
+	want := make(map[srs.MasteryState]int)
+	for i, c := range cases {
+		ex := models.Exercise{Title: "Ex" + string(rune('A'+i)), Domain: "Go", Difficulty: 2}
+		if err := store.CreateExercise(&ex); err != nil {
+			t.Fatalf("create exercise: %v", err)
+		}
+		if c.daysAgo >= 0 {
+			last := now.AddDate(0, 0, -c.daysAgo)
+			ex.LastReviewed = &last
+		}
+		ex.IntervalDays = c.interval
+		ex.Done = c.done
+		ex.EaseFactor = 2.5
+		ex.NextReviewAt = now
+		if err := store.SaveExercise(&ex); err != nil {
+			t.Fatalf("save exercise: %v", err)
+		}
+		want[srs.Mastery(ex.LastReviewed, ex.IntervalDays, now)]++
+	}
+
+	stats := NewDashboardService().GetDashboardStats()
+	got := map[srs.MasteryState]int{
+		srs.StateNew:      stats.Mastery.New,
+		srs.StateLearning: stats.Mastery.Learning,
+		srs.StateYoung:    stats.Mastery.Young,
+		srs.StateMature:   stats.Mastery.Mature,
+		srs.StateMastered: stats.Mastery.Mastered,
+	}
+	for _, state := range srs.MasteryStates {
+		if got[state] != want[state] {
+			t.Errorf("%s = %d, want %d", state, got[state], want[state])
+		}
+
+		list, err := store.GetFiltered(models.ExerciseFilter{Status: string(state)})
+		if err != nil {
+			t.Fatalf("filter %s: %v", state, err)
+		}
+		if len(list) != want[state] {
+			t.Errorf("filtre %s = %d exercices, want %d", state, len(list), want[state])
+		}
+	}
+
+	if want[srs.StateMastered] != 2 || stats.MasteredRate != 25 {
+		t.Errorf("mastered = %d (%d%%), want 2 (25%%)", want[srs.StateMastered], stats.MasteredRate)
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"maestro/internal/domain/session"
+	"maestro/internal/domain/srs"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+// Les nouveaux forment une file séparée, bornée par le quota quotidien
+func TestNewCardQuota(t *testing.T) {
+	clk := newTestStore(t, time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC), time.UTC)
+
+	if err := store.SetNewCardPolicy(session.NewCardPolicy{DailyLimit: 2, Ratio: 100}); err != nil {
+		t.Fatalf("set policy: %v", err)
+	}
+
+	exercises := NewExerciseService()
+	sessions := NewSessionService()
+	for i := 0; i < 5; i++ {
+		ex := models.Exercise{Title: "Ex" + string(rune('A'+i)), Domain: "Go", Difficulty: 2}
+		if err := exercises.CreateExercise(&ex); err != nil {
+			t.Fatalf("create exercise: %v", err)
+		}
+	}
+
+	report, ids, err := sessions.SelectExercises(session.GetConfig(models.EnergyHigh), session.DefaultFocus())
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if report.TodayDue != 0 || report.TodayNew != 2 || report.NewQueued != 5 || len(ids) != 2 {
+		t.Fatalf("report = %+v, ids = %v; want 0 due, 2/5 new, 2 selected", report, ids)
+	}
+
+	// Première révision : consomme le quota, une seconde révision non
+	for range 2 {
+		if _, err := exercises.ReviewExercise(ids[0], srs.Good, time.Minute); err != nil {
+			t.Fatalf("review: %v", err)
+		}
+	}
+	status := sessions.NewCardStatus(7)
+	if status.Introduced != 1 || status.Queued != 4 || len(status.History) != 7 || status.History[6].Introduced != 1 {
+		t.Errorf("status = %+v, want 1 introduced, 4 queued, 7 days", status)
+	}
+	if _, ids, _ = sessions.SelectExercises(session.GetConfig(models.EnergyHigh), session.DefaultFocus()); len(ids) != 1 {
+		t.Errorf("selected %v after one introduction, want 1 new", ids)
+	}
+
+	// Lendemain : quota renouvelé, l'historique garde la veille
+	clk.Advance(24 * time.Hour)
+	if status := sessions.NewCardStatus(7); status.Introduced != 0 || status.History[5].Introduced != 1 {
+		t.Errorf("next day status = %+v, want 0 introduced today, 1 yesterday", status)
+	}
+	report, ids, err = sessions.SelectExercises(session.GetConfig(models.EnergyHigh), session.DefaultFocus())
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if report.TodayNew != 2 || len(ids) != 2 {
+		t.Errorf("next day report = %+v, ids = %v; want 2 new", report, ids)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	"maestro/internal/domain/calendar"
 	"maestro/internal/domain/planner"
 	"maestro/internal/models"
 	"maestro/internal/store"
@@ -17,21 +18,34 @@ func NewPlannerService() *PlannerService {
 }
 
 func (s *PlannerService) GetReviewsForDate(date time.Time) []models.Exercise {
-	allExercises := store.GetAll()
-	reviews := planner.GetReviewsForDate(allExercises, date)
+	cal := calendar.Current()
+	day := cal.DayKey(date)
+	candidates, err := store.GetExercisesDueBetween(day, day)
+	if err != nil {
+		log.Printf("❌ [PlannerService] %v", err)
+	}
+	reviews := planner.GetReviewsForDate(cal, candidates, date)
 
 	log.Printf("🔍 [PlannerService] %d révision(s) pour %s", len(reviews), date.Format("2006-01-02"))
 	return reviews
 }
 
 func (s *PlannerService) GetOverdueReviews() []models.Exercise {
-	allExercises := store.GetAll()
-	return planner.GetOverdueReviews(allExercises)
+	cal := calendar.Current()
+	candidates, err := store.GetExercisesDueBetween(1, cal.AddDays(cal.Today(), -1))
+	if err != nil {
+		log.Printf("❌ [PlannerService] %v", err)
+	}
+	return planner.GetOverdueReviews(cal, candidates)
 }
 
 func (s *PlannerService) GetUpcomingReviews(limit int) []models.Exercise {
-	allExercises := store.GetAll()
-	return planner.GetUpcomingReviews(allExercises, limit)
+	cal := calendar.Current()
+	candidates, err := store.GetExercisesDueAfter(cal.Today(), limit)
+	if err != nil {
+		log.Printf("❌ [PlannerService] %v", err)
+	}
+	return planner.GetUpcomingReviews(cal, candidates, limit)
 }
 
 func (s *PlannerService) GetWeekSchedule(startDate time.Time) []models.DaySchedule {
@@ -56,14 +70,20 @@ func (s *PlannerService) GetWeekSchedule(startDate time.Time) []models.DaySchedu
 
 func (s *PlannerService) GetMonthSchedule(year int, month time.Month) map[int]int {
 	counts := make(map[int]int)
-	allExercises := store.GetAll()
-	for _, ex := range allExercises {
+	cal := calendar.Current()
+	first := cal.DayKey(cal.Date(year, month, 1))
+	last := cal.DayKey(cal.Date(year, month+1, 0))
+	exercises, err := store.GetExercisesDueBetween(first, last)
+	if err != nil {
+		log.Printf("❌ [PlannerService] %v", err)
+	}
+	for _, ex := range exercises {
 		if ex.NextReviewAt.IsZero() {
 			continue
 		}
-		if ex.NextReviewAt.Year() == year && ex.NextReviewAt.Month() == month {
-			day := ex.NextReviewAt.Day()
-			counts[day]++
+		key := cal.DayKey(ex.NextReviewAt)
+		if key/10000 == year && time.Month((key%10000)/100) == month {
+			counts[key%100]++
 		}
 	}
 	return counts
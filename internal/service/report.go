@@ -0,0 +1,201 @@
+// internal/service/report.go
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/report"
+	"maestro/internal/models"
+	"maestro/internal/store"
+	reportview "maestro/internal/views/report"
+)
+
+const (
+	reportWeakDomains  = 3  // Domaines les plus faibles listés
+	reportLeeches      = 5  // Exercices en échec répété listés
+	reportNewLearned   = 10 // Nouveaux exercices listés (le total reste exact)
+	reportWorkloadDays = 7  // Horizon de la charge à venir
+)
+
+// ReportService : Rapports de progression hebdo / mensuels (HTML + Markdown)
+type ReportService struct {
+	dashboard *DashboardService
+}
+
+func NewReportService() *ReportService {
+	return &ReportService{dashboard: NewDashboardService()}
+}
+
+// Build : Rapport de la dernière période terminée avant ref
+func (s *ReportService) Build(kind report.Kind, ref time.Time) (models.ProgressReport, error) {
+	cal := calendar.Current()
+	period := report.LastCompleted(cal, kind, ref)
+
+	r := models.ProgressReport{
+		Kind:        string(kind),
+		Label:       period.Label(),
+		From:        period.From,
+		To:          period.To,
+		GeneratedAt: cal.Now(),
+		PeriodDays:  period.Days(cal),
+	}
+
+	// 1. Activité de la période (+ précédente pour comparaison)
+	totals, err := store.GetPeriodReviewTotals(period.From.Unix(), period.To.Unix())
+	if err != nil {
+		return r, fmt.Errorf("build report: %w", err)
+	}
+	r.Reviews = totals.Reviews
+	r.Lapses = totals.Lapses
+	r.RetentionRate = percent(totals.Successful, totals.Reviews)
+
+	prev := period.Previous(cal)
+	prevTotals, err := store.GetPeriodReviewTotals(prev.From.Unix(), prev.To.Unix())
+	if err != nil {
+		return r, fmt.Errorf("build report: %w", err)
+	}
+	r.PrevReviews = prevTotals.Reviews
+	r.PrevRetentionRate = percent(prevTotals.Successful, prevTotals.Reviews)
+
+	daily, err := store.GetDailyReviewCounts(cal, period.From.Unix())
+	if err != nil {
+		return r, fmt.Errorf("build report: %w", err)
+	}
+	from, to := cal.DayKey(period.From), cal.DayKey(period.To)
+	for day, count := range daily {
+		if day >= from && day < to && count > 0 {
+			r.ActiveDays++
+		}
+	}
+
+	minutes, err := store.GetSessionMinutes(period.From.Unix(), period.To.Unix())
+	if err != nil {
+		return r, fmt.Errorf("build report: %w", err)
+	}
+	r.TimeSpent = time.Duration(minutes) * time.Minute
+
+	// 2. Apprentissage
+	r.NewLearnedCount, r.NewLearned, err = store.GetNewlyLearned(period.From.Unix(), period.To.Unix(), reportNewLearned)
+	if err != nil {
+		return r, fmt.Errorf("build report: %w", err)
+	}
+
+	// 3. Streak (état courant)
+	goal := NewStreakService().GetGoalProgress()
+	r.CurrentStreak = goal.CurrentStreak
+	r.LongestStreak = goal.LongestStreak
+
+	// 4. Points faibles (DashboardService : même analyse que le tableau de bord)
+	strengths := s.dashboard.GetDomainStrengths()
+	for i := len(strengths) - 1; i >= 0 && len(r.WeakestDomains) < reportWeakDomains; i-- {
+		r.WeakestDomains = append(r.WeakestDomains, strengths[i])
+	}
+	r.Leeches = s.dashboard.GetFailurePatterns(reportLeeches)
+
+	// 5. Charge à venir (à partir du jour de génération)
+	today := cal.Today()
+	overdue, err := store.GetDueCounts(1, cal.AddDays(today, -1))
+	if err != nil {
+		return r, fmt.Errorf("build report: %w", err)
+	}
+	for _, count := range overdue {
+		r.OverdueCount += count
+	}
+
+	last := cal.AddDays(today, reportWorkloadDays-1)
+	due, err := store.GetDueCounts(today, last)
+	if err != nil {
+		return r, fmt.Errorf("build report: %w", err)
+	}
+	for day := today; day <= last; day = cal.AddDays(day, 1) {
+		r.Upcoming = append(r.Upcoming, models.WorkloadDay{Date: cal.FromDayKey(day), Count: due[day]})
+		r.UpcomingTotal += due[day]
+	}
+
+	return r, nil
+}
+
+// FileName : Nom de base des fichiers d'un rapport ("weekly-2026-W15")
+func FileName(r models.ProgressReport) string {
+	return r.Kind + "-" + r.Label
+}
+
+// WriteFiles : Écrit <dir>/<kind>-<label>.html et .md, retourne leurs chemins
+func (s *ReportService) WriteFiles(r models.ProgressReport, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create report dir: %w", err)
+	}
+
+	var html bytes.Buffer
+	if err := reportview.ProgressReport(r).Render(context.Background(), &html); err != nil {
+		return nil, fmt.Errorf("render report html: %w", err)
+	}
+
+	base := filepath.Join(dir, FileName(r))
+	files := map[string][]byte{
+		base + ".html": html.Bytes(),
+		base + ".md":   []byte(reportview.Markdown(r)),
+	}
+
+	paths := make([]string, 0, len(files))
+	for _, path := range []string{base + ".html", base + ".md"} {
+		if err := os.WriteFile(path, files[path], 0o644); err != nil {
+			return nil, fmt.Errorf("write report: %w", err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// GenerateMissing : Écrit le rapport de chaque période terminée absent de dir
+// (fréquences kinds uniquement)
+func (s *ReportService) GenerateMissing(dir string, kinds []report.Kind) error {
+	cal := calendar.Current()
+	now := cal.Now()
+
+	for _, kind := range kinds {
+		period := report.LastCompleted(cal, kind, now)
+		name := FileName(models.ProgressReport{Kind: string(kind), Label: period.Label()}) + ".html"
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			continue
+		}
+
+		r, err := s.Build(kind, now)
+		if err != nil {
+			return err
+		}
+		paths, err := s.WriteFiles(r, dir)
+		if err != nil {
+			return err
+		}
+		log.Printf("📝 Rapport %s %s → %v", kind, r.Label, paths)
+	}
+	return nil
+}
+
+// RunSchedule : Job périodique, dossier relu à chaque passage (réglage report_dir)
+func (s *ReportService) RunSchedule(interval time.Duration) {
+	for {
+		if dir := store.GetSetting(store.SettingReportDir, ""); dir != "" {
+			if err := s.GenerateMissing(dir, report.Kinds); err != nil {
+				log.Printf("❌ [Reports] %v", err)
+			}
+		}
+		time.Sleep(interval)
+	}
+}
+
+// percent : part en % (0 si total nul)
+func percent(part, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return part * 100 / total
+}
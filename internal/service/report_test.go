@@ -0,0 +1,115 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"maestro/internal/domain/report"
+	"maestro/internal/domain/srs"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+func TestWeeklyReport(t *testing.T) {
+	// Semaine 2026-W15 : lundi 6 → dimanche 12 avril
+	clk := newTestStore(t, time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC), time.UTC)
+
+	old := models.Exercise{Title: "Channels", Domain: "Go", Difficulty: 2, EaseFactor: 2.5}
+	fresh := models.Exercise{Title: "Dijkstra", Domain: "Algo", Difficulty: 3, EaseFactor: 2.5}
+	for _, ex := range []*models.Exercise{&old, &fresh} {
+		if err := store.CreateExercise(ex); err != nil {
+			t.Fatalf("create exercise: %v", err)
+		}
+	}
+
+	review := func(id int, quality srs.ReviewQuality) {
+		if _, err := NewExerciseService().ReviewExercise(id, quality, 0); err != nil {
+			t.Fatalf("review: %v", err)
+		}
+	}
+
+	// Semaine précédente : 1 review (old)
+	review(old.ID, 2)
+
+	// W15 : mardi 2 reviews, jeudi 1 oubli + premier passage de fresh
+	clk.Set(time.Date(2026, 4, 7, 10, 0, 0, 0, time.UTC))
+	review(old.ID, 3)
+	review(old.ID, 2)
+	clk.Set(time.Date(2026, 4, 9, 10, 0, 0, 0, time.UTC))
+	review(old.ID, 0)
+	review(fresh.ID, 2)
+
+	// Hors période (semaine suivante)
+	clk.Set(time.Date(2026, 4, 14, 10, 0, 0, 0, time.UTC))
+	review(fresh.ID, 3)
+
+	s := NewReportService()
+	r, err := s.Build(report.Weekly, clk.Now())
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	if r.Label != "2026-W15" || r.PeriodDays != 7 {
+		t.Errorf("période = %s (%d jours)", r.Label, r.PeriodDays)
+	}
+	if r.Reviews != 4 || r.Lapses != 1 || r.RetentionRate != 75 {
+		t.Errorf("activité = %d reviews, %d oublis, %d%%", r.Reviews, r.Lapses, r.RetentionRate)
+	}
+	if r.PrevReviews != 1 || r.PrevRetentionRate != 100 {
+		t.Errorf("période précédente = %d reviews, %d%%", r.PrevReviews, r.PrevRetentionRate)
+	}
+	if r.ActiveDays != 2 {
+		t.Errorf("ActiveDays = %d, want 2", r.ActiveDays)
+	}
+	if r.NewLearnedCount != 1 || r.NewLearned[0].Title != "Dijkstra" {
+		t.Errorf("nouveaux = %d %+v", r.NewLearnedCount, r.NewLearned)
+	}
+	if len(r.Upcoming) != 7 {
+		t.Errorf("Upcoming = %d jours, want 7", len(r.Upcoming))
+	}
+
+	// Fichiers : écrits une seule fois par période
+	dir := t.TempDir()
+	if err := s.GenerateMissing(dir, report.Kinds); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	md, err := os.ReadFile(filepath.Join(dir, "weekly-2026-W15.md"))
+	if err != nil {
+		t.Fatalf("read markdown: %v", err)
+	}
+	if !strings.Contains(string(md), "| Révisions | 4 | 1 (+300%) |") {
+		t.Errorf("markdown inattendu:\n%s", md)
+	}
+	html, err := os.ReadFile(filepath.Join(dir, "monthly-2026-03.html"))
+	if err != nil {
+		t.Fatalf("read html: %v", err)
+	}
+	if !strings.Contains(string(html), "<style>") || !strings.Contains(string(html), "Rapport mensuel 2026-03") {
+		t.Errorf("html non autonome ou titre absent")
+	}
+
+	stamp := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	path := filepath.Join(dir, "weekly-2026-W15.md")
+	os.Chtimes(path, stamp, stamp)
+	if err := s.GenerateMissing(dir, report.Kinds); err != nil {
+		t.Fatalf("generate again: %v", err)
+	}
+	if info, _ := os.Stat(path); !info.ModTime().Equal(stamp) {
+		t.Errorf("rapport existant réécrit")
+	}
+
+	// Fréquence choisie uniquement (-period weekly -schedule)
+	weeklyDir := t.TempDir()
+	if err := s.GenerateMissing(weeklyDir, []report.Kind{report.Weekly}); err != nil {
+		t.Fatalf("generate weekly: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(weeklyDir, "weekly-2026-W15.html")); err != nil {
+		t.Errorf("rapport hebdo absent: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(weeklyDir, "monthly-2026-03.html")); err == nil {
+		t.Errorf("rapport mensuel écrit alors que seul weekly est demandé")
+	}
+}
@@ -0,0 +1,57 @@
+// internal/service/retention.go
+package service
+
+import (
+	"log"
+
+	"maestro/internal/domain/srs"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+type RetentionService struct{}
+
+func NewRetentionService() *RetentionService {
+	return &RetentionService{}
+}
+
+// GetRetentionCurve : Rappel observé vs prédit par tranche d'intervalle écoulé
+// (domain "" / difficulty 0 = tous)
+func (s *RetentionService) GetRetentionCurve(domain string, difficulty int) models.RetentionCurve {
+	curve := models.RetentionCurve{
+		Domain:     domain,
+		Difficulty: difficulty,
+		Buckets:    make([]models.RetentionBucket, len(srs.RecallBuckets)),
+	}
+	for i, b := range srs.RecallBuckets {
+		curve.Buckets[i].Label = b.Label
+	}
+
+	groups, err := store.GetRecallPairs(domain, difficulty)
+	if err != nil {
+		log.Printf("❌ [Retention] %v", err)
+		return curve
+	}
+
+	// Agrège : rappels observés + somme des rappels prédits (pondérée)
+	recalled := make([]int, len(curve.Buckets))
+	predicted := make([]float64, len(curve.Buckets))
+	for _, g := range groups {
+		elapsedDays := (float64(g.ElapsedHours) + 0.5) / 24
+		i := srs.BucketIndex(elapsedDays)
+
+		curve.Buckets[i].Reviews += g.Count
+		recalled[i] += g.Recalled
+		predicted[i] += srs.PredictedRecall(elapsedDays, g.PrevInterval) * float64(g.Count)
+		curve.TotalPairs += g.Count
+	}
+
+	for i := range curve.Buckets {
+		if n := curve.Buckets[i].Reviews; n > 0 {
+			curve.Buckets[i].Observed = float64(recalled[i]) / float64(n)
+			curve.Buckets[i].Predicted = predicted[i] / float64(n)
+		}
+	}
+
+	return curve
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+func TestGetRetentionCurve(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	clk := newTestStore(t, start, time.UTC)
+
+	// review : (jours depuis le début, qualité, intervalle planifié ensuite)
+	type review struct {
+		day      int
+		quality  int
+		interval int
+	}
+	seed := func(title, domain string, difficulty int, reviews []review) int {
+		ex := models.Exercise{Title: title, Domain: domain, Difficulty: difficulty, EaseFactor: 2.5}
+		if err := store.CreateExercise(&ex); err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+		for _, r := range reviews {
+			clk.Set(start.AddDate(0, 0, r.day))
+			ex.IntervalDays = r.interval
+			if err := store.LogProgress(ex.ID, r.quality, &ex, 0); err != nil {
+				t.Fatalf("log progress: %v", err)
+			}
+		}
+		return ex.ID
+	}
+
+	// Go : 1 → 2 jours (rappel), 2 → 4 jours (rappel), 4 → 10 jours (oubli)
+	seed("Channels", "Go", 2, []review{{0, 2, 2}, {2, 2, 4}, {6, 3, 10}, {16, 0, 0}})
+	// Algorithms : 1 jour (oubli)
+	seed("Heap", "Algorithms", 4, []review{{0, 1, 1}, {1, 0, 0}})
+	// Supprimé : historique ignoré
+	deleted := seed("Mutex", "Go", 2, []review{{0, 2, 2}, {2, 0, 0}})
+	if err := store.DeleteExercise(deleted); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	s := NewRetentionService()
+
+	all := s.GetRetentionCurve("", 0)
+	if all.TotalPairs != 4 {
+		t.Fatalf("TotalPairs = %d, want 4", all.TotalPairs)
+	}
+
+	bucket := func(c models.RetentionCurve, label string) models.RetentionBucket {
+		for _, b := range c.Buckets {
+			if b.Label == label {
+				return b
+			}
+		}
+		t.Fatalf("bucket %s absent", label)
+		return models.RetentionBucket{}
+	}
+
+	if b := bucket(all, "1-3d"); b.Reviews != 2 || b.Observed != 0.5 {
+		t.Errorf("1-3d = %d reviews, %.2f observé, want 2, 0.50", b.Reviews, b.Observed)
+	}
+	if b := bucket(all, "3-7d"); b.Reviews != 1 || b.Observed != 1 {
+		t.Errorf("3-7d = %d reviews, %.2f observé, want 1, 1.00", b.Reviews, b.Observed)
+	}
+	if b := bucket(all, "7-14d"); b.Reviews != 1 || b.Observed != 0 {
+		t.Errorf("7-14d = %d reviews, %.2f observé, want 1, 0.00", b.Reviews, b.Observed)
+	}
+	// Revue pile à l'échéance → rappel prédit ≈ 90%
+	if b := bucket(all, "3-7d"); b.Predicted < 0.89 || b.Predicted > 0.91 {
+		t.Errorf("3-7d prédit = %.3f, want ≈ 0.90", b.Predicted)
+	}
+
+	if got := s.GetRetentionCurve("Go", 0).TotalPairs; got != 3 {
+		t.Errorf("domaine Go : %d paires, want 3", got)
+	}
+	if got := s.GetRetentionCurve("", 4).TotalPairs; got != 1 {
+		t.Errorf("difficulté 4 : %d paires, want 1", got)
+	}
+	if got := s.GetRetentionCurve("Go", 4).TotalPairs; got != 0 {
+		t.Errorf("Go + difficulté 4 : %d paires, want 0", got)
+	}
+}
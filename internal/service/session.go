@@ -3,9 +3,14 @@ package service
 import (
 	"database/sql"
 	"fmt"
+	"log"
+	"math/rand/v2"
+	"sort"
 	"time"
 
+	"maestro/internal/domain/calendar"
 	"maestro/internal/domain/session" // ✅ NOUVEAU
+	"maestro/internal/events"
 	"maestro/internal/models"
 	"maestro/internal/store"
 )
@@ -16,15 +21,13 @@ func NewSessionService() *SessionService {
 	return &SessionService{}
 }
 
-// StartSession : Crée une session adaptative
+// StartSession : Crée une session adaptative à partir d'un template
 func (s *SessionService) StartSession(
-	energy models.EnergyLevel,
+	config session.Config, // Template choisi (mode, énergie, pauses)
 	exerciseIDs []int, // ✅ Reçoit directement les IDs limités
+	focus session.Focus, // Ordre d'enchaînement + minuteur
 ) (int64, *models.AdaptiveSession, error) {
-	// 1. Récupère config depuis domain
-	config := session.GetConfig(energy)
-
-	// 2. Charge exercices complets depuis store
+	// 1. Charge exercices complets depuis store
 	exercises := make([]models.Exercise, 0, len(exerciseIDs))
 	for _, id := range exerciseIDs {
 		ex, err := store.FindExercise(id)
@@ -34,22 +37,35 @@ func (s *SessionService) StartSession(
 		exercises = append(exercises, *ex)
 	}
 
-	// 3. Trie par priorité (domain)
-	exercises = session.SortByPriority(exercises)
+	// 2. Ordonne selon la stratégie choisie (domain)
+	cal := calendar.Current()
+	ordering := focus.Ordering
+	if ordering == "" {
+		ordering = session.OrderPriority
+	}
+	seed := uint64(cal.Now().UnixNano())
+	exercises = session.Order(cal, exercises, ordering, rand.New(rand.NewPCG(seed, seed>>32)))
+
+	ordered := make([]int, len(exercises))
+	for i, ex := range exercises {
+		ordered[i] = ex.ID
+	}
 
-	// 4. Build session model
+	// 3. Build session model
 	sessionModel := models.AdaptiveSession{
 		Mode:          config.Mode,
-		EnergyLevel:   energy,
-		EstimatedTime: config.Duration,
-		Exercises:     exerciseIDs, // Garde les IDs uniquement
+		EnergyLevel:   config.Level,
+		Ordering:      string(ordering),
+		EstimatedTime: s.EstimateSessionTime(exerciseIDs, config),
+		TimeBudget:    focus.Timer,
+		Exercises:     ordered, // Garde les IDs uniquement (ordre de passage)
 		BreakSchedule: config.BreakSchedule,
-		StartedAt:     time.Now(),
+		StartedAt:     cal.Now(),
 		CurrentIndex:  0,
 	}
 
-	// 5. Stocke dans SQLite
-	sessionID, err := store.StartSession(energy, exercises)
+	// 4. Stocke dans SQLite
+	sessionID, err := store.StartSession(config, exercises, ordering, focus.Timer, focus.Practice)
 	if err != nil {
 		return 0, nil, fmt.Errorf("start session: %w", err)
 	}
@@ -60,18 +76,202 @@ func (s *SessionService) StartSession(
 }
 
 // CompleteExercise : Marque un exercice comme complété dans la session
-func (s *SessionService) CompleteExercise(sessionID int64, exerciseID int, quality int) error {
-	if err := store.CompleteSessionExercise(sessionID, exerciseID, quality); err != nil {
+func (s *SessionService) CompleteExercise(sessionID int64, exerciseID int, quality int, duration time.Duration) error {
+	if err := store.CompleteSessionExercise(sessionID, exerciseID, quality, duration); err != nil {
 		return fmt.Errorf("complete exercise %d in session %d: %w", exerciseID, sessionID, err)
 	}
 	return nil
 }
 
+// MarkShown : Horodate l'affichage d'un exercice de la session (après une
+// interruption, un nouveau bloc de concentration commence)
+func (s *SessionService) MarkShown(sessionID int64, exerciseID int) error {
+	if err := s.trackFocus(sessionID); err != nil {
+		return err
+	}
+	if err := store.MarkSessionExerciseShown(sessionID, exerciseID); err != nil {
+		return fmt.Errorf("mark exercise %d shown in session %d: %w", exerciseID, sessionID, err)
+	}
+	return nil
+}
+
+// AnswerDuration : Temps de réponse depuis l'affichage (0 si non mesurable)
+func (s *SessionService) AnswerDuration(sessionID int64, exerciseID int) time.Duration {
+	shownAt, err := store.GetSessionExerciseShownAt(sessionID, exerciseID)
+	if err != nil {
+		return 0
+	}
+
+	d, ok := session.AnswerDuration(shownAt, calendar.Current().Now())
+	if !ok {
+		return 0
+	}
+	return d
+}
+
+// EstimateSessionTime : Durée estimée depuis les temps moyens réels
+// (exercice → moyenne globale → template)
+func (s *SessionService) EstimateSessionTime(exerciseIDs []int, config session.Config) time.Duration {
+	times := answerTimes(exerciseIDs)
+	perExercise := make([]time.Duration, len(exerciseIDs))
+	for i, id := range exerciseIDs {
+		perExercise[i] = times[id]
+	}
+	return session.EstimateSessionTime(perExercise, config)
+}
+
+// answerTimes : Temps moyen par exercice (moyenne globale si jamais chronométré,
+// 0 si aucune donnée : le template prend le relais)
+func answerTimes(exerciseIDs []int) map[int]time.Duration {
+	durations := make(map[int]time.Duration, len(exerciseIDs))
+
+	times, err := store.GetExerciseAnswerTimes(exerciseIDs)
+	if err != nil {
+		fmt.Printf("⚠️ Answer times failed: %v\n", err)
+		return durations
+	}
+	_, globalAvg, err := store.GetAnswerTimeTotals()
+	if err != nil {
+		fmt.Printf("⚠️ Answer time totals failed: %v\n", err)
+	}
+
+	for _, id := range exerciseIDs {
+		sec, ok := times[id]
+		if !ok {
+			sec = globalAvg
+		}
+		durations[id] = time.Duration(sec) * time.Second
+	}
+	return durations
+}
+
+// ============================================
+// SESSION BUILDER (focus)
+// ============================================
+
+// SelectExercises : Exercices du jour retenus par le focus du builder (au
+// plus le maximum du template). Entraînement : tous les exercices, dus ou
+// non, sans quota de nouveaux.
+func (s *SessionService) SelectExercises(config session.Config, focus session.Focus) (models.SessionReport, []int, error) {
+	if err := focus.Validate(); err != nil {
+		return models.SessionReport{}, nil, err
+	}
+
+	report, candidates, err := store.GetTodayReport()
+	if err != nil {
+		return report, nil, fmt.Errorf("today report: %w", err)
+	}
+
+	// Nouveaux : quota restant du jour, ratio des réglages en mode "Auto"
+	policy := store.GetNewCardPolicy()
+	if focus.NewRatio == session.RatioAuto && !focus.Practice {
+		focus.NewRatio = policy.Ratio
+	}
+	newQuota := policy.Remaining(report.NewIntroduced)
+
+	if focus.Practice {
+		if candidates, err = store.GetFiltered(models.ExerciseFilter{}); err != nil {
+			return report, nil, fmt.Errorf("practice exercises: %w", err)
+		}
+		newQuota = len(candidates) // Aucun nouveau n'est introduit
+	}
+
+	ids := make([]int, len(candidates))
+	for i, ex := range candidates {
+		ids[i] = ex.ID
+	}
+	times := answerTimes(ids)
+	estimate := func(ex models.Exercise) time.Duration {
+		return session.EstimateSessionTime([]time.Duration{times[ex.ID]}, config)
+	}
+
+	selected := session.Select(calendar.Current(), candidates, focus, newQuota, config.MaxExercises, estimate)
+	exerciseIDs := make([]int, len(selected))
+	for i, ex := range selected {
+		exerciseIDs[i] = ex.ID
+	}
+	return report, exerciseIDs, nil
+}
+
+// BuilderOptions : Domaines et tags ayant des exercices disponibles aujourd'hui
+func (s *SessionService) BuilderOptions() (domains, tags []models.BuilderDomain) {
+	_, candidates, err := store.GetTodayReport()
+	if err != nil {
+		log.Printf("❌ [Session] %v", err)
+		return nil, nil
+	}
+
+	cal := calendar.Current()
+	domains = countAvailable(cal, candidates, func(ex models.Exercise) []string { return []string{ex.Domain} })
+	tags = countAvailable(cal, candidates, func(ex models.Exercise) []string { return ex.Tags })
+	return domains, tags
+}
+
+// countAvailable : Dus / nouveaux par clé (domaine ou tag), triés par nom
+func countAvailable(cal calendar.Calendar, candidates []models.Exercise, keys func(models.Exercise) []string) []models.BuilderDomain {
+	index := make(map[string]int)
+	var counts []models.BuilderDomain
+	for _, ex := range candidates {
+		for _, key := range keys(ex) {
+			i, ok := index[key]
+			if !ok {
+				i = len(counts)
+				index[key] = i
+				counts = append(counts, models.BuilderDomain{Name: key})
+			}
+			if session.IsNew(ex) {
+				counts[i].New++
+			} else if session.IsOverdue(cal, ex) || session.IsDueToday(cal, ex) {
+				counts[i].Due++
+			}
+		}
+	}
+
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Name < counts[j].Name })
+	return counts
+}
+
+// NewCardStatus : Quota de nouveaux du jour et historique récent (builder)
+func (s *SessionService) NewCardStatus(days int) models.NewCardStatus {
+	policy := store.GetNewCardPolicy()
+	status := models.NewCardStatus{
+		Limit:      policy.DailyLimit,
+		Ratio:      policy.Ratio,
+		Introduced: store.GetNewCardsIntroduced(calendar.Current().Today()),
+		Queued:     store.CountNewQueued(),
+	}
+
+	var err error
+	if status.History, err = store.GetNewCardHistory(days); err != nil {
+		fmt.Printf("⚠️ New card history failed: %v\n", err)
+	}
+	return status
+}
+
+// EstimateForTemplate : Durée estimée d'une session démarrée maintenant avec ce template
+func (s *SessionService) EstimateForTemplate(config session.Config) time.Duration {
+	_, ids, err := s.SelectExercises(config, config.BaseFocus())
+	if err != nil {
+		fmt.Printf("⚠️ Today report failed: %v\n", err)
+		return config.Duration
+	}
+	return s.EstimateSessionTime(ids, config)
+}
+
 // EndSession : Termine une session
 func (s *SessionService) EndSession(sessionID int64) error {
 	if err := store.EndSession(sessionID); err != nil {
 		return fmt.Errorf("end session %d: %w", sessionID, err)
 	}
+
+	// Objectif en minutes : la durée n'est connue qu'en fin de session
+	recordStreakActivity()
+
+	events.Publish(events.Event{
+		Kind:      events.SessionEnded,
+		At:        calendar.Current().Now(),
+		SessionID: sessionID,
+	})
 	return nil
 }
 
@@ -86,11 +286,119 @@ func (s *SessionService) GetActiveSession() (int64, error) {
 
 // ClearAllSessions : Ferme toutes les sessions actives
 func (s *SessionService) ClearAllSessions() error {
-	sessionID, err := s.GetActiveSession()
-	if err != nil || sessionID == 0 {
-		return err
+	open, err := store.GetOpenSessions()
+	if err != nil {
+		return fmt.Errorf("clear sessions: %w", err)
+	}
+	for _, o := range open {
+		if err := s.EndSession(o.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ============================================
+// REPRISE / INACTIVITÉ
+// ============================================
+
+// GetResumable : Session ouverte la plus récente encore reprenable (nil sinon)
+func (s *SessionService) GetResumable() *models.OpenSession {
+	open, err := store.GetOpenSessions()
+	if err != nil {
+		log.Printf("❌ [Session] %v", err)
+		return nil
+	}
+
+	now := calendar.Current().Now()
+	timeout := store.GetSessionIdleTimeout()
+	for _, o := range open {
+		if !session.IsIdle(o.LastActivity, now, timeout) {
+			return &o
+		}
+	}
+	return nil
+}
+
+// ResumeSession : Prochain exercice d'une session ouverte (0 = plus rien ou
+// minuteur écoulé, la session est alors terminée). Une session inactive est
+// abandonnée.
+func (s *SessionService) ResumeSession(sessionID int64) (int, error) {
+	status, err := store.GetSessionStatus(sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("resume session %d: %w", sessionID, err)
+	}
+	if status != session.StatusActive {
+		return 0, &session.SessionClosedError{SessionID: sessionID, Status: status}
+	}
+
+	open, err := store.GetOpenSessions()
+	if err != nil {
+		return 0, fmt.Errorf("resume session %d: %w", sessionID, err)
+	}
+	now := calendar.Current().Now()
+	timeout := store.GetSessionIdleTimeout()
+	for _, o := range open {
+		if o.ID == sessionID && session.IsIdle(o.LastActivity, now, timeout) {
+			if err := store.AbandonSession(sessionID, o.LastActivity); err != nil {
+				return 0, err
+			}
+			return 0, &session.SessionClosedError{SessionID: sessionID, Status: session.StatusAbandoned}
+		}
+	}
+
+	// Minuteur : reprise après interruption ou échéance atteinte
+	if err := s.trackFocus(sessionID); err != nil {
+		return 0, err
+	}
+	expired, err := s.ExpireIfDue(sessionID)
+	if err != nil || expired {
+		return 0, err
+	}
+
+	exerciseID, err := store.GetNextSessionExercise(sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("resume session %d: %w", sessionID, err)
+	}
+	if exerciseID == 0 {
+		return 0, s.EndSession(sessionID) // Tout était fait : fin normale
+	}
+	return exerciseID, nil
+}
+
+// CloseIdleSessions : Abandonne les sessions sans activité depuis le délai configuré
+func (s *SessionService) CloseIdleSessions() (int, error) {
+	open, err := store.GetOpenSessions()
+	if err != nil {
+		return 0, fmt.Errorf("close idle sessions: %w", err)
+	}
+
+	now := calendar.Current().Now()
+	timeout := store.GetSessionIdleTimeout()
+	closed := 0
+	for _, o := range open {
+		if !session.IsIdle(o.LastActivity, now, timeout) {
+			continue
+		}
+		if err := store.AbandonSession(o.ID, o.LastActivity); err != nil {
+			return closed, err
+		}
+		closed++
+	}
+	return closed, nil
+}
+
+// RunIdleSweep : Job périodique de fermeture des sessions inactives
+func (s *SessionService) RunIdleSweep(interval time.Duration) {
+	for {
+		closed, err := s.CloseIdleSessions()
+		if err != nil {
+			log.Printf("❌ [Session] %v", err)
+		} else if closed > 0 {
+			log.Printf("💤 %d session(s) inactive(s) abandonnée(s)", closed)
+		}
+		time.Sleep(interval)
 	}
-	return s.EndSession(sessionID)
 }
 
 // GetSessionResult : Récupère le résultat d'une session terminée
@@ -99,9 +407,28 @@ func (s *SessionService) GetSessionResult(sessionID int64) (*models.SessionResul
 	if err != nil {
 		return nil, fmt.Errorf("get session result %d: %w", sessionID, err)
 	}
+
+	// Entraînement : bilan rapporté à part
+	if result.Practice {
+		qualities := make([]int, len(result.Exercises))
+		for i, id := range result.Exercises {
+			qualities[i] = result.Qualities[id]
+		}
+		summary := session.SummarizePractice(qualities)
+		result.PracticeStats = &summary
+	}
 	return result, nil
 }
 
+// IsPractice : Session d'entraînement (révisions sans effet sur la planification)
+func (s *SessionService) IsPractice(sessionID int64) (bool, error) {
+	practice, err := store.IsPracticeSession(sessionID)
+	if err != nil {
+		return false, fmt.Errorf("practice flag of session %d: %w", sessionID, err)
+	}
+	return practice, nil
+}
+
 // GetNextExercise : Prochain exercice dans la session
 func (s *SessionService) GetNextExercise(sessionID int64) (*models.Exercise, error) {
 	exerciseID, err := store.GetNextSessionExercise(sessionID)
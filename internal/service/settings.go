@@ -0,0 +1,110 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/session"
+	"maestro/internal/domain/srs"
+	"maestro/internal/domain/streak"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+type SettingsService struct{}
+
+func NewSettingsService() *SettingsService {
+	return &SettingsService{}
+}
+
+// GetSettings : Réglages utilisateur courants
+func (s *SettingsService) GetSettings() models.UserSettings {
+	goal := store.GetDailyGoal()
+	risk := store.GetRiskPolicy()
+	newCards := store.GetNewCardPolicy()
+	return models.UserSettings{
+		Timezone:        store.GetSetting(store.SettingTimezone, "Local"),
+		DayRolloverHour: store.GetSettingInt(store.SettingDayRolloverHour, 0),
+		DailyGoalKind:   string(goal.Kind),
+		DailyGoalTarget: goal.Target,
+		ReportDir:       store.GetSetting(store.SettingReportDir, ""),
+		AtRiskRecall:    int(risk.Threshold*100 + 0.5),
+		AtRiskDays:      risk.HorizonDays,
+		SessionIdleMin:  int(store.GetSessionIdleTimeout() / time.Minute),
+		NewCardsPerDay:  newCards.DailyLimit,
+		NewCardRatio:    newCards.Ratio,
+		EnergyAdapter:   store.GetEnergyAdapter(),
+	}
+}
+
+// UpdateSettings : Valide, persiste puis applique les réglages
+func (s *SettingsService) UpdateSettings(settings models.UserSettings) error {
+	// 1. Validation (domain)
+	settings.Timezone = strings.TrimSpace(settings.Timezone)
+	loc, err := calendar.LoadLocation(settings.Timezone)
+	if err != nil {
+		return fmt.Errorf("timezone %q: %w", settings.Timezone, err)
+	}
+
+	cal, err := calendar.New(loc, settings.DayRolloverHour)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	goal := streak.Goal{Kind: streak.GoalKind(settings.DailyGoalKind), Target: settings.DailyGoalTarget}
+	if err := goal.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	risk := srs.RiskPolicy{Threshold: float64(settings.AtRiskRecall) / 100, HorizonDays: settings.AtRiskDays}
+	if err := risk.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	idle := time.Duration(settings.SessionIdleMin) * time.Minute
+	if err := session.ValidateIdleTimeout(idle); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	newCards := session.NewCardPolicy{DailyLimit: settings.NewCardsPerDay, Ratio: settings.NewCardRatio}
+	if err := newCards.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	// 2. Persistance
+	if settings.Timezone == "" {
+		settings.Timezone = "Local"
+	}
+	if err := store.SetSetting(store.SettingTimezone, settings.Timezone); err != nil {
+		return err
+	}
+	if err := store.SetSetting(store.SettingDayRolloverHour, strconv.Itoa(settings.DayRolloverHour)); err != nil {
+		return err
+	}
+	if err := store.SetDailyGoal(goal); err != nil {
+		return err
+	}
+	if err := store.SetRiskPolicy(risk); err != nil {
+		return err
+	}
+	if err := store.SetSetting(store.SettingReportDir, strings.TrimSpace(settings.ReportDir)); err != nil {
+		return err
+	}
+	if err := store.SetSessionIdleTimeout(idle); err != nil {
+		return err
+	}
+	if err := store.SetNewCardPolicy(newCards); err != nil {
+		return err
+	}
+	if err := store.SetEnergyAdapter(settings.EnergyAdapter); err != nil {
+		return err
+	}
+
+	// 3. Applique le nouveau jour utilisateur
+	calendar.Configure(cal)
+
+	return nil
+}
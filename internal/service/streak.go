@@ -0,0 +1,144 @@
+// internal/service/streak.go
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/streak"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+type StreakService struct{}
+
+func NewStreakService() *StreakService {
+	return &StreakService{}
+}
+
+// RecordActivity : Met à jour le streak persisté si l'objectif du jour est atteint
+// (appelé après chaque review et chaque fin de session)
+func (s *StreakService) RecordActivity() error {
+	cal := calendar.Current()
+	today := cal.Today()
+
+	activity, err := store.GetDayActivity(cal, today)
+	if err != nil {
+		return err
+	}
+	if !store.GetDailyGoal().Met(activity) {
+		return nil
+	}
+
+	state, err := store.GetStreakState()
+	if err != nil {
+		return err
+	}
+	if state.LastDay == today {
+		return nil // Déjà compté
+	}
+
+	frozen, err := loadFreezes(state.LastDay)
+	if err != nil {
+		return err
+	}
+
+	return store.SaveStreakState(streak.Record(cal, state, today, frozen))
+}
+
+// GetGoalProgress : Objectif du jour, streak courant et gels planifiés
+func (s *StreakService) GetGoalProgress() models.GoalProgress {
+	cal := calendar.Current()
+	today := cal.Today()
+	goal := store.GetDailyGoal()
+
+	progress := models.GoalProgress{
+		Kind:   string(goal.Kind),
+		Target: goal.Target,
+	}
+
+	activity, err := store.GetDayActivity(cal, today)
+	if err != nil {
+		log.Printf("❌ [Streak] %v", err)
+	}
+	progress.Done = goal.Progress(activity)
+	progress.Met = goal.Met(activity)
+	progress.Percent = min(100, progress.Done*100/goal.Target)
+
+	state, err := store.GetStreakState()
+	if err != nil {
+		log.Printf("❌ [Streak] %v", err)
+	}
+
+	frozen, err := loadFreezes(min(state.LastDay, today))
+	if err != nil {
+		log.Printf("❌ [Streak] %v", err)
+	}
+	progress.CurrentStreak = streak.Alive(cal, state, today, frozen)
+	progress.LongestStreak = state.Longest
+	progress.FrozenToday = frozen[today]
+
+	upcoming, err := store.GetStreakFreezes(today)
+	if err != nil {
+		log.Printf("❌ [Streak] %v", err)
+	}
+	for _, day := range upcoming {
+		progress.Freezes = append(progress.Freezes, cal.FromDayKey(day))
+	}
+
+	return progress
+}
+
+// AddFreeze : Planifie un jour de congé (le streak n'est pas cassé ce jour-là)
+func (s *StreakService) AddFreeze(day time.Time) error {
+	cal := calendar.Current()
+	today := cal.Today()
+	key := cal.DayKey(day)
+
+	planned, err := store.GetStreakFreezes(today)
+	if err != nil {
+		return err
+	}
+	for _, d := range planned {
+		if d == key {
+			return nil // Déjà planifié
+		}
+	}
+
+	if err := streak.ValidateFreeze(key, today, len(planned)); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	return store.AddStreakFreeze(key)
+}
+
+// RemoveFreeze : Annule un jour de congé
+func (s *StreakService) RemoveFreeze(day time.Time) error {
+	return store.DeleteStreakFreeze(calendar.Current().DayKey(day))
+}
+
+// ============================================
+// HELPER FUNCTIONS
+// ============================================
+
+// loadFreezes : Gels à partir de fromDay (pour combler les trous du streak)
+func loadFreezes(fromDay int) (streak.Freezes, error) {
+	days, err := store.GetStreakFreezes(fromDay)
+	if err != nil {
+		return nil, err
+	}
+
+	frozen := make(streak.Freezes, len(days))
+	for _, day := range days {
+		frozen[day] = true
+	}
+	return frozen, nil
+}
+
+// recordStreakActivity : RecordActivity non-bloquant
+func recordStreakActivity() {
+	if err := NewStreakService().RecordActivity(); err != nil {
+		fmt.Printf("⚠️ Streak update failed: %v\n", err)
+	}
+}
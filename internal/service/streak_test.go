@@ -0,0 +1,74 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"maestro/internal/domain/streak"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+func TestStreakGoalAndFreezes(t *testing.T) {
+	clk := newTestStore(t, time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC), time.UTC)
+
+	if err := store.SetDailyGoal(streak.Goal{Kind: streak.GoalReviews, Target: 2}); err != nil {
+		t.Fatalf("set goal: %v", err)
+	}
+
+	ex := models.Exercise{Title: "Select", Domain: "Go", Difficulty: 2, EaseFactor: 2.5}
+	if err := store.CreateExercise(&ex); err != nil {
+		t.Fatalf("create exercise: %v", err)
+	}
+
+	s := NewStreakService()
+	review := func(n int) {
+		for range n {
+			if _, err := NewExerciseService().ReviewExercise(ex.ID, 2, 0); err != nil {
+				t.Fatalf("review: %v", err)
+			}
+		}
+	}
+	nextDay := func() { clk.Advance(24 * time.Hour) }
+
+	// Lundi : 1 review < objectif
+	review(1)
+	if p := s.GetGoalProgress(); p.Done != 1 || p.Met || p.CurrentStreak != 0 || p.Percent != 50 {
+		t.Fatalf("lundi 1 review = %+v", p)
+	}
+	// Lundi : objectif atteint
+	review(1)
+	if p := s.GetGoalProgress(); !p.Met || p.CurrentStreak != 1 {
+		t.Fatalf("lundi 2 reviews = %+v", p)
+	}
+
+	// Mardi + mercredi gelés (planifiés lundi), jeudi objectif atteint
+	if err := s.AddFreeze(clk.Now().AddDate(0, 0, 1)); err != nil {
+		t.Fatalf("add freeze: %v", err)
+	}
+	if err := s.AddFreeze(clk.Now().AddDate(0, 0, 2)); err != nil {
+		t.Fatalf("add freeze: %v", err)
+	}
+	nextDay()
+	if p := s.GetGoalProgress(); !p.FrozenToday || p.CurrentStreak != 1 || len(p.Freezes) != 2 {
+		t.Fatalf("mardi gelé = %+v", p)
+	}
+	nextDay()
+	nextDay()
+	review(2)
+	if p := s.GetGoalProgress(); p.CurrentStreak != 2 || p.LongestStreak != 2 {
+		t.Fatalf("jeudi = %+v, want streak 2", p)
+	}
+
+	// Un gel ne se planifie pas dans le passé
+	if err := s.AddFreeze(clk.Now().AddDate(0, 0, -1)); err == nil {
+		t.Error("AddFreeze(hier) = nil, want erreur")
+	}
+
+	// Samedi : vendredi manqué sans gel → cassé, record conservé
+	nextDay()
+	nextDay()
+	if p := s.GetGoalProgress(); p.CurrentStreak != 0 || p.LongestStreak != 2 {
+		t.Fatalf("samedi = %+v, want streak 0, record 2", p)
+	}
+}
@@ -0,0 +1,86 @@
+// internal/service/template.go
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"maestro/internal/domain/session"
+	"maestro/internal/store"
+)
+
+// ============================================
+// TEMPLATES DE SESSION
+// ============================================
+
+type TemplateService struct{}
+
+func NewTemplateService() *TemplateService {
+	return &TemplateService{}
+}
+
+// ListTemplates : Templates et nombre de sessions par template (slug)
+func (s *TemplateService) ListTemplates() ([]session.Config, map[string]int, error) {
+	templates, err := store.ListTemplates()
+	if err != nil {
+		return nil, nil, fmt.Errorf("list templates: %w", err)
+	}
+	counts, err := store.CountSessionsByMode()
+	if err != nil {
+		return nil, nil, fmt.Errorf("count template sessions: %w", err)
+	}
+	return templates, counts, nil
+}
+
+// GetTemplate : Template par id (TemplateNotFoundError si absent)
+func (s *TemplateService) GetTemplate(id int64) (session.Config, error) {
+	return store.GetTemplateByID(id)
+}
+
+// CreateTemplate : Valide puis enregistre un template utilisateur
+func (s *TemplateService) CreateTemplate(c session.Config) (int64, error) {
+	c = normalizeTemplate(c)
+	if err := c.Validate(); err != nil {
+		return 0, err
+	}
+	return store.CreateTemplate(c)
+}
+
+// UpdateTemplate : Valide puis met à jour (slug et statut intégré conservés)
+func (s *TemplateService) UpdateTemplate(id int64, c session.Config) error {
+	existing, err := store.GetTemplateByID(id)
+	if err != nil {
+		return err
+	}
+	c.Mode = existing.Mode
+	c.Builtin = existing.Builtin
+
+	c = normalizeTemplate(c)
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	return store.UpdateTemplate(id, c)
+}
+
+// DeleteTemplate : Supprime un template utilisateur jamais utilisé
+func (s *TemplateService) DeleteTemplate(id int64) error {
+	return store.DeleteTemplate(id)
+}
+
+// normalizeTemplate : Espaces superflus, domaines dédoublonnés
+func normalizeTemplate(c session.Config) session.Config {
+	c.Mode = strings.ToLower(strings.TrimSpace(c.Mode))
+	c.Name = strings.TrimSpace(c.Name)
+	c.Description = strings.TrimSpace(c.Description)
+
+	var domains []string
+	seen := make(map[string]bool)
+	for _, d := range c.Domains {
+		if d = strings.TrimSpace(d); d != "" && !seen[d] {
+			seen[d] = true
+			domains = append(domains, d)
+		}
+	}
+	c.Domains = domains
+	return c
+}
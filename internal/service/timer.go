@@ -0,0 +1,66 @@
+// internal/service/timer.go
+package service
+
+import (
+	"fmt"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/session"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+// ============================================
+// MINUTEUR / TEMPS ACTIF (SessionService)
+// ============================================
+
+// GetTimer : Minuteur de la session avec temps actif et temps restant
+func (s *SessionService) GetTimer(sessionID int64) (*models.SessionTimer, error) {
+	timer, err := store.GetSessionTimer(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("timer of session %d: %w", sessionID, err)
+	}
+	if !timer.Closed {
+		timer.Active = session.ActiveTime(timer.Active, timer.BlockStartedAt, calendar.Current().Now())
+	}
+	if timer.Budget > 0 {
+		timer.Remaining = session.TimerRemaining(timer.Budget, timer.Active)
+	}
+	return timer, nil
+}
+
+// ExpireIfDue : Termine une session chronométrée dont le minuteur est écoulé
+// (les exercices restants ne sont pas touchés). true si la session vient
+// d'être terminée.
+func (s *SessionService) ExpireIfDue(sessionID int64) (bool, error) {
+	timer, err := s.GetTimer(sessionID)
+	if err != nil {
+		return false, err
+	}
+	if timer.Closed || !session.TimerExpired(timer.Budget, timer.Active) {
+		return false, nil
+	}
+	if err := s.EndSession(sessionID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// trackFocus : Après une interruption (hors pause), le bloc en cours s'arrête
+// à la dernière activité et un nouveau bloc commence
+func (s *SessionService) trackFocus(sessionID int64) error {
+	timer, err := store.GetSessionTimer(sessionID)
+	if err != nil {
+		return fmt.Errorf("track focus of session %d: %w", sessionID, err)
+	}
+	if timer.Closed || timer.BlockStartedAt.IsZero() {
+		return nil // Terminée ou en pause
+	}
+	if !session.IsInterrupted(timer.LastActivity, calendar.Current().Now()) {
+		return nil
+	}
+	if err := store.RestartFocusBlock(sessionID, timer.LastActivity); err != nil {
+		return fmt.Errorf("restart focus block of session %d: %w", sessionID, err)
+	}
+	return nil
+}
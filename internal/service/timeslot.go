@@ -0,0 +1,107 @@
+// internal/service/timeslot.go
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/timeslot"
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+// timeSlotWindowDays : Historique analysé (les habitudes horaires évoluent)
+const timeSlotWindowDays = 365
+
+type TimeSlotService struct{}
+
+func NewTimeSlotService() *TimeSlotService {
+	return &TimeSlotService{}
+}
+
+// Analyze : Calcule la performance par heure / jour, le meilleur créneau
+// et recalibre difficulty_success_rate, puis persiste le résultat
+func (s *TimeSlotService) Analyze() (models.TimePerformance, error) {
+	cal := calendar.Current()
+	since := cal.FromDayKey(cal.AddDays(cal.Today(), -timeSlotWindowDays)).Unix()
+
+	// 1. Agrégats bruts (store)
+	tallies, err := store.GetTimeTallies(cal, since)
+	if err != nil {
+		return models.TimePerformance{}, fmt.Errorf("analyze time slots: %w", err)
+	}
+
+	// 2. Règles métier (domain)
+	perf := models.TimePerformance{
+		Hours:           make([]models.SlotStat, 24),
+		Weekdays:        make([]models.SlotStat, 0, 7),
+		DifficultyRates: timeslot.CalibrateDifficulty(tallies.ByDifficulty),
+		AnalyzedAt:      cal.Now(),
+	}
+
+	var total timeslot.Tally
+	for h, t := range tallies.Hours {
+		perf.Hours[h] = slotStat(fmt.Sprintf("%02dh", h), t)
+		total = total.Add(t)
+	}
+	perf.OverallRate = total.SuccessRate()
+
+	for i := range 7 {
+		day := time.Weekday((i + 1) % 7) // Lundi d'abord
+		perf.Weekdays = append(perf.Weekdays, slotStat(day.String()[:3], tallies.Weekdays[day]))
+	}
+
+	if start, ok := timeslot.BestSlot(tallies.Hours); ok {
+		perf.BestSlot = timeslot.SlotLabel(start)
+		var w timeslot.Tally
+		for h := range timeslot.SlotHours {
+			w = w.Add(tallies.Hours[(start+h)%24])
+		}
+		perf.BestSlotRate = w.SuccessRate()
+	}
+	if day, ok := timeslot.BestWeekday(tallies.Weekdays); ok {
+		perf.BestWeekday = day.String()
+	}
+
+	// 3. Persistance
+	if err := store.SaveTimePerformance(perf); err != nil {
+		return perf, err
+	}
+	return perf, nil
+}
+
+// GetTimePerformance : Dernier résultat persisté
+func (s *TimeSlotService) GetTimePerformance() models.TimePerformance {
+	perf, err := store.GetTimePerformance()
+	if err != nil {
+		log.Printf("❌ [TimeSlots] %v", err)
+	}
+	return perf
+}
+
+// RunEvery : Job périodique (premier calcul immédiat)
+func (s *TimeSlotService) RunEvery(interval time.Duration) {
+	for {
+		start := time.Now()
+		perf, err := s.Analyze()
+		if err != nil {
+			log.Printf("❌ [TimeSlots] %v", err)
+		} else {
+			log.Printf("🕒 Analyse horaire: best=%q (%s) en %v",
+				perf.BestSlot, perf.BestWeekday, time.Since(start).Round(time.Millisecond))
+		}
+		time.Sleep(interval)
+	}
+}
+
+// slotStat : Tally → vue
+func slotStat(label string, t timeslot.Tally) models.SlotStat {
+	return models.SlotStat{
+		Label:          label,
+		Reviews:        t.Reviews,
+		SuccessRate:    t.SuccessRate(),
+		AvgResponseSec: t.AvgResponseSec(),
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"maestro/internal/models"
+	"maestro/internal/store"
+)
+
+func TestTimeSlotAnalyze(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("timezone indisponible: %v", err)
+	}
+	clk := newTestStore(t, time.Date(2026, 6, 1, 12, 0, 0, 0, paris), paris)
+
+	ex := models.Exercise{Title: "Mutex", Domain: "Go", Difficulty: 3, EaseFactor: 2.5}
+	if err := store.CreateExercise(&ex); err != nil {
+		t.Fatalf("create exercise: %v", err)
+	}
+
+	// 40 jours : 9h (heure de Paris) réussi, 21h raté une fois sur deux
+	for d := 1; d <= 40; d++ {
+		day := time.Date(2026, 6, 1-d, 0, 0, 0, 0, paris)
+		log := func(hour, quality int) {
+			clk.Set(day.Add(time.Duration(hour) * time.Hour))
+			if err := store.LogProgress(ex.ID, quality, &ex, 0); err != nil {
+				t.Fatalf("log progress: %v", err)
+			}
+		}
+		log(9, 3)
+		log(21, d%2*2)
+	}
+	clk.Set(time.Date(2026, 6, 1, 12, 0, 0, 0, paris))
+
+	s := NewTimeSlotService()
+	perf, err := s.Analyze()
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	if perf.Hours[9].Reviews != 40 || perf.Hours[9].SuccessRate != 1 {
+		t.Errorf("9h = %+v, want 40 reviews à 100%%", perf.Hours[9])
+	}
+	if perf.Hours[21].SuccessRate != 0.5 {
+		t.Errorf("21h = %.2f, want 0.50", perf.Hours[21].SuccessRate)
+	}
+	if perf.BestSlot != "07h-10h" {
+		t.Errorf("BestSlot = %q, want 07h-10h (premier créneau couvrant 9h)", perf.BestSlot)
+	}
+	if perf.OverallRate != 0.75 {
+		t.Errorf("OverallRate = %.2f, want 0.75", perf.OverallRate)
+	}
+
+	// Persisté : relu tel quel + difficulty_success_rate recalibré
+	stored := s.GetTimePerformance()
+	if stored.BestSlot != perf.BestSlot || len(stored.Hours) != 24 {
+		t.Errorf("relu = %q (%d heures), want %q (24)", stored.BestSlot, len(stored.Hours), perf.BestSlot)
+	}
+	rates := store.GetDifficultySuccessRates()
+	if rates[3] <= 0.70 || rates[1] != 0.95 {
+		t.Errorf("rates = %v, want D3 > 0.70 (observé 75%%), D1 = 0.95", rates)
+	}
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// ============================================
+// SUCCÈS (achievements)
+// ============================================
+
+// UnlockAchievement : Enregistre un succès, false s'il l'était déjà
+func UnlockAchievement(key string, at time.Time) (bool, error) {
+	res, err := db.Exec(`INSERT OR IGNORE INTO achievements (key, unlocked_at) VALUES (?, ?)`, key, at.Unix())
+	if err != nil {
+		return false, fmt.Errorf("unlock achievement %s: %w", key, err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return false, nil
+	}
+	notifyChange(TableAchievements)
+	return true, nil
+}
+
+// GetUnlockedAchievements : Clé → date de déblocage
+func GetUnlockedAchievements() (map[string]time.Time, error) {
+	rows, err := db.Query(`SELECT key, unlocked_at FROM achievements`)
+	if err != nil {
+		return nil, fmt.Errorf("query achievements: %w", err)
+	}
+	defer rows.Close()
+
+	unlocked := make(map[string]time.Time)
+	for rows.Next() {
+		var key string
+		var at int64
+		if err := rows.Scan(&key, &at); err != nil {
+			return nil, fmt.Errorf("scan achievement: %w", err)
+		}
+		unlocked[key] = fromUnix(at)
+	}
+	return unlocked, rows.Err()
+}
+
+// CountReviews : Nombre total de révisions (progress_log)
+func CountReviews() (int, error) {
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM progress_log`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count reviews: %w", err)
+	}
+	return n, nil
+}
+
+// CountExercises : Nombre d'exercices actifs
+func CountExercises() (int, error) {
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM exercises WHERE deleted = 0`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count exercises: %w", err)
+	}
+	return n, nil
+}
+
+// GetSessionOutcome : Mode, exercices prévus, complétés et réussis (quality >= minQuality)
+func GetSessionOutcome(sessionID int64, minQuality int) (mode string, planned, completed, good int, err error) {
+	err = db.QueryRow(`SELECT COALESCE(s.mode, ''), COUNT(se.exercise_id),
+            COALESCE(SUM(se.completed = 1), 0),
+            COALESCE(SUM(se.completed = 1 AND se.quality >= ?), 0)
+        FROM sessions s
+        LEFT JOIN session_exercises se ON se.session_id = s.id
+        WHERE s.id = ?
+        GROUP BY s.id`, minQuality, sessionID).Scan(&mode, &planned, &completed, &good)
+	if err != nil {
+		return "", 0, 0, 0, fmt.Errorf("query session outcome %d: %w", sessionID, err)
+	}
+	return mode, planned, completed, good, nil
+}
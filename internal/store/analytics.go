@@ -1,9 +1,5 @@
 package store
 
-import (
-	"time"
-)
-
 // GetAnalytics : Métriques globales
 func GetAnalytics() (map[string]any, error) {
 	query := `SELECT 
@@ -11,15 +7,19 @@ func GetAnalytics() (map[string]any, error) {
         current_streak,
         longest_streak,
         total_sessions,
-        total_exercises_done
+        total_exercises_done,
+        breaks_taken,
+        breaks_skipped
     FROM analytics WHERE id = 1`
 
 	var avgLength float64
 	var currentStreak, longestStreak, totalSessions, totalExercises int
+	var breaksTaken, breaksSkipped int
 
 	err := db.QueryRow(query).Scan(
 		&avgLength, &currentStreak, &longestStreak,
 		&totalSessions, &totalExercises,
+		&breaksTaken, &breaksSkipped,
 	)
 	if err != nil {
 		return nil, err
@@ -31,6 +31,8 @@ func GetAnalytics() (map[string]any, error) {
 		"longest_streak":     longestStreak,
 		"total_sessions":     totalSessions,
 		"total_exercises":    totalExercises,
+		"breaks_taken":       breaksTaken,
+		"breaks_skipped":     breaksSkipped,
 	}, nil
 }
 
@@ -42,6 +44,7 @@ func updateAnalytics(completedCount, durationMin int) error {
         updated_at = ?
     WHERE id = 1`
 
-	_, err := db.Exec(query, completedCount, time.Now().Unix(), time.Now().Unix())
+	now := nowUnix()
+	_, err := db.Exec(query, completedCount, now, now)
 	return err
 }
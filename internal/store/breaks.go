@@ -0,0 +1,129 @@
+// internal/store/breaks.go
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"maestro/internal/models"
+)
+
+// ============================================
+// PAUSES DE SESSION
+// ============================================
+
+// StartBreak : Ouvre la pause prévue après afterCount exercices et clôt le
+// bloc de concentration (idempotent : retourne la pause existante, même terminée)
+func StartBreak(sessionID int64, afterCount int, planned time.Duration) (*models.SessionBreak, error) {
+	now := nowUnix()
+	res, err := db.Exec(`INSERT OR IGNORE INTO session_breaks (session_id, after_count, planned_sec, started_at)
+        VALUES (?, ?, ?, ?)`, sessionID, afterCount, int64(planned/time.Second), now)
+	if err != nil {
+		return nil, fmt.Errorf("start break: %w", err)
+	}
+
+	// La pause interrompt le bloc de concentration
+	if n, _ := res.RowsAffected(); n > 0 {
+		if err := closeFocusBlock(db, sessionID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	brk, err := scanBreak(db.QueryRow(breakColumns+` WHERE session_id = ? AND after_count = ?`, sessionID, afterCount))
+	if err != nil {
+		return nil, fmt.Errorf("query break: %w", err)
+	}
+
+	notifyChange(TableSessions)
+	return brk, nil
+}
+
+// GetOpenBreak : Pause en cours de la session (nil si aucune)
+func GetOpenBreak(sessionID int64) (*models.SessionBreak, error) {
+	brk, err := scanBreak(db.QueryRow(breakColumns+` WHERE session_id = ? AND ended_at IS NULL
+        ORDER BY started_at DESC LIMIT 1`, sessionID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query open break: %w", err)
+	}
+	return brk, nil
+}
+
+// FinishBreak : Clôt une pause (skipped = sautée avant la fin), ouvre un
+// nouveau bloc de concentration et met à jour les compteurs analytics
+func FinishBreak(breakID int64, skipped bool) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := nowUnix()
+	res, err := tx.Exec(`UPDATE session_breaks SET ended_at = ?, skipped = ?
+        WHERE id = ? AND ended_at IS NULL`, now, skipped, breakID)
+	if err != nil {
+		return fmt.Errorf("finish break %d: %w", breakID, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil // Déjà terminée (double clic)
+	}
+
+	// Reprise : nouveau bloc de concentration
+	var sessionID int64
+	if err := tx.QueryRow("SELECT session_id FROM session_breaks WHERE id = ?", breakID).Scan(&sessionID); err != nil {
+		return fmt.Errorf("query break session: %w", err)
+	}
+	if err := openFocusBlock(tx, sessionID, now); err != nil {
+		return err
+	}
+
+	counter := "breaks_taken"
+	if skipped {
+		counter = "breaks_skipped"
+	}
+	if _, err := tx.Exec(`UPDATE analytics SET ` + counter + ` = ` + counter + ` + 1 WHERE id = 1`); err != nil {
+		return fmt.Errorf("count break: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit break: %w", err)
+	}
+
+	notifyChange(TableSessions)
+	return nil
+}
+
+// closeOpenBreaks : Fin de session pendant une pause (non comptée)
+func closeOpenBreaks(sessionID int64, at int64) error {
+	_, err := db.Exec(`UPDATE session_breaks SET ended_at = ? WHERE session_id = ? AND ended_at IS NULL`, at, sessionID)
+	if err != nil {
+		return fmt.Errorf("close open breaks: %w", err)
+	}
+	return nil
+}
+
+// ============================================
+// HELPERS
+// ============================================
+
+const breakColumns = `SELECT id, session_id, after_count, planned_sec, started_at, ended_at, skipped
+        FROM session_breaks`
+
+func scanBreak(row *sql.Row) (*models.SessionBreak, error) {
+	var b models.SessionBreak
+	var plannedSec, startedAt int64
+	var endedAt sql.NullInt64
+	if err := row.Scan(&b.ID, &b.SessionID, &b.AfterCount, &plannedSec, &startedAt, &endedAt, &b.Skipped); err != nil {
+		return nil, err
+	}
+	b.Planned = time.Duration(plannedSec) * time.Second
+	b.StartedAt = fromUnix(startedAt)
+	if endedAt.Valid {
+		t := fromUnix(endedAt.Int64)
+		b.EndedAt = &t
+	}
+	return &b, nil
+}
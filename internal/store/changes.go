@@ -0,0 +1,33 @@
+// internal/store/changes.go
+package store
+
+import (
+	"maestro/internal/domain/calendar"
+	"maestro/internal/events"
+)
+
+// ============================================
+// NOTIFICATION DES ÉCRITURES
+// ============================================
+
+// Tables notifiées ("" = base entière, ex : réouverture)
+const (
+	TableExercises    = "exercises"
+	TableProgressLog  = "progress_log"
+	TableSessions     = "sessions"
+	TableSettings     = "settings"
+	TableStreak       = "streak"
+	TableTimeSlots    = "time_performance"
+	TableAchievements = "achievements"
+	TableNewCards     = "daily_new_cards"
+	TableTemplates    = "session_templates"
+)
+
+// notifyChange : Publie DataChanged après une écriture réussie (invalidation des caches)
+func notifyChange(table string) {
+	events.Publish(events.Event{
+		Kind:  events.DataChanged,
+		At:    calendar.Current().Now(),
+		Table: table,
+	})
+}
@@ -2,14 +2,20 @@ package store
 
 import (
 	"database/sql"
+	_ "embed"
 	"fmt"
-	"os"
 
 	_ "modernc.org/sqlite"
 )
 
 var db *sql.DB
 
+// MemoryDSN : Base SQLite en mémoire (tests, démos)
+const MemoryDSN = ":memory:"
+
+//go:embed schema.sql
+var schemaSQL string
+
 // InitDB initialise la connexion SQLite
 func InitDB(dbPath string) error {
 	var err error
@@ -18,23 +24,39 @@ func InitDB(dbPath string) error {
 		return fmt.Errorf("open db: %w", err)
 	}
 
+	// En mémoire : chaque connexion aurait sa propre base → une seule connexion
+	if dbPath == MemoryDSN {
+		db.SetMaxOpenConns(1)
+	}
+
 	// Optimisations SQLite
 	db.Exec("PRAGMA journal_mode=WAL")
 	db.Exec("PRAGMA synchronous=NORMAL")
 	db.Exec("PRAGMA cache_size=-64000")
 	db.Exec("PRAGMA foreign_keys=ON")
 
-	// Charge et exécute le schema
-	schema, err := os.ReadFile("internal/store/schema.sql")
+	// Exécute le schema (embarqué dans le binaire)
+	_, err = db.Exec(schemaSQL)
 	if err != nil {
-		return fmt.Errorf("read schema: %w", err)
+		return fmt.Errorf("exec schema: %w", err)
 	}
 
-	_, err = db.Exec(string(schema))
-	if err != nil {
-		return fmt.Errorf("exec schema: %w", err)
+	// Jour utilisateur (fuseau + heure de bascule)
+	if err := LoadCalendar(); err != nil {
+		return fmt.Errorf("calendar: %w", err)
+	}
+
+	// Migrations des bases existantes
+	if err := runMigrations(); err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+
+	// Templates de session (registre du domaine)
+	if err := LoadTemplates(); err != nil {
+		return fmt.Errorf("templates: %w", err)
 	}
 
+	notifyChange("") // Nouvelle base : caches périmés
 	return nil
 }
 
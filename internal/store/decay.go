@@ -0,0 +1,70 @@
+package store
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"maestro/internal/domain/srs"
+	"maestro/internal/models"
+)
+
+// ============================================
+// DÉCROISSANCE (exercices à risque)
+// ============================================
+
+// GetRiskPolicy : Seuil et horizon "à risque" (défaut : 85%, 3 jours)
+func GetRiskPolicy() srs.RiskPolicy {
+	def := srs.DefaultRiskPolicy()
+	policy := srs.RiskPolicy{
+		Threshold:   float64(GetSettingInt(SettingAtRiskRecall, int(math.Round(def.Threshold*100)))) / 100,
+		HorizonDays: GetSettingInt(SettingAtRiskDays, def.HorizonDays),
+	}
+	if policy.Validate() != nil {
+		return def
+	}
+	return policy
+}
+
+// SetRiskPolicy : Persiste le seuil (en %) et l'horizon
+func SetRiskPolicy(policy srs.RiskPolicy) error {
+	if err := SetSetting(SettingAtRiskRecall, strconv.Itoa(int(math.Round(policy.Threshold*100)))); err != nil {
+		return err
+	}
+	return SetSetting(SettingAtRiskDays, strconv.Itoa(policy.HorizonDays))
+}
+
+// GetDecayCandidates : Exercices matures dont le rappel prédit franchit le
+// seuil entre now et now + horizon (présélection SQL, décision finale en Go)
+func GetDecayCandidates(policy srs.RiskPolicy, now int64) ([]models.Exercise, error) {
+	ratio := srs.RecallElapsedRatio(policy.Threshold)
+	horizon := int64(policy.HorizonDays) * 86400
+
+	rows, err := db.Query(`SELECT id, title, domain, difficulty, last_reviewed_date, interval_days, next_review_date
+        FROM exercises
+        WHERE deleted = 0
+          AND last_reviewed_date IS NOT NULL
+          AND interval_days >= ?
+          AND last_reviewed_date + ? * interval_days * 86400 BETWEEN ? AND ?`,
+		srs.MatureInterval, ratio, now, now+horizon)
+	if err != nil {
+		return nil, fmt.Errorf("query decay candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var exercises []models.Exercise
+	for rows.Next() {
+		var ex models.Exercise
+		var lastReviewed int64
+		var nextReviewDate int
+		if err := rows.Scan(&ex.ID, &ex.Title, &ex.Domain, &ex.Difficulty,
+			&lastReviewed, &ex.IntervalDays, &nextReviewDate); err != nil {
+			return nil, fmt.Errorf("scan decay candidate: %w", err)
+		}
+		t := fromUnix(lastReviewed)
+		ex.LastReviewed = &t
+		ex.NextReviewAt = fromDateInt(nextReviewDate)
+		exercises = append(exercises, ex)
+	}
+	return exercises, rows.Err()
+}
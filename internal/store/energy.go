@@ -0,0 +1,161 @@
+// internal/store/energy.go
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"maestro/internal/domain/session"
+	"maestro/internal/models"
+)
+
+// ============================================
+// RECOMMANDATION / ADAPTATION D'ÉNERGIE
+// ============================================
+
+// GetEnergyAdapter : Adaptation en cours de session activée (défaut : oui)
+func GetEnergyAdapter() bool {
+	return GetSetting(SettingEnergyAdapter, "true") == "true"
+}
+
+// SetEnergyAdapter : Persiste le réglage
+func SetEnergyAdapter(enabled bool) error {
+	value := "false"
+	if enabled {
+		value = "true"
+	}
+	return SetSetting(SettingEnergyAdapter, value)
+}
+
+// GetRecentSessionSignals : Performance des dernières sessions terminées
+// (plus récentes d'abord, entraînements exclus)
+func GetRecentSessionSignals(limit int) ([]session.SessionSignal, error) {
+	rows, err := db.Query(`
+        SELECT s.id,
+               (SELECT COUNT(*) FROM session_exercises se WHERE se.session_id = s.id AND se.completed = 1),
+               (SELECT COUNT(*) FROM session_exercises se WHERE se.session_id = s.id),
+               COALESCE((SELECT AVG(se.duration_sec) FROM session_exercises se
+                         WHERE se.session_id = s.id AND se.duration_sec IS NOT NULL), 0)
+        FROM sessions s
+        WHERE s.ended_at IS NOT NULL AND s.practice = 0
+        ORDER BY s.started_at DESC, s.id DESC
+        LIMIT ?
+    `, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query recent sessions: %w", err)
+	}
+
+	var ids []int64
+	var signals []session.SessionSignal
+	for rows.Next() {
+		var id int64
+		var s session.SessionSignal
+		var avgSec float64
+		if err := rows.Scan(&id, &s.Completed, &s.Total, &avgSec); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan recent session: %w", err)
+		}
+		s.AvgAnswer = time.Duration(avgSec * float64(time.Second))
+		ids = append(ids, id)
+		signals = append(signals, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Qualités (requêtes après fermeture du curseur)
+	for i, id := range ids {
+		if signals[i].Qualities, err = getSessionQualities(id, 0); err != nil {
+			return nil, err
+		}
+	}
+	return signals, nil
+}
+
+// getSessionQualities : Qualités des exercices complétés dans l'ordre des
+// réponses, à partir de la (skip+1)-ième
+func getSessionQualities(sessionID int64, skip int) ([]int, error) {
+	rows, err := db.Query(`
+        SELECT COALESCE(quality, 0) FROM session_exercises
+        WHERE session_id = ? AND completed = 1
+        ORDER BY reviewed_at ASC, position ASC
+        LIMIT -1 OFFSET ?
+    `, sessionID, skip)
+	if err != nil {
+		return nil, fmt.Errorf("query session qualities: %w", err)
+	}
+	defer rows.Close()
+
+	var qualities []int
+	for rows.Next() {
+		var q int
+		if err := rows.Scan(&q); err != nil {
+			return nil, fmt.Errorf("scan session quality: %w", err)
+		}
+		qualities = append(qualities, q)
+	}
+	return qualities, rows.Err()
+}
+
+// GetSessionAdaptState : Énergie courante, template, qualités depuis la
+// dernière adaptation et exercices restants d'une session
+func GetSessionAdaptState(sessionID int64) (models.EnergyLevel, string, []int, int, error) {
+	var energy, mode string
+	var adaptedAfter, remaining int
+	err := db.QueryRow(`SELECT COALESCE(s.energy_level, ''), COALESCE(s.mode, ''), s.adapted_after,
+            (SELECT COUNT(*) FROM session_exercises se WHERE se.session_id = s.id AND se.completed = 0)
+        FROM sessions s WHERE s.id = ?`, sessionID).Scan(&energy, &mode, &adaptedAfter, &remaining)
+	if err == sql.ErrNoRows {
+		return 0, "", nil, 0, &session.SessionNotFoundError{SessionID: sessionID}
+	}
+	if err != nil {
+		return 0, "", nil, 0, fmt.Errorf("query session adapt state: %w", err)
+	}
+
+	qualities, err := getSessionQualities(sessionID, adaptedAfter)
+	if err != nil {
+		return 0, "", nil, 0, err
+	}
+	return stringToEnergy(energy), mode, qualities, remaining, nil
+}
+
+// DownshiftSession : Passe la session au niveau to (le template, donc mode
+// et pauses, reste celui du départ ; adapted_from garde l'énergie initiale)
+// et ne garde que les keep prochains exercices ; les autres retournent dans
+// la file sans être touchés. Retourne le nombre d'exercices retirés.
+func DownshiftSession(sessionID int64, to models.EnergyLevel, keep int) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`UPDATE sessions SET
+        adapted_from = COALESCE(adapted_from, energy_level),
+        energy_level = ?,
+        adapted_after = (SELECT COUNT(*) FROM session_exercises WHERE session_id = ? AND completed = 1)
+    WHERE id = ? AND ended_at IS NULL`,
+		energyToString(to), sessionID, sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("downshift session %d: %w", sessionID, err)
+	}
+
+	res, err := tx.Exec(`DELETE FROM session_exercises
+        WHERE session_id = ? AND completed = 0 AND exercise_id NOT IN (
+            SELECT exercise_id FROM session_exercises
+            WHERE session_id = ? AND completed = 0
+            ORDER BY position ASC LIMIT ?)`, sessionID, sessionID, keep)
+	if err != nil {
+		return 0, fmt.Errorf("shorten session %d: %w", sessionID, err)
+	}
+	dropped, _ := res.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit downshift: %w", err)
+	}
+
+	notifyChange(TableSessions)
+	return int(dropped), nil
+}
@@ -4,27 +4,33 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
+	"maestro/internal/domain/srs"
 	"maestro/internal/models"
 )
 
 func GetFiltered(filter models.ExerciseFilter) ([]models.Exercise, error) {
 	query := `SELECT id, title, domain, difficulty, done, 
-                     next_review_date, completed_steps, steps
+                     next_review_date, completed_steps, steps,
+                     last_reviewed_date, interval_days, tags
               FROM exercises WHERE deleted = 0`
 
 	args := []interface{}{}
 
 	// ✅ FILTRES CONTENU (pas de temps)
 
-	// 1. Statut (done)
-	if filter.Status != "" {
-		switch filter.Status {
-		case "in_progress":
-			query += " AND done = 0"
-		case "mastered":
-			query += " AND done = 1"
+	// 1. Statut (done) ou état de maîtrise (new, learning, young, mature, mastered)
+	switch filter.Status {
+	case "":
+	case "in_progress":
+		query += " AND done = 0"
+	default:
+		if slices.Contains(srs.MasteryStates, srs.MasteryState(filter.Status)) {
+			now := nowUnix()
+			query += " AND " + masteryCase + " = ?"
+			args = append(args, now, now, filter.Status)
 		}
 	}
 
@@ -48,7 +54,7 @@ func GetFiltered(filter models.ExerciseFilter) ([]models.Exercise, error) {
 func FindExercise(id int) (*models.Exercise, error) {
 	query := `SELECT 
         id, title, description, domain, difficulty,
-        content, mnemonic, conceptual_visuals,
+        content, mnemonic, conceptual_visuals, tags,
         steps, completed_steps,
         done, last_reviewed_date, next_review_date,
         ease_factor, interval_days, repetitions,
@@ -58,12 +64,12 @@ func FindExercise(id int) (*models.Exercise, error) {
     WHERE id = ? AND deleted = 0`
 
 	var ex models.Exercise
-	var stepsJSON, completedJSON, visualsJSON string
+	var stepsJSON, completedJSON, visualsJSON, tagsJSON string
 	var lastReviewed, lastSkipped, nextReview, createdAt, updatedAt sql.NullInt64
 
 	err := db.QueryRow(query, id).Scan(
 		&ex.ID, &ex.Title, &ex.Description, &ex.Domain, &ex.Difficulty,
-		&ex.Content, &ex.Mnemonic, &visualsJSON,
+		&ex.Content, &ex.Mnemonic, &visualsJSON, &tagsJSON,
 		&stepsJSON, &completedJSON,
 		&ex.Done, &lastReviewed, &nextReview,
 		&ex.EaseFactor, &ex.IntervalDays, &ex.Repetitions,
@@ -79,7 +85,7 @@ func FindExercise(id int) (*models.Exercise, error) {
 	}
 
 	// Parse JSON + timestamps
-	parseExerciseFields(&ex, stepsJSON, completedJSON, visualsJSON,
+	parseExerciseFields(&ex, stepsJSON, completedJSON, visualsJSON, tagsJSON,
 		lastReviewed, lastSkipped, nextReview, createdAt, updatedAt)
 
 	return &ex, nil
@@ -90,11 +96,9 @@ func SaveExercise(ex *models.Exercise) error {
 	stepsJSON, _ := json.Marshal(ex.Steps)
 	completedJSON, _ := json.Marshal(ex.CompletedSteps)
 	visualsJSON, _ := json.Marshal(ex.ConceptualVisuals)
+	tagsJSON := marshalTags(ex.Tags)
 
-	var lastReviewedDate sql.NullInt64
-	if ex.LastReviewed != nil && !ex.LastReviewed.IsZero() {
-		lastReviewedDate = sql.NullInt64{Int64: int64(toDateInt(*ex.LastReviewed)), Valid: true}
-	}
+	lastReviewedAt := toNullUnix(ex.LastReviewed)
 
 	var lastSkippedDate sql.NullInt64
 	if ex.LastSkipped != nil && !ex.LastSkipped.IsZero() {
@@ -102,11 +106,11 @@ func SaveExercise(ex *models.Exercise) error {
 	}
 
 	nextReviewDate := toDateInt(ex.NextReviewAt)
-	updatedAt := todayInt()
+	updatedAt := nowUnix()
 
 	query := `UPDATE exercises SET
         title = ?, description = ?, content = ?,
-        mnemonic = ?, conceptual_visuals = ?,
+        mnemonic = ?, conceptual_visuals = ?, tags = ?,
         steps = ?, completed_steps = ?,
         done = ?, last_reviewed_date = ?, next_review_date = ?,
         ease_factor = ?, interval_days = ?, repetitions = ?,
@@ -116,16 +120,20 @@ func SaveExercise(ex *models.Exercise) error {
 
 	_, err := db.Exec(query,
 		ex.Title, ex.Description, ex.Content,
-		ex.Mnemonic, visualsJSON,
+		ex.Mnemonic, visualsJSON, tagsJSON,
 		stepsJSON, completedJSON,
-		ex.Done, lastReviewedDate, nextReviewDate,
+		ex.Done, lastReviewedAt, nextReviewDate,
 		ex.EaseFactor, ex.IntervalDays, ex.Repetitions,
 		ex.SkippedCount, lastSkippedDate,
 		updatedAt,
 		ex.ID,
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	notifyChange(TableExercises)
+	return nil
 }
 
 // GetAll : Tous les exercices
@@ -147,29 +155,31 @@ func CreateExercise(ex *models.Exercise) error {
 		data, _ := json.Marshal(ex.ConceptualVisuals)
 		visualsJSON = string(data)
 	}
+	tagsJSON := marshalTags(ex.Tags)
 
-	// 2. Dates
-	now := todayInt() // YYYYMMDD
+	// 2. Dates (clé de jour pour la révision, timestamp Unix pour le cycle de vie)
+	today := todayInt()
+	now := nowUnix()
 
 	// 3. INSERT avec RETURNING id (SQLite 3.35+)
 	query := `
         INSERT INTO exercises (
             title, description, domain, difficulty,
-            content, mnemonic, conceptual_visuals,
+            content, mnemonic, conceptual_visuals, tags,
             steps, completed_steps,
             done, next_review_date,
             ease_factor, interval_days, repetitions,
             deleted, created_at, updated_at
-        ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+        ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
         RETURNING id
     `
 
 	err = db.QueryRow(query,
 		ex.Title, ex.Description, ex.Domain, ex.Difficulty,
-		ex.Content, ex.Mnemonic, visualsJSON,
+		ex.Content, ex.Mnemonic, visualsJSON, tagsJSON,
 		stepsJSON, "[]", // completed_steps vide
 		0,         // done = false
-		now,       // next_review_date = aujourd'hui
+		today,     // next_review_date = aujourd'hui
 		2.5, 0, 0, // ease_factor, interval, repetitions (défauts SRS)
 		0, now, now, // deleted, created_at, updated_at
 	).Scan(&ex.ID)
@@ -181,6 +191,7 @@ func CreateExercise(ex *models.Exercise) error {
 		return fmt.Errorf("insert exercise: %w", err)
 	}
 
+	notifyChange(TableExercises)
 	return nil
 }
 
@@ -189,6 +200,7 @@ func UpdateExercise(ex *models.Exercise) error {
 	// 1. Serialize JSON
 	stepsJSON, _ := json.Marshal(ex.Steps)
 	visualsJSON, _ := json.Marshal(ex.ConceptualVisuals)
+	tagsJSON := marshalTags(ex.Tags)
 
 	// 2. UPDATE (trigger met à jour updated_at automatiquement)
 	query := `
@@ -200,13 +212,14 @@ func UpdateExercise(ex *models.Exercise) error {
             content = ?,
             mnemonic = ?,
             conceptual_visuals = ?,
+            tags = ?,
             steps = ?
         WHERE id = ? AND deleted = 0
     `
 
 	result, err := db.Exec(query,
 		ex.Title, ex.Description, ex.Domain, ex.Difficulty,
-		ex.Content, ex.Mnemonic, visualsJSON,
+		ex.Content, ex.Mnemonic, visualsJSON, tagsJSON,
 		stepsJSON,
 		ex.ID,
 	)
@@ -220,6 +233,7 @@ func UpdateExercise(ex *models.Exercise) error {
 		return fmt.Errorf("exercice %d introuvable ou supprimé", ex.ID)
 	}
 
+	notifyChange(TableExercises)
 	return nil
 }
 
@@ -262,18 +276,22 @@ func GetNextDueExercise(fromSession bool, sessionExercises []int) (*models.Exerc
 }
 
 // LogProgress : Enregistre révision dans l'historique
-func LogProgress(exerciseID int, quality int, ex *models.Exercise) error {
+func LogProgress(exerciseID int, quality int, ex *models.Exercise, duration time.Duration) error {
 	query := `INSERT INTO progress_log (
         exercise_id, reviewed_at, quality,
-        ease_factor, interval_days, repetitions
-    ) VALUES (?, ?, ?, ?, ?, ?)`
+        ease_factor, interval_days, repetitions, duration_sec
+    ) VALUES (?, ?, ?, ?, ?, ?, ?)`
 
 	_, err := db.Exec(query,
-		exerciseID, time.Now().Unix(), quality,
-		ex.EaseFactor, ex.IntervalDays, ex.Repetitions,
+		exerciseID, nowUnix(), quality,
+		ex.EaseFactor, ex.IntervalDays, ex.Repetitions, toNullSeconds(duration),
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	notifyChange(TableProgressLog)
+	return nil
 }
 
 // GetProgressHistory : Historique révisions
@@ -309,18 +327,19 @@ func GetProgressHistory(exerciseID int, limit int) ([]map[string]interface{}, er
 	return history, nil
 }
 
-// DeleteExercise : soft delete (marque deleted = 1, deleted_at = today)
+// DeleteExercise : soft delete (marque deleted = 1, deleted_at = now)
 func DeleteExercise(id int) error {
-	today := todayInt()
+	now := nowUnix()
 
 	query := `
         UPDATE exercises
         SET deleted = 1,
+            deleted_at = ?,
             updated_at = ?
         WHERE id = ? AND deleted = 0
     `
 
-	result, err := db.Exec(query, today, id)
+	result, err := db.Exec(query, now, now, id)
 	if err != nil {
 		return fmt.Errorf("delete exercise: %w", err)
 	}
@@ -330,21 +349,23 @@ func DeleteExercise(id int) error {
 		return fmt.Errorf("exercise %d not found or already deleted", id)
 	}
 
+	notifyChange(TableExercises)
 	return nil
 }
 
 // RestoreExercise : restaure un exercice soft-deleted (optionnel)
 func RestoreExercise(id int) error {
-	today := todayInt()
+	now := nowUnix()
 
 	query := `
         UPDATE exercises
         SET deleted = 0,
+            deleted_at = NULL,
             updated_at = ?
         WHERE id = ? AND deleted = 1
     `
 
-	result, err := db.Exec(query, today, id)
+	result, err := db.Exec(query, now, id)
 	if err != nil {
 		return fmt.Errorf("restore exercise: %w", err)
 	}
@@ -354,6 +375,7 @@ func RestoreExercise(id int) error {
 		return fmt.Errorf("exercise %d not found or not deleted", id)
 	}
 
+	notifyChange(TableExercises)
 	return nil
 }
 
@@ -371,5 +393,6 @@ func HardDeleteExercise(id int) error {
 		return fmt.Errorf("exercise %d not found", id)
 	}
 
+	notifyChange(TableExercises)
 	return nil
 }
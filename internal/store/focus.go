@@ -0,0 +1,95 @@
+// internal/store/focus.go
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"maestro/internal/domain/session"
+	"maestro/internal/models"
+)
+
+// ============================================
+// BLOCS DE CONCENTRATION / MINUTEUR
+// ============================================
+
+// execer : *sql.DB ou *sql.Tx
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// closeFocusBlock : Ajoute le bloc en cours au temps actif (sans effet si
+// aucun bloc n'est ouvert)
+func closeFocusBlock(ex execer, sessionID int64, at int64) error {
+	_, err := ex.Exec(`UPDATE sessions SET
+        active_sec = active_sec + MAX(? - block_started_at, 0),
+        block_started_at = NULL
+    WHERE id = ? AND block_started_at IS NOT NULL`, at, sessionID)
+	if err != nil {
+		return fmt.Errorf("close focus block: %w", err)
+	}
+	return nil
+}
+
+// openFocusBlock : Démarre un nouveau bloc (sans effet si un bloc est déjà
+// ouvert ou la session terminée)
+func openFocusBlock(ex execer, sessionID int64, at int64) error {
+	_, err := ex.Exec(`UPDATE sessions SET
+        block_started_at = ?,
+        focus_blocks = focus_blocks + 1
+    WHERE id = ? AND block_started_at IS NULL AND ended_at IS NULL`, at, sessionID)
+	if err != nil {
+		return fmt.Errorf("open focus block: %w", err)
+	}
+	return nil
+}
+
+// RestartFocusBlock : Interruption hors pause : le bloc en cours s'arrête à
+// la dernière activité, un nouveau commence maintenant
+func RestartFocusBlock(sessionID int64, lastActivity time.Time) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := closeFocusBlock(tx, sessionID, lastActivity.Unix()); err != nil {
+		return err
+	}
+	if err := openFocusBlock(tx, sessionID, nowUnix()); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit focus block: %w", err)
+	}
+
+	notifyChange(TableSessions)
+	return nil
+}
+
+// GetSessionTimer : Minuteur et temps actif clos d'une session (Active =
+// blocs clos, le bloc en cours est ajouté par le service)
+func GetSessionTimer(sessionID int64) (*models.SessionTimer, error) {
+	var t models.SessionTimer
+	var budgetSec, activeSec int64
+	var blockStartedAt, endedAt sql.NullInt64
+	var lastActivity int64
+	err := db.QueryRow(`SELECT s.id, s.time_budget_sec, s.active_sec, s.block_started_at,
+            s.focus_blocks, s.ended_at, `+lastActivitySQL+`
+        FROM sessions s WHERE s.id = ?`, sessionID).Scan(
+		&t.SessionID, &budgetSec, &activeSec, &blockStartedAt, &t.Blocks, &endedAt, &lastActivity)
+	if err == sql.ErrNoRows {
+		return nil, &session.SessionNotFoundError{SessionID: sessionID}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query session timer: %w", err)
+	}
+
+	t.Budget = time.Duration(budgetSec) * time.Second
+	t.Active = time.Duration(activeSec) * time.Second
+	t.BlockStartedAt = fromUnix(blockStartedAt.Int64)
+	t.LastActivity = fromUnix(lastActivity)
+	t.Closed = endedAt.Valid
+	return &t, nil
+}
@@ -5,44 +5,74 @@ import (
 	"encoding/json"
 	"time"
 
+	"maestro/internal/domain/calendar"
 	"maestro/internal/models"
 )
 
 // ============================================
-// DATE HELPERS (YYYYMMDD)
+// DATE HELPERS (YYYYMMDD, jour utilisateur)
 // ============================================
 
-// toDateInt : Convertit time.Time en YYYYMMDD
+// toDateInt : Convertit time.Time en clé de jour YYYYMMDD
 func toDateInt(t time.Time) int {
-	if t.IsZero() {
-		return 0
-	}
-	return t.Year()*10000 + int(t.Month())*100 + t.Day()
+	return calendar.Current().DayKey(t)
 }
 
-// fromDateInt : Convertit YYYYMMDD en time.Time
+// fromDateInt : Convertit une clé de jour YYYYMMDD en début de jour
 func fromDateInt(dateInt int) time.Time {
-	if dateInt == 0 {
-		return time.Time{}
-	}
-
-	year := dateInt / 10000
-	month := (dateInt % 10000) / 100
-	day := dateInt % 100
-
-	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	return calendar.Current().FromDayKey(dateInt)
 }
 
-// todayInt : Date d'aujourd'hui en YYYYMMDD
+// todayInt : Jour utilisateur courant en YYYYMMDD
 func todayInt() int {
-	return toDateInt(time.Now())
+	return calendar.Current().Today()
 }
 
 // addDays : Ajoute N jours à une date YYYYMMDD
 func addDays(dateInt int, days int) int {
-	t := fromDateInt(dateInt)
-	t = t.AddDate(0, 0, days)
-	return toDateInt(t)
+	return calendar.Current().AddDays(dateInt, days)
+}
+
+// ============================================
+// TIMESTAMP HELPERS (Unix secondes)
+// ============================================
+
+// nowUnix : Instant courant (horloge du calendrier) en Unix secondes
+func nowUnix() int64 {
+	return calendar.Current().Now().Unix()
+}
+
+// toNullUnix : Convertit *time.Time en timestamp Unix nullable
+func toNullUnix(t *time.Time) sql.NullInt64 {
+	if t == nil || t.IsZero() {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: t.Unix(), Valid: true}
+}
+
+// toNullSeconds : Durée en secondes nullable (0 = non mesurée)
+func toNullSeconds(d time.Duration) sql.NullInt64 {
+	if d <= 0 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(d / time.Second), Valid: true}
+}
+
+// fromUnix : Convertit un timestamp Unix en time.Time (0 → zéro)
+func fromUnix(ts int64) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	return time.Unix(ts, 0)
+}
+
+// marshalTags : Tags en JSON (jamais null : la colonne est NOT NULL)
+func marshalTags(tags []string) string {
+	if len(tags) == 0 {
+		return "[]"
+	}
+	data, _ := json.Marshal(tags)
+	return string(data)
 }
 
 // placeholders génère placeholders SQL
@@ -72,17 +102,24 @@ func queryExercisesLight(query string, args ...interface{}) ([]models.Exercise,
 	var exercises []models.Exercise
 	for rows.Next() {
 		var ex models.Exercise
-		var stepsJSON, completedJSON string
+		var stepsJSON, completedJSON, tagsJSON string
 		var nextReviewDate int
+		var lastReviewedAt sql.NullInt64
 
 		rows.Scan(
 			&ex.ID, &ex.Title, &ex.Domain, &ex.Difficulty,
 			&ex.Done, &nextReviewDate, &completedJSON, &stepsJSON,
+			&lastReviewedAt, &ex.IntervalDays, &tagsJSON,
 		)
 
 		json.Unmarshal([]byte(stepsJSON), &ex.Steps)
+		json.Unmarshal([]byte(tagsJSON), &ex.Tags)
 		json.Unmarshal([]byte(completedJSON), &ex.CompletedSteps)
 		ex.NextReviewAt = fromDateInt(nextReviewDate)
+		if lastReviewedAt.Valid && lastReviewedAt.Int64 > 0 {
+			t := fromUnix(lastReviewedAt.Int64)
+			ex.LastReviewed = &t
+		}
 
 		exercises = append(exercises, ex)
 	}
@@ -101,14 +138,14 @@ func queryExercisesFull(query string, args ...interface{}) ([]models.Exercise, e
 	var exercises []models.Exercise
 	for rows.Next() {
 		var ex models.Exercise
-		var stepsJSON, completedJSON, visualsJSON string
-		var lastReviewedDate, lastSkippedDate, nextReviewDate, createdAt, updatedAt sql.NullInt64
+		var stepsJSON, completedJSON, visualsJSON, tagsJSON string
+		var lastReviewedAt, lastSkippedDate, nextReviewDate, createdAt, updatedAt sql.NullInt64
 
 		err := rows.Scan(
 			&ex.ID, &ex.Title, &ex.Description, &ex.Domain, &ex.Difficulty,
-			&ex.Content, &ex.Mnemonic, &visualsJSON,
+			&ex.Content, &ex.Mnemonic, &visualsJSON, &tagsJSON,
 			&stepsJSON, &completedJSON,
-			&ex.Done, &lastReviewedDate, &nextReviewDate,
+			&ex.Done, &lastReviewedAt, &nextReviewDate,
 			&ex.EaseFactor, &ex.IntervalDays, &ex.Repetitions,
 			&ex.SkippedCount, &lastSkippedDate,
 			&ex.Deleted, &createdAt, &updatedAt,
@@ -117,8 +154,8 @@ func queryExercisesFull(query string, args ...interface{}) ([]models.Exercise, e
 			continue
 		}
 
-		parseExerciseFields(&ex, stepsJSON, completedJSON, visualsJSON,
-			lastReviewedDate, lastSkippedDate, nextReviewDate, createdAt, updatedAt)
+		parseExerciseFields(&ex, stepsJSON, completedJSON, visualsJSON, tagsJSON,
+			lastReviewedAt, lastSkippedDate, nextReviewDate, createdAt, updatedAt)
 
 		exercises = append(exercises, ex)
 	}
@@ -127,16 +164,19 @@ func queryExercisesFull(query string, args ...interface{}) ([]models.Exercise, e
 }
 
 // parseExerciseFields : Parse JSON + dates
+// last_reviewed_date, created_at, updated_at : timestamps Unix
+// next_review_date, last_skipped_date : clés de jour YYYYMMDD
 func parseExerciseFields(ex *models.Exercise,
-	stepsJSON, completedJSON, visualsJSON string,
-	lastReviewedDate, lastSkippedDate, nextReviewDate, createdAt, updatedAt sql.NullInt64,
+	stepsJSON, completedJSON, visualsJSON, tagsJSON string,
+	lastReviewedAt, lastSkippedDate, nextReviewDate, createdAt, updatedAt sql.NullInt64,
 ) {
 	json.Unmarshal([]byte(stepsJSON), &ex.Steps)
 	json.Unmarshal([]byte(completedJSON), &ex.CompletedSteps)
 	json.Unmarshal([]byte(visualsJSON), &ex.ConceptualVisuals)
+	json.Unmarshal([]byte(tagsJSON), &ex.Tags)
 
-	if lastReviewedDate.Valid && lastReviewedDate.Int64 > 0 {
-		t := fromDateInt(int(lastReviewedDate.Int64))
+	if lastReviewedAt.Valid && lastReviewedAt.Int64 > 0 {
+		t := fromUnix(lastReviewedAt.Int64)
 		ex.LastReviewed = &t
 	}
 	if lastSkippedDate.Valid && lastSkippedDate.Int64 > 0 {
@@ -147,10 +187,10 @@ func parseExerciseFields(ex *models.Exercise,
 		ex.NextReviewAt = fromDateInt(int(nextReviewDate.Int64))
 	}
 	if createdAt.Valid {
-		ex.CreatedAt = fromDateInt(int(createdAt.Int64))
+		ex.CreatedAt = fromUnix(createdAt.Int64)
 	}
 	if updatedAt.Valid {
-		ex.UpdatedAt = fromDateInt(int(updatedAt.Int64))
+		ex.UpdatedAt = fromUnix(updatedAt.Int64)
 	}
 }
 
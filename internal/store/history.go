@@ -0,0 +1,252 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"maestro/internal/models"
+)
+
+// ============================================
+// HISTORIQUE DES SESSIONS
+// ============================================
+
+// sessionSummarySQL : Colonnes d'une ligne d'historique (alias s = sessions)
+const sessionSummarySQL = `SELECT s.id, s.started_at, COALESCE(s.ended_at, 0),
+            COALESCE(s.energy_level, 'medium'), COALESCE(s.mode, ''), s.ordering, s.status,
+            COALESCE(s.duration_min, 0), s.time_budget_sec, s.focus_blocks, COALESCE(s.adapted_from, ''), s.practice,
+            (SELECT COUNT(*) FROM session_exercises se WHERE se.session_id = s.id AND se.completed = 1),
+            (SELECT COUNT(*) FROM session_exercises se WHERE se.session_id = s.id),
+            COALESCE((SELECT AVG(se.quality) FROM session_exercises se
+                      WHERE se.session_id = s.id AND se.completed = 1), 0)
+        FROM sessions s`
+
+// historyWhere : Clause WHERE des filtres mode / dates (bornes Unix, 0 = aucune)
+func historyWhere(mode string, from, to int64) (string, []any) {
+	var conds []string
+	var args []any
+	if mode != "" {
+		conds = append(conds, "s.mode = ?")
+		args = append(args, mode)
+	}
+	if from > 0 {
+		conds = append(conds, "s.started_at >= ?")
+		args = append(args, from)
+	}
+	if to > 0 {
+		conds = append(conds, "s.started_at < ?")
+		args = append(args, to)
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// GetSessionHistory : Sessions filtrées, plus récentes d'abord, et total filtré
+func GetSessionHistory(mode string, from, to int64, limit, offset int) ([]models.SessionSummary, int, error) {
+	where, args := historyWhere(mode, from, to)
+
+	var total int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sessions s"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count session history: %w", err)
+	}
+
+	rows, err := db.Query(sessionSummarySQL+where+`
+        ORDER BY s.started_at DESC, s.id DESC
+        LIMIT ? OFFSET ?`, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query session history: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []models.SessionSummary
+	for rows.Next() {
+		summary, err := scanSessionSummary(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		sessions = append(sessions, summary)
+	}
+	return sessions, total, rows.Err()
+}
+
+// GetSessionSummary : Ligne d'historique d'une session (nil si introuvable)
+func GetSessionSummary(sessionID int64) (*models.SessionSummary, error) {
+	summary, err := scanSessionSummary(db.QueryRow(sessionSummarySQL+" WHERE s.id = ?", sessionID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// scanSessionSummary : Lit une ligne de sessionSummarySQL
+func scanSessionSummary(row interface{ Scan(...any) error }) (models.SessionSummary, error) {
+	var s models.SessionSummary
+	var startedAt, endedAt int64
+	var energy string
+	var durationMin int
+	var budgetSec int64
+	var adaptedFrom string
+	err := row.Scan(&s.ID, &startedAt, &endedAt, &energy, &s.Mode, &s.Ordering, &s.Status,
+		&durationMin, &budgetSec, &s.FocusBlocks, &adaptedFrom, &s.Practice, &s.Completed, &s.Total, &s.AvgQuality)
+	if err == sql.ErrNoRows {
+		return s, err
+	}
+	if err != nil {
+		return s, fmt.Errorf("scan session summary: %w", err)
+	}
+
+	s.StartedAt = fromUnix(startedAt)
+	if endedAt > 0 {
+		ended := fromUnix(endedAt)
+		s.EndedAt = &ended
+	}
+	s.Energy = stringToEnergy(energy)
+	s.Duration = time.Duration(durationMin) * time.Minute
+	s.TimeBudget = time.Duration(budgetSec) * time.Second
+	if adaptedFrom != "" {
+		s.AdaptedFrom = stringToEnergy(adaptedFrom)
+	}
+	return s, nil
+}
+
+// GetSessionExercises : Exercices d'une session dans l'ordre de passage, avec
+// l'évolution SRS retrouvée dans progress_log (review la plus proche de
+// reviewed_at, précédée de la review antérieure du même exercice). Les
+// réponses d'entraînement n'ont pas d'évolution SRS.
+func GetSessionExercises(sessionID int64) ([]models.SessionExerciseDetail, error) {
+	rows, err := db.Query(`
+        SELECT se.exercise_id, COALESCE(e.title, ''), COALESCE(e.domain, ''), se.position,
+               se.completed, COALESCE(se.quality, 0), COALESCE(se.duration_sec, 0),
+               COALESCE(se.reviewed_at, 0), se.practice
+        FROM session_exercises se
+        LEFT JOIN exercises e ON e.id = se.exercise_id
+        WHERE se.session_id = ?
+        ORDER BY se.position ASC
+    `, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("query session exercises: %w", err)
+	}
+
+	var details []models.SessionExerciseDetail
+	var reviewedAt []int64
+	var practice []bool
+	for rows.Next() {
+		var d models.SessionExerciseDetail
+		var durationSec int
+		var at int64
+		var p bool
+		if err := rows.Scan(&d.ExerciseID, &d.Title, &d.Domain, &d.Position,
+			&d.Completed, &d.Quality, &durationSec, &at, &p); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan session exercise: %w", err)
+		}
+		d.Duration = time.Duration(durationSec) * time.Second
+		details = append(details, d)
+		reviewedAt = append(reviewedAt, at)
+		practice = append(practice, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Évolution SRS (requêtes après fermeture du curseur)
+	for i := range details {
+		if !details[i].Completed || reviewedAt[i] == 0 || practice[i] {
+			continue
+		}
+		if err := fillSRSChange(&details[i], reviewedAt[i]); err != nil {
+			return nil, err
+		}
+	}
+	return details, nil
+}
+
+// srsMatchWindow : Écart max entre la review SRS et la complétion en session
+const srsMatchWindow = 5 * 60
+
+// fillSRSChange : Intervalle / facilité avant et après la review de la session
+func fillSRSChange(d *models.SessionExerciseDetail, reviewedAt int64) error {
+	var logID int64
+	err := db.QueryRow(`
+        SELECT id, COALESCE(interval_days, 0), COALESCE(ease_factor, 0)
+        FROM progress_log
+        WHERE exercise_id = ? AND ABS(reviewed_at - ?) <= ?
+        ORDER BY ABS(reviewed_at - ?) ASC, id DESC
+        LIMIT 1
+    `, d.ExerciseID, reviewedAt, srsMatchWindow, reviewedAt).Scan(&logID, &d.IntervalAfter, &d.EaseAfter)
+	if err == sql.ErrNoRows {
+		return nil // Review hors session (ou historique purgé)
+	}
+	if err != nil {
+		return fmt.Errorf("query srs after: %w", err)
+	}
+	d.HasSRS = true
+
+	err = db.QueryRow(`
+        SELECT COALESCE(interval_days, 0), COALESCE(ease_factor, 0)
+        FROM progress_log
+        WHERE exercise_id = ? AND id < ?
+        ORDER BY id DESC
+        LIMIT 1
+    `, d.ExerciseID, logID).Scan(&d.IntervalBefore, &d.EaseBefore)
+	if err == sql.ErrNoRows {
+		d.FirstReview = true
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("query srs before: %w", err)
+	}
+	return nil
+}
+
+// GetSessionStartTimes : Débuts des sessions depuis from (Unix)
+func GetSessionStartTimes(from int64) ([]time.Time, error) {
+	rows, err := db.Query(`SELECT started_at FROM sessions WHERE started_at >= ? ORDER BY started_at`, from)
+	if err != nil {
+		return nil, fmt.Errorf("query session starts: %w", err)
+	}
+	defer rows.Close()
+
+	var starts []time.Time
+	for rows.Next() {
+		var ts int64
+		if err := rows.Scan(&ts); err != nil {
+			return nil, fmt.Errorf("scan session start: %w", err)
+		}
+		starts = append(starts, fromUnix(ts))
+	}
+	return starts, rows.Err()
+}
+
+// GetModeQuality : Sessions et qualité moyenne des reviews par mode
+func GetModeQuality() ([]models.ModeQuality, error) {
+	rows, err := db.Query(`
+        SELECT s.mode, COUNT(DISTINCT s.id), COUNT(se.quality), COALESCE(AVG(se.quality), 0)
+        FROM sessions s
+        LEFT JOIN session_exercises se ON se.session_id = s.id AND se.completed = 1
+        WHERE s.mode IS NOT NULL
+        GROUP BY s.mode
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("query mode quality: %w", err)
+	}
+	defer rows.Close()
+
+	var modes []models.ModeQuality
+	for rows.Next() {
+		var m models.ModeQuality
+		if err := rows.Scan(&m.Mode, &m.Sessions, &m.Reviews, &m.AvgQuality); err != nil {
+			return nil, fmt.Errorf("scan mode quality: %w", err)
+		}
+		modes = append(modes, m)
+	}
+	return modes, rows.Err()
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"fmt"
+
+	"maestro/internal/domain/srs"
+	"maestro/internal/models"
+)
+
+// ============================================
+// MAÎTRISE (états calculés en SQL)
+// ============================================
+
+// masteryCase : Expression SQL de srs.Mastery (mêmes seuils ; les seuils de
+// rappel deviennent des ratios écoulé / intervalle). Deux paramètres : now, now.
+var masteryCase = fmt.Sprintf(`CASE
+        WHEN last_reviewed_date IS NULL THEN '%s'
+        WHEN interval_days < %d
+          OR ? - last_reviewed_date > %f * MAX(interval_days, 1) * 86400 THEN '%s'
+        WHEN interval_days < %d THEN '%s'
+        WHEN interval_days < %d
+          OR ? - last_reviewed_date > %f * MAX(interval_days, 1) * 86400 THEN '%s'
+        ELSE '%s'
+    END`,
+	srs.StateNew,
+	srs.YoungInterval, srs.RecallElapsedRatio(srs.ForgottenRecall), srs.StateLearning,
+	srs.MatureInterval, srs.StateYoung,
+	srs.MasteredInterval, srs.RecallElapsedRatio(srs.TargetRecall), srs.StateMature,
+	srs.StateMastered,
+)
+
+// GetDomainTotals : Répartition par état de maîtrise et ease moyen, par domaine
+func GetDomainTotals() ([]models.DomainStrength, error) {
+	now := nowUnix()
+	rows, err := db.Query(`SELECT domain, `+masteryCase+` AS state, COUNT(*), COALESCE(SUM(ease_factor), 0)
+        FROM exercises WHERE deleted = 0
+        GROUP BY domain, state ORDER BY domain`, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("query domain totals: %w", err)
+	}
+	defer rows.Close()
+
+	var domains []models.DomainStrength
+	var easeSum float64
+	for rows.Next() {
+		var domain, state string
+		var count int
+		var ease float64
+		if err := rows.Scan(&domain, &state, &count, &ease); err != nil {
+			return nil, fmt.Errorf("scan domain totals: %w", err)
+		}
+
+		if len(domains) == 0 || domains[len(domains)-1].Name != domain {
+			easeSum = 0
+			domains = append(domains, models.DomainStrength{Name: domain})
+		}
+		ds := &domains[len(domains)-1]
+		ds.TotalCount += count
+		addMastery(&ds.Mastery, srs.MasteryState(state), count)
+		easeSum += ease
+		ds.AvgEaseFactor = easeSum / float64(ds.TotalCount)
+	}
+	return domains, rows.Err()
+}
+
+// addMastery : Ajoute n exercices à l'état donné
+func addMastery(c *models.MasteryCounts, state srs.MasteryState, n int) {
+	switch state {
+	case srs.StateNew:
+		c.New += n
+	case srs.StateLearning:
+		c.Learning += n
+	case srs.StateYoung:
+		c.Young += n
+	case srs.StateMature:
+		c.Mature += n
+	case srs.StateMastered:
+		c.Mastered += n
+	}
+}
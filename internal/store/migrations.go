@@ -0,0 +1,549 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/streak"
+)
+
+// ============================================
+// MIGRATIONS VERSIONNÉES (PRAGMA user_version)
+// ============================================
+
+// migration : Étape appliquée une seule fois, dans une transaction
+type migration struct {
+	version int
+	name    string
+	up      func(tx *sql.Tx) error
+}
+
+// migrations : Liste ordonnée (ne jamais réordonner, seulement ajouter)
+var migrations = []migration{
+	{1, "exercise timestamps YYYYMMDD → Unix", migrateExerciseTimestamps},
+	{2, "persistent streak (analytics.last_goal_day)", migratePersistentStreak},
+	{3, "time-of-day analytics (analytics.time_slot_stats)", migrateTimeSlotStats},
+	{4, "answer latency (shown_at, duration_sec)", migrateAnswerLatency},
+	{5, "session status (sessions.status)", migrateSessionStatus},
+	{6, "break counters (analytics.breaks_taken, breaks_skipped)", migrateBreakCounters},
+	{7, "session ordering (sessions.ordering)", migrateSessionOrdering},
+	{8, "focus timer (sessions.time_budget_sec, active_sec, focus_blocks)", migrateFocusTimer},
+	{9, "in-session energy adapter (sessions.adapted_from, adapted_after)", migrateEnergyAdapter},
+	{10, "session templates (sessions.mode → session_templates.slug)", migrateSessionTemplates},
+	{11, "practice sessions (sessions.practice, session_exercises.practice)", migratePracticeSessions},
+	{12, "unix created_at / updated_at (sessions, analytics, settings)", migrateUnixDefaults},
+	{13, "exercise tags (exercises.tags)", migrateExerciseTags},
+}
+
+// runMigrations : Applique les migrations manquantes. Les clés étrangères
+// sont désactivées sur une connexion dédiée (reconstruction de tables sans
+// cascade) et vérifiées avant chaque commit.
+func runMigrations() error {
+	var current int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&current); err != nil {
+		return fmt.Errorf("read user_version: %w", err)
+	}
+	if current >= migrations[len(migrations)-1].version {
+		return nil
+	}
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("migration connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys=OFF"); err != nil {
+		return fmt.Errorf("disable foreign keys: %w", err)
+	}
+	defer conn.ExecContext(ctx, "PRAGMA foreign_keys=ON")
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("begin migration %d: %w", m.version, err)
+		}
+
+		if err := m.up(tx); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+		}
+
+		if err := checkForeignKeys(tx); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+		}
+
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", m.version)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("set user_version %d: %w", m.version, err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("commit migration %d: %w", m.version, err)
+		}
+
+		log.Printf("🔧 Migration %d appliquée: %s", m.version, m.name)
+	}
+
+	return nil
+}
+
+// checkForeignKeys : Refuse une migration qui laisse des références orphelines
+func checkForeignKeys(tx *sql.Tx) error {
+	rows, err := tx.Query("PRAGMA foreign_key_check")
+	if err != nil {
+		return fmt.Errorf("foreign key check: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var table string
+		var rowid sql.NullInt64
+		var parent string
+		var fkid int
+		if err := rows.Scan(&table, &rowid, &parent, &fkid); err != nil {
+			return fmt.Errorf("scan foreign key check: %w", err)
+		}
+		return fmt.Errorf("foreign key violation: %s (rowid %d) → %s", table, rowid.Int64, parent)
+	}
+	return rows.Err()
+}
+
+// ============================================
+// 1 : TIMESTAMPS EXERCICES
+// ============================================
+
+// isDateInt : Vrai si la valeur ressemble à une clé YYYYMMDD (et pas à un Unix)
+func isDateInt(v int64) bool {
+	return v >= 19000101 && v <= 99991231
+}
+
+// migrateExerciseTimestamps : last_reviewed_date, created_at, updated_at
+// et deleted_at passent de YYYYMMDD à Unix (début du jour utilisateur).
+func migrateExerciseTimestamps(tx *sql.Tx) error {
+	// L'ancien trigger réécrivait updated_at en YYYYMMDD à chaque UPDATE
+	if _, err := tx.Exec("DROP TRIGGER IF EXISTS update_exercise_timestamp"); err != nil {
+		return fmt.Errorf("drop legacy trigger: %w", err)
+	}
+
+	rows, err := tx.Query(`SELECT id, last_reviewed_date, created_at, updated_at, deleted_at
+                           FROM exercises`)
+	if err != nil {
+		return fmt.Errorf("query exercises: %w", err)
+	}
+
+	type row struct {
+		id                                        int
+		lastReviewed, createdAt, updatedAt, delAt sql.NullInt64
+	}
+	var pending []row
+	for rows.Next() {
+		var r row
+		if err := rows.Scan(&r.id, &r.lastReviewed, &r.createdAt, &r.updatedAt, &r.delAt); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan exercise: %w", err)
+		}
+		pending = append(pending, r)
+	}
+	rows.Close()
+
+	convert := func(v sql.NullInt64) sql.NullInt64 {
+		if v.Valid && isDateInt(v.Int64) {
+			v.Int64 = fromDateInt(int(v.Int64)).Unix()
+		}
+		return v
+	}
+
+	for _, r := range pending {
+		_, err := tx.Exec(`UPDATE exercises SET
+            last_reviewed_date = ?, created_at = ?, updated_at = ?, deleted_at = ?
+        WHERE id = ?`,
+			convert(r.lastReviewed), convert(r.createdAt), convert(r.updatedAt), convert(r.delAt),
+			r.id,
+		)
+		if err != nil {
+			return fmt.Errorf("convert exercise %d: %w", r.id, err)
+		}
+	}
+
+	return nil
+}
+
+// ============================================
+// 2 : STREAK PERSISTÉ
+// ============================================
+
+// migratePersistentStreak : Ajoute last_goal_day puis reconstruit le streak
+// depuis progress_log avec l'objectif par défaut (1 review par jour).
+func migratePersistentStreak(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE analytics ADD COLUMN last_goal_day INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return fmt.Errorf("add last_goal_day: %w", err)
+	}
+
+	rows, err := tx.Query("SELECT reviewed_at FROM progress_log ORDER BY reviewed_at")
+	if err != nil {
+		return fmt.Errorf("query review history: %w", err)
+	}
+
+	cal := calendar.Current()
+	var state streak.State
+	for rows.Next() {
+		var reviewedAt int64
+		if err := rows.Scan(&reviewedAt); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan review: %w", err)
+		}
+		state = streak.Record(cal, state, cal.DayKey(fromUnix(reviewedAt)), nil)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate reviews: %w", err)
+	}
+
+	_, err = tx.Exec(`UPDATE analytics SET
+        current_streak = ?, longest_streak = MAX(COALESCE(longest_streak, 0), ?), last_goal_day = ?
+    WHERE id = 1`, state.Current, state.Longest, state.LastDay)
+	if err != nil {
+		return fmt.Errorf("save rebuilt streak: %w", err)
+	}
+	return nil
+}
+
+// ============================================
+// 3 : ANALYSE HORAIRE
+// ============================================
+
+// migrateTimeSlotStats : Détail JSON du dernier calcul (best_time_slot existe déjà)
+func migrateTimeSlotStats(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE analytics ADD COLUMN time_slot_stats TEXT"); err != nil {
+		return fmt.Errorf("add time_slot_stats: %w", err)
+	}
+	return nil
+}
+
+// ============================================
+// 4 : TEMPS PAR EXERCICE
+// ============================================
+
+// migrateAnswerLatency : Affichage en session + durée de réponse mesurée
+func migrateAnswerLatency(tx *sql.Tx) error {
+	steps := []string{
+		"ALTER TABLE session_exercises ADD COLUMN shown_at INTEGER",
+		"ALTER TABLE session_exercises ADD COLUMN duration_sec INTEGER",
+		"ALTER TABLE progress_log ADD COLUMN duration_sec INTEGER",
+		"CREATE INDEX IF NOT EXISTS idx_progress_duration ON progress_log(exercise_id, duration_sec)",
+	}
+	for _, step := range steps {
+		if _, err := tx.Exec(step); err != nil {
+			return fmt.Errorf("%s: %w", step, err)
+		}
+	}
+	return nil
+}
+
+// ============================================
+// 5 : STATUT DES SESSIONS
+// ============================================
+
+// migrateSessionStatus : active | completed | abandoned (sessions déjà
+// terminées → completed)
+func migrateSessionStatus(tx *sql.Tx) error {
+	steps := []string{
+		"ALTER TABLE sessions ADD COLUMN status TEXT NOT NULL DEFAULT 'active'",
+		"UPDATE sessions SET status = 'completed' WHERE ended_at IS NOT NULL",
+	}
+	for _, step := range steps {
+		if _, err := tx.Exec(step); err != nil {
+			return fmt.Errorf("%s: %w", step, err)
+		}
+	}
+	return nil
+}
+
+// ============================================
+// 6 : PAUSES
+// ============================================
+
+// migrateBreakCounters : Pauses prises jusqu'au bout / sautées
+func migrateBreakCounters(tx *sql.Tx) error {
+	steps := []string{
+		"ALTER TABLE analytics ADD COLUMN breaks_taken INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE analytics ADD COLUMN breaks_skipped INTEGER NOT NULL DEFAULT 0",
+	}
+	for _, step := range steps {
+		if _, err := tx.Exec(step); err != nil {
+			return fmt.Errorf("%s: %w", step, err)
+		}
+	}
+	return nil
+}
+
+// ============================================
+// 7 : ORDRE DES SESSIONS
+// ============================================
+
+// migrateSessionOrdering : Stratégie d'enchaînement choisie au démarrage
+func migrateSessionOrdering(tx *sql.Tx) error {
+	step := "ALTER TABLE sessions ADD COLUMN ordering TEXT NOT NULL DEFAULT 'priority'"
+	if _, err := tx.Exec(step); err != nil {
+		return fmt.Errorf("%s: %w", step, err)
+	}
+	return nil
+}
+
+// ============================================
+// 8 : MINUTEUR / TEMPS ACTIF
+// ============================================
+
+// migrateFocusTimer : Budget du minuteur, temps actif cumulé et blocs de
+// concentration (sessions terminées : temps actif = durée enregistrée,
+// sessions ouvertes : un bloc en cours depuis le début)
+func migrateFocusTimer(tx *sql.Tx) error {
+	steps := []string{
+		"ALTER TABLE sessions ADD COLUMN time_budget_sec INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE sessions ADD COLUMN active_sec INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE sessions ADD COLUMN block_started_at INTEGER",
+		"ALTER TABLE sessions ADD COLUMN focus_blocks INTEGER NOT NULL DEFAULT 0",
+		"UPDATE sessions SET active_sec = COALESCE(duration_min, 0) * 60, focus_blocks = 1 WHERE ended_at IS NOT NULL",
+		"UPDATE sessions SET block_started_at = started_at, focus_blocks = 1 WHERE ended_at IS NULL",
+	}
+	for _, step := range steps {
+		if _, err := tx.Exec(step); err != nil {
+			return fmt.Errorf("%s: %w", step, err)
+		}
+	}
+	return nil
+}
+
+// ============================================
+// 9 : ADAPTATION D'ÉNERGIE
+// ============================================
+
+// migrateEnergyAdapter : Énergie de départ si la session a été abaissée et
+// exercices complétés lors de la dernière adaptation
+func migrateEnergyAdapter(tx *sql.Tx) error {
+	steps := []string{
+		"ALTER TABLE sessions ADD COLUMN adapted_from TEXT",
+		"ALTER TABLE sessions ADD COLUMN adapted_after INTEGER NOT NULL DEFAULT 0",
+	}
+	for _, step := range steps {
+		if _, err := tx.Exec(step); err != nil {
+			return fmt.Errorf("%s: %w", step, err)
+		}
+	}
+	return nil
+}
+
+// ============================================
+// 10 : TEMPLATES DE SESSION
+// ============================================
+
+// migrateSessionTemplates : sessions.mode référence session_templates.slug
+// (remplace la contrainte CHECK des trois modes). SQLite ne modifie pas une
+// contrainte en place : la table est reconstruite, colonnes des migrations
+// 5 à 9 comprises. Les modes inconnus (aucun en pratique) passent à NULL.
+func migrateSessionTemplates(tx *sql.Tx) error {
+	steps := []string{
+		`CREATE TABLE sessions_new (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            started_at INTEGER NOT NULL,
+            ended_at INTEGER,
+            energy_level TEXT CHECK(energy_level IN ('low', 'medium', 'high')),
+            mode TEXT REFERENCES session_templates(slug) ON UPDATE CASCADE,
+            completed_count INTEGER DEFAULT 0,
+            duration_min INTEGER,
+            created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+            status TEXT NOT NULL DEFAULT 'active',
+            ordering TEXT NOT NULL DEFAULT 'priority',
+            time_budget_sec INTEGER NOT NULL DEFAULT 0,
+            active_sec INTEGER NOT NULL DEFAULT 0,
+            block_started_at INTEGER,
+            focus_blocks INTEGER NOT NULL DEFAULT 0,
+            adapted_from TEXT,
+            adapted_after INTEGER NOT NULL DEFAULT 0
+        )`,
+		`INSERT INTO sessions_new (id, started_at, ended_at, energy_level, mode, completed_count,
+            duration_min, created_at, status, ordering, time_budget_sec, active_sec,
+            block_started_at, focus_blocks, adapted_from, adapted_after)
+        SELECT id, started_at, ended_at, energy_level,
+            CASE WHEN mode IN (SELECT slug FROM session_templates) THEN mode END,
+            completed_count, duration_min, created_at, status, ordering, time_budget_sec,
+            active_sec, block_started_at, focus_blocks, adapted_from, adapted_after
+        FROM sessions`,
+		"DROP TABLE sessions",
+		"ALTER TABLE sessions_new RENAME TO sessions",
+		"CREATE INDEX IF NOT EXISTS idx_session_date ON sessions(started_at)",
+	}
+	for _, step := range steps {
+		if _, err := tx.Exec(step); err != nil {
+			return fmt.Errorf("%s: %w", step, err)
+		}
+	}
+	return nil
+}
+
+// ============================================
+// 11 : SESSIONS D'ENTRAÎNEMENT
+// ============================================
+
+// migratePracticeSessions : Sessions d'entraînement (révisions sans effet
+// sur la planification SRS ni progress_log)
+func migratePracticeSessions(tx *sql.Tx) error {
+	steps := []string{
+		"ALTER TABLE sessions ADD COLUMN practice INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE session_exercises ADD COLUMN practice INTEGER NOT NULL DEFAULT 0",
+	}
+	for _, step := range steps {
+		if _, err := tx.Exec(step); err != nil {
+			return fmt.Errorf("%s: %w", step, err)
+		}
+	}
+	return nil
+}
+
+// ============================================
+// 12 : HORODATAGES UNIX (sessions, analytics, settings)
+// ============================================
+
+// legacyDateDefault : Défaut YYYYMMDD UTC (ignorait fuseau et heure de bascule)
+const legacyDateDefault = "strftime('%Y%m%d', 'now')"
+
+// migrateUnixDefaults : sessions.created_at, analytics.updated_at et
+// settings.updated_at passent de YYYYMMDD à Unix (valeurs existantes puis
+// défauts des colonnes).
+func migrateUnixDefaults(tx *sql.Tx) error {
+	// 1. Sessions : l'instant de création est le début de session
+	if _, err := tx.Exec(`UPDATE sessions SET created_at = started_at
+        WHERE created_at BETWEEN 19000101 AND 99991231`); err != nil {
+		return fmt.Errorf("convert sessions.created_at: %w", err)
+	}
+
+	// 2. analytics / settings : début du jour utilisateur (comme la migration 1)
+	for _, t := range []struct{ table, key string }{{"analytics", "id"}, {"settings", "key"}} {
+		if err := convertDateColumn(tx, t.table, t.key, "updated_at"); err != nil {
+			return err
+		}
+	}
+
+	// 3. Défauts en Unix
+	for _, table := range []string{"sessions", "analytics", "settings"} {
+		if err := rebuildWithUnixDefault(tx, table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertDateColumn : Valeurs YYYYMMDD de column → Unix (début du jour utilisateur)
+func convertDateColumn(tx *sql.Tx, table, key, column string) error {
+	rows, err := tx.Query(fmt.Sprintf("SELECT %s, %s FROM %s", key, column, table))
+	if err != nil {
+		return fmt.Errorf("query %s.%s: %w", table, column, err)
+	}
+
+	type row struct {
+		key   any
+		value int64
+	}
+	var pending []row
+	for rows.Next() {
+		var r row
+		var v sql.NullInt64
+		if err := rows.Scan(&r.key, &v); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan %s.%s: %w", table, column, err)
+		}
+		if v.Valid && isDateInt(v.Int64) {
+			r.value = fromDateInt(int(v.Int64)).Unix()
+			pending = append(pending, r)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate %s.%s: %w", table, column, err)
+	}
+
+	for _, r := range pending {
+		query := fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ?", table, column, key)
+		if _, err := tx.Exec(query, r.value, r.key); err != nil {
+			return fmt.Errorf("convert %s.%s: %w", table, column, err)
+		}
+	}
+	return nil
+}
+
+// rebuildWithUnixDefault : SQLite ne modifie pas un DEFAULT en place : la
+// table est recréée depuis sa définition courante (colonnes des migrations
+// comprises), défaut remplacé, puis ses index sont recréés.
+func rebuildWithUnixDefault(tx *sql.Tx, table string) error {
+	var ddl string
+	if err := tx.QueryRow("SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&ddl); err != nil {
+		return fmt.Errorf("read %s definition: %w", table, err)
+	}
+	if !strings.Contains(ddl, legacyDateDefault) {
+		return nil // Base créée avec le schéma courant
+	}
+
+	indexes, err := tableIndexes(tx, table)
+	if err != nil {
+		return err
+	}
+
+	columns := ddl[strings.Index(ddl, "("):]
+	columns = strings.ReplaceAll(columns, legacyDateDefault, "strftime('%s', 'now')")
+	steps := []string{
+		"CREATE TABLE " + table + "_new " + columns,
+		"INSERT INTO " + table + "_new SELECT * FROM " + table,
+		"DROP TABLE " + table,
+		"ALTER TABLE " + table + "_new RENAME TO " + table,
+	}
+	for _, step := range append(steps, indexes...) {
+		if _, err := tx.Exec(step); err != nil {
+			return fmt.Errorf("%s: %w", step, err)
+		}
+	}
+	return nil
+}
+
+// tableIndexes : Définitions des index explicites d'une table
+func tableIndexes(tx *sql.Tx, table string) ([]string, error) {
+	rows, err := tx.Query(`SELECT sql FROM sqlite_master
+        WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL`, table)
+	if err != nil {
+		return nil, fmt.Errorf("query %s indexes: %w", table, err)
+	}
+	defer rows.Close()
+
+	var indexes []string
+	for rows.Next() {
+		var ddl string
+		if err := rows.Scan(&ddl); err != nil {
+			return nil, fmt.Errorf("scan %s index: %w", table, err)
+		}
+		indexes = append(indexes, ddl)
+	}
+	return indexes, rows.Err()
+}
+
+// ============================================
+// 13 : TAGS EXERCICES
+// ============================================
+
+// migrateExerciseTags : Tags libres (JSON array, comme steps) pour le focus du builder
+func migrateExerciseTags(tx *sql.Tx) error {
+	step := "ALTER TABLE exercises ADD COLUMN tags TEXT NOT NULL DEFAULT '[]'"
+	if _, err := tx.Exec(step); err != nil {
+		return fmt.Errorf("%s: %w", step, err)
+	}
+	return nil
+}
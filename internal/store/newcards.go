@@ -0,0 +1,102 @@
+package store
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"maestro/internal/domain/session"
+	"maestro/internal/models"
+)
+
+// ============================================
+// NOUVEAUX EXERCICES (quota quotidien)
+// ============================================
+
+// GetNewCardPolicy : Quota et ratio des réglages (défaut si invalides)
+func GetNewCardPolicy() session.NewCardPolicy {
+	def := session.DefaultNewCardPolicy()
+	policy := session.NewCardPolicy{
+		DailyLimit: GetSettingInt(SettingNewCardsPerDay, def.DailyLimit),
+		Ratio:      GetSettingInt(SettingNewCardRatio, def.Ratio),
+	}
+	if policy.Validate() != nil {
+		return def
+	}
+	return policy
+}
+
+// SetNewCardPolicy : Persiste le quota et le ratio
+func SetNewCardPolicy(policy session.NewCardPolicy) error {
+	if err := SetSetting(SettingNewCardsPerDay, strconv.Itoa(policy.DailyLimit)); err != nil {
+		return err
+	}
+	return SetSetting(SettingNewCardRatio, strconv.Itoa(policy.Ratio))
+}
+
+// RecordNewCardIntroduced : Compte un nouveau révisé pour la première fois ce jour
+func RecordNewCardIntroduced(day int) error {
+	_, err := db.Exec(`
+        INSERT INTO daily_new_cards (day, introduced) VALUES (?, 1)
+        ON CONFLICT(day) DO UPDATE SET introduced = introduced + 1
+    `, day)
+	if err != nil {
+		return fmt.Errorf("record new card %d: %w", day, err)
+	}
+	notifyChange(TableNewCards)
+	return nil
+}
+
+// GetNewCardsIntroduced : Nouveaux introduits un jour donné
+func GetNewCardsIntroduced(day int) int {
+	var n int
+	if err := db.QueryRow(`SELECT introduced FROM daily_new_cards WHERE day = ?`, day).Scan(&n); err != nil {
+		return 0
+	}
+	return n
+}
+
+// CountNewQueued : Exercices jamais révisés en attente
+func CountNewQueued() int {
+	var n int
+	if err := db.QueryRow(`
+        SELECT COUNT(*) FROM exercises
+        WHERE deleted = 0 AND last_reviewed_date IS NULL
+    `).Scan(&n); err != nil {
+		log.Printf("❌ [NewCards] %v", err)
+	}
+	return n
+}
+
+// GetNewCardHistory : Nouveaux introduits sur les N derniers jours (jours vides inclus)
+func GetNewCardHistory(days int) ([]models.NewCardDay, error) {
+	today := todayInt()
+	from := addDays(today, -(days - 1))
+
+	rows, err := db.Query(`
+        SELECT day, introduced FROM daily_new_cards
+        WHERE day >= ? AND day <= ?
+    `, from, today)
+	if err != nil {
+		return nil, fmt.Errorf("query new card history: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var day, n int
+		if err := rows.Scan(&day, &n); err != nil {
+			return nil, fmt.Errorf("scan new card day: %w", err)
+		}
+		counts[day] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	history := make([]models.NewCardDay, 0, days)
+	for day := from; day <= today; day = addDays(day, 1) {
+		history = append(history, models.NewCardDay{Date: fromDateInt(day), Introduced: counts[day]})
+	}
+	return history, nil
+}
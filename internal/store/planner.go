@@ -7,7 +7,8 @@ import "maestro/internal/models"
 // GetPlannerExercises : filtre par date (pour Planner uniquement)
 func GetPlannerExercises(view string) ([]models.Exercise, error) {
 	query := `SELECT id, title, domain, difficulty, done, 
-                     next_review_date, completed_steps, steps
+                     next_review_date, completed_steps, steps,
+                     last_reviewed_date, interval_days, tags
               FROM exercises WHERE deleted = 0`
 
 	args := []interface{}{}
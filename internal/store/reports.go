@@ -7,6 +7,9 @@ import (
 	"maestro/internal/models"
 )
 
+// GetTodayReport : Révisions dues (retard + aujourd'hui) puis file des nouveaux
+// (ordre de création). La file est complète : le quota quotidien est appliqué à
+// la sélection, le rapport n'annonce que les nouveaux encore introductibles.
 func GetTodayReport() (models.SessionReport, []models.Exercise, error) {
 	today := todayInt()
 
@@ -14,36 +17,33 @@ func GetTodayReport() (models.SessionReport, []models.Exercise, error) {
 
 	report := models.SessionReport{}
 
-	// 1. Compte exercices dus AUJOURD'HUI ou EN RETARD (ignore done)
-	err := db.QueryRow(`
-        SELECT COUNT(*) FROM exercises 
+	// 1. Révisions dues AUJOURD'HUI ou EN RETARD (déjà révisés au moins une fois)
+	query := `
+        SELECT id, title, description, domain, difficulty,
+               content, mnemonic, conceptual_visuals, tags,
+               steps, completed_steps, done, 
+               last_reviewed_date, next_review_date,
+               ease_factor, interval_days, repetitions,
+               skipped_count, last_skipped_date,
+               deleted, created_at, updated_at
+        FROM exercises 
         WHERE deleted = 0 
+        AND last_reviewed_date IS NOT NULL
         AND next_review_date > 0
         AND next_review_date <= ?
-    `, today).Scan(&report.TodayDue)
+        ORDER BY next_review_date ASC
+    `
+	reviews, err := queryExercisesFull(query, today)
 	if err != nil {
-		log.Printf("🔍 [ERREUR] TodayDue: %v", err)
+		return report, nil, fmt.Errorf("due reviews: %w", err)
 	}
+	report.TodayDue = len(reviews)
 	log.Printf("🔍 [GetTodayReport] TodayDue (retard+aujourd'hui) = %d ✅", report.TodayDue)
 
-	// 2. Nouveaux (jamais révisés)
-	err = db.QueryRow(`
-        SELECT COUNT(*) FROM exercises 
-        WHERE deleted = 0 
-        AND last_reviewed_date IS NULL
-    `).Scan(&report.TodayNew)
-	if err != nil {
-		log.Printf("🔍 [ERREUR] TodayNew: %v", err)
-	}
-	log.Printf("🔍 [GetTodayReport] TodayNew = %d", report.TodayNew)
-
-	report.TotalAvailable = report.TodayDue + report.TodayNew
-	log.Printf("🔍 [SESSION] Total disponible = %d 🚀", report.TotalAvailable)
-
-	// 3. Liste des exercices (tous ceux à réviser AUJOURD'HUI ou EN RETARD)
-	query := `
+	// 2. File des nouveaux (jamais révisés), plus anciens d'abord
+	query = `
         SELECT id, title, description, domain, difficulty,
-               content, mnemonic, conceptual_visuals,
+               content, mnemonic, conceptual_visuals, tags,
                steps, completed_steps, done, 
                last_reviewed_date, next_review_date,
                ease_factor, interval_days, repetitions,
@@ -51,18 +51,26 @@ func GetTodayReport() (models.SessionReport, []models.Exercise, error) {
                deleted, created_at, updated_at
         FROM exercises 
         WHERE deleted = 0 
-        AND next_review_date > 0
-        AND next_review_date <= ?
-        ORDER BY next_review_date ASC
+        AND last_reviewed_date IS NULL
+        ORDER BY created_at ASC, id ASC
     `
-
-	exercises, err := queryExercisesFull(query, today)
+	fresh, err := queryExercisesFull(query)
 	if err != nil {
-		log.Printf("🔍 [ERREUR] queryExercisesFull: %v", err)
+		return report, nil, fmt.Errorf("new queue: %w", err)
 	}
-	log.Printf("🔍 [SESSION] %d exercices à pratiquer", len(exercises))
 
-	return report, exercises, nil
+	policy := GetNewCardPolicy()
+	report.NewQueued = len(fresh)
+	report.NewLimit = policy.DailyLimit
+	report.NewIntroduced = GetNewCardsIntroduced(today)
+	report.TodayNew = min(report.NewQueued, policy.Remaining(report.NewIntroduced))
+	log.Printf("🔍 [GetTodayReport] TodayNew = %d (file %d, introduits %d/%d)",
+		report.TodayNew, report.NewQueued, report.NewIntroduced, report.NewLimit)
+
+	report.TotalAvailable = report.TodayDue + report.TodayNew
+	log.Printf("🔍 [SESSION] Total disponible = %d 🚀", report.TotalAvailable)
+
+	return report, append(reviews, fresh...), nil
 }
 
 func getUpcomingReviews(days int) []models.UpcomingReview {
@@ -113,3 +121,90 @@ func formatDateInt(dateInt int) string {
 	day := dateInt % 100
 	return fmt.Sprintf("%04d-%02d-%02d", year, month, day)
 }
+
+// ============================================
+// RAPPORTS DE PROGRESSION (période [from, to))
+// ============================================
+
+// GetPeriodReviewTotals : Reviews, réussites et oublis entre from et to (Unix)
+func GetPeriodReviewTotals(from, to int64) (models.ReviewTotals, error) {
+	var t models.ReviewTotals
+	err := db.QueryRow(`SELECT
+            COUNT(*),
+            COALESCE(SUM(quality >= 2), 0),
+            COALESCE(SUM(quality = 0), 0)
+        FROM progress_log
+        WHERE reviewed_at >= ? AND reviewed_at < ?`, from, to,
+	).Scan(&t.Reviews, &t.Successful, &t.Lapses)
+	if err != nil {
+		return t, fmt.Errorf("query period review totals: %w", err)
+	}
+	return t, nil
+}
+
+// GetNewlyLearned : Exercices actifs dont la première review tombe entre from et to
+func GetNewlyLearned(from, to int64, limit int) (int, []models.Exercise, error) {
+	rows, err := db.Query(`SELECT e.id, e.title, e.domain, e.difficulty
+        FROM (
+            SELECT exercise_id, MIN(reviewed_at) AS first_review
+            FROM progress_log
+            GROUP BY exercise_id
+        ) f
+        JOIN exercises e ON e.id = f.exercise_id
+        WHERE e.deleted = 0 AND f.first_review >= ? AND f.first_review < ?
+        ORDER BY f.first_review ASC`, from, to)
+	if err != nil {
+		return 0, nil, fmt.Errorf("query newly learned: %w", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	var exercises []models.Exercise
+	for rows.Next() {
+		count++
+		if len(exercises) >= limit {
+			continue
+		}
+		var ex models.Exercise
+		if err := rows.Scan(&ex.ID, &ex.Title, &ex.Domain, &ex.Difficulty); err != nil {
+			return 0, nil, fmt.Errorf("scan newly learned: %w", err)
+		}
+		exercises = append(exercises, ex)
+	}
+	return count, exercises, rows.Err()
+}
+
+// GetSessionMinutes : Minutes des sessions terminées (avec au moins une review)
+// entre from et to
+func GetSessionMinutes(from, to int64) (int, error) {
+	var minutes int
+	err := db.QueryRow(`SELECT COALESCE(SUM(duration_min), 0)
+        FROM sessions
+        WHERE ended_at >= ? AND ended_at < ? AND completed_count > 0`, from, to).Scan(&minutes)
+	if err != nil {
+		return 0, fmt.Errorf("query session minutes: %w", err)
+	}
+	return minutes, nil
+}
+
+// GetDueCounts : Exercices dus par jour (YYYYMMDD) entre from et to inclus
+func GetDueCounts(from, to int) (map[int]int, error) {
+	rows, err := db.Query(`SELECT next_review_date, COUNT(*)
+        FROM exercises
+        WHERE deleted = 0 AND next_review_date BETWEEN ? AND ?
+        GROUP BY next_review_date`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query due counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var day, count int
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, fmt.Errorf("scan due count: %w", err)
+		}
+		counts[day] = count
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"fmt"
+
+	"maestro/internal/domain/srs"
+	"maestro/internal/models"
+)
+
+// ============================================
+// RÉTENTION (Paires de reviews consécutives)
+// ============================================
+
+// GetRecallPairs : Paires de reviews consécutives d'un même exercice,
+// regroupées par heures écoulées et intervalle planifié à la review précédente.
+// domain "" et difficulty 0 = pas de filtre (exercices supprimés exclus).
+// Les paires sont formées en Go sur un parcours ordonné de l'index couvrant :
+// une fonction fenêtre (LAG) est plusieurs fois plus lente sur de gros historiques.
+func GetRecallPairs(domain string, difficulty int) ([]models.RecallPairGroup, error) {
+	query := `SELECT exercise_id, reviewed_at, COALESCE(interval_days, 0), quality
+        FROM progress_log
+        WHERE exercise_id IN (
+            SELECT id FROM exercises
+            WHERE deleted = 0
+            AND (? = '' OR domain = ?)
+            AND (? = 0 OR difficulty = ?)
+        )
+        ORDER BY exercise_id, reviewed_at`
+
+	rows, err := db.Query(query, domain, domain, difficulty, difficulty)
+	if err != nil {
+		return nil, fmt.Errorf("query recall pairs: %w", err)
+	}
+	defer rows.Close()
+
+	type pairKey struct{ hours, interval int }
+	groups := make(map[pairKey]*models.RecallPairGroup)
+	var order []pairKey
+
+	prevID, prevAt, prevInterval := int64(-1), int64(0), 0
+	for rows.Next() {
+		var id, at int64
+		var interval, quality int
+		if err := rows.Scan(&id, &at, &interval, &quality); err != nil {
+			return nil, fmt.Errorf("scan recall pair: %w", err)
+		}
+
+		if id == prevID {
+			key := pairKey{int((at - prevAt) / 3600), prevInterval}
+			g, ok := groups[key]
+			if !ok {
+				g = &models.RecallPairGroup{ElapsedHours: key.hours, PrevInterval: key.interval}
+				groups[key] = g
+				order = append(order, key)
+			}
+			g.Count++
+			if srs.Recalled(srs.ReviewQuality(quality)) {
+				g.Recalled++
+			}
+		}
+		prevID, prevAt, prevInterval = id, at, interval
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate recall pairs: %w", err)
+	}
+
+	result := make([]models.RecallPairGroup, 0, len(order))
+	for _, key := range order {
+		result = append(result, *groups[key])
+	}
+	return result, nil
+}
@@ -1,5 +1,7 @@
 -- ============================================
--- MAESTRO GO v2 - DATABASE SCHEMA (Dates YYYYMMDD)
+-- MAESTRO GO v2 - DATABASE SCHEMA
+-- Jours (échéances) : YYYYMMDD
+-- Instants (révisions, cycle de vie) : Unix secondes
 -- ============================================
 
 CREATE TABLE IF NOT EXISTS exercises (
@@ -18,10 +20,10 @@ CREATE TABLE IF NOT EXISTS exercises (
     steps TEXT,
     completed_steps TEXT,
     
-    -- SRS (Spaced Repetition System) - DATES UNIQUEMENT
+    -- SRS (Spaced Repetition System)
     done BOOLEAN DEFAULT 0,
-    last_reviewed_date INTEGER,
-    next_review_date INTEGER NOT NULL DEFAULT 0,
+    last_reviewed_date INTEGER,              -- Unix secondes
+    next_review_date INTEGER NOT NULL DEFAULT 0, -- YYYYMMDD
     ease_factor REAL DEFAULT 2.5 CHECK(ease_factor >= 1.3),
     interval_days INTEGER DEFAULT 1,
     repetitions INTEGER DEFAULT 0,
@@ -30,9 +32,9 @@ CREATE TABLE IF NOT EXISTS exercises (
     
     -- Soft delete
     deleted BOOLEAN DEFAULT 0,
-    deleted_at INTEGER,
+    deleted_at INTEGER,                      -- Unix secondes
     
-    -- Metadata
+    -- Metadata (Unix secondes)
     created_at INTEGER NOT NULL,
     updated_at INTEGER NOT NULL
 );
@@ -43,6 +45,35 @@ CREATE INDEX IF NOT EXISTS idx_domain ON exercises(domain, deleted);
 CREATE INDEX IF NOT EXISTS idx_done ON exercises(done) WHERE deleted = 0;
 CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_title ON exercises(title) WHERE deleted = 0;
 
+-- ============================================
+-- TABLE : SESSION_TEMPLATES
+-- ============================================
+CREATE TABLE IF NOT EXISTS session_templates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    slug TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    energy_level TEXT NOT NULL CHECK(energy_level IN ('low', 'medium', 'high')),
+    duration_min INTEGER NOT NULL,
+    max_exercises INTEGER NOT NULL,
+    break_every INTEGER NOT NULL DEFAULT 0,
+    break_schedule TEXT NOT NULL DEFAULT '[]', -- Minutes (JSON), cycle
+    ordering TEXT NOT NULL DEFAULT 'priority',
+    domains TEXT NOT NULL DEFAULT '[]',        -- JSON, vide = tous
+    min_difficulty INTEGER NOT NULL DEFAULT 0,
+    max_difficulty INTEGER NOT NULL DEFAULT 0,
+    new_ratio INTEGER NOT NULL DEFAULT -1,     -- -1 = réglage new_card_ratio
+    builtin INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
+);
+
+-- Templates intégrés (session.DefaultConfigs)
+INSERT OR IGNORE INTO session_templates
+    (slug, name, description, energy_level, duration_min, max_exercises, break_every, break_schedule, builtin) VALUES
+    ('micro', 'Micro', 'Session courte (1-2 exos, 15min)', 'low', 15, 2, 0, '[5]', 1),
+    ('standard', 'Standard', 'Session moyenne (2-4 exos, 30min)', 'medium', 30, 4, 2, '[5,10]', 1),
+    ('deep', 'Deep', 'Session longue (4-8 exos, 60min)', 'high', 60, 8, 3, '[5,10,15]', 1);
+
 -- ============================================
 -- TABLE : SESSIONS
 -- ============================================
@@ -51,10 +82,10 @@ CREATE TABLE IF NOT EXISTS sessions (
     started_at INTEGER NOT NULL,
     ended_at INTEGER,
     energy_level TEXT CHECK(energy_level IN ('low', 'medium', 'high')),
-    mode TEXT CHECK(mode IN ('micro', 'standard', 'deep')),
+    mode TEXT REFERENCES session_templates(slug) ON UPDATE CASCADE,
     completed_count INTEGER DEFAULT 0,
     duration_min INTEGER,
-    created_at INTEGER NOT NULL DEFAULT (strftime('%Y%m%d', 'now'))
+    created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
 );
 
 CREATE INDEX IF NOT EXISTS idx_session_date ON sessions(started_at);
@@ -76,6 +107,21 @@ CREATE TABLE IF NOT EXISTS session_exercises (
 
 CREATE INDEX IF NOT EXISTS idx_session_ex ON session_exercises(session_id);
 
+-- ============================================
+-- TABLE : SESSION_BREAKS (pauses imposées)
+-- ============================================
+CREATE TABLE IF NOT EXISTS session_breaks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id INTEGER NOT NULL,
+    after_count INTEGER NOT NULL, -- Exercices complétés au début de la pause
+    planned_sec INTEGER NOT NULL,
+    started_at INTEGER NOT NULL,
+    ended_at INTEGER,
+    skipped BOOLEAN NOT NULL DEFAULT 0,
+    UNIQUE (session_id, after_count),
+    FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE CASCADE
+);
+
 -- ============================================
 -- TABLE : PROGRESS_LOG
 -- ============================================
@@ -91,6 +137,8 @@ CREATE TABLE IF NOT EXISTS progress_log (
 );
 
 CREATE INDEX IF NOT EXISTS idx_progress_exercise ON progress_log(exercise_id, reviewed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_progress_reviewed ON progress_log(reviewed_at, quality); -- Agrégats dashboard
+CREATE INDEX IF NOT EXISTS idx_progress_pairs ON progress_log(exercise_id, reviewed_at, interval_days, quality); -- Courbe de rétention
 
 -- ============================================
 -- TABLE : ANALYTICS
@@ -105,10 +153,10 @@ CREATE TABLE IF NOT EXISTS analytics (
     last_session_date INTEGER,
     total_sessions INTEGER DEFAULT 0,
     total_exercises_done INTEGER DEFAULT 0,
-    updated_at INTEGER NOT NULL DEFAULT (strftime('%Y%m%d', 'now'))
+    updated_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
 );
 
-INSERT OR IGNORE INTO analytics (id, updated_at) VALUES (1, strftime('%Y%m%d', 'now'));
+INSERT OR IGNORE INTO analytics (id, updated_at) VALUES (1, strftime('%s', 'now'));
 
 -- ============================================
 -- TABLE : SETTINGS
@@ -116,21 +164,58 @@ INSERT OR IGNORE INTO analytics (id, updated_at) VALUES (1, strftime('%Y%m%d', '
 CREATE TABLE IF NOT EXISTS settings (
     key TEXT PRIMARY KEY,
     value TEXT NOT NULL,
-    updated_at INTEGER NOT NULL DEFAULT (strftime('%Y%m%d', 'now'))
+    updated_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
 );
 
 INSERT OR IGNORE INTO settings (key, value) VALUES 
     ('theme', 'dark'),
     ('session_reminder', 'true'),
     ('default_energy', 'medium'),
-    ('ascii_visuals_enabled', 'true');
+    ('ascii_visuals_enabled', 'true'),
+    ('timezone', 'Local'),
+    ('day_rollover_hour', '0'),
+    ('daily_goal_kind', 'reviews'),
+    ('daily_goal_target', '1'),
+    ('report_dir', ''),
+    ('at_risk_recall', '85'),
+    ('at_risk_days', '3'),
+    ('session_idle_minutes', '120'),
+    ('new_cards_per_day', '10'),
+    ('new_card_ratio', '25'),
+    ('energy_adapter', 'true');
+
+-- ============================================
+-- TABLE : STREAK FREEZES (jours de congé planifiés)
+-- ============================================
+CREATE TABLE IF NOT EXISTS streak_freezes (
+    day INTEGER PRIMARY KEY, -- YYYYMMDD (jour utilisateur)
+    created_at INTEGER NOT NULL
+);
+
+-- ============================================
+-- TABLE : DAILY_NEW_CARDS (nouveaux introduits par jour)
+-- ============================================
+CREATE TABLE IF NOT EXISTS daily_new_cards (
+    day INTEGER PRIMARY KEY, -- YYYYMMDD (jour utilisateur)
+    introduced INTEGER NOT NULL DEFAULT 0
+);
+
+-- ============================================
+-- TABLE : ACHIEVEMENTS (succès débloqués)
+-- ============================================
+CREATE TABLE IF NOT EXISTS achievements (
+    key TEXT PRIMARY KEY, -- "streak_30", "domain_mature:Go"
+    unlocked_at INTEGER NOT NULL
+);
 
 -- ============================================
 -- TRIGGERS
 -- ============================================
-CREATE TRIGGER IF NOT EXISTS update_exercise_timestamp
+-- Touche updated_at uniquement si l'UPDATE ne l'a pas fixé lui-même
+CREATE TRIGGER IF NOT EXISTS touch_exercise_updated_at
 AFTER UPDATE ON exercises
 FOR EACH ROW
+WHEN NEW.updated_at = OLD.updated_at
 BEGIN
-    UPDATE exercises SET updated_at = strftime('%Y%m%d', 'now') WHERE id = OLD.id;
+    UPDATE exercises SET updated_at = CAST(strftime('%s', 'now') AS INTEGER) WHERE id = OLD.id;
 END;
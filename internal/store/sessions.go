@@ -3,6 +3,7 @@ package store
 import (
 	"database/sql"
 	"fmt"
+	"strconv"
 	"time"
 
 	"maestro/internal/domain/session" // ✅ NOUVEAU
@@ -13,22 +14,22 @@ import (
 // SESSION CRUD
 // ============================================
 
-// StartSession : Crée nouvelle session en DB
-func StartSession(energy models.EnergyLevel, exercises []models.Exercise) (int64, error) {
+// StartSession : Crée nouvelle session en DB à partir d'un template
+// (exercices déjà dans l'ordre de passage, timeBudget 0 = non chronométrée,
+// practice = entraînement). Le premier bloc de concentration commence immédiatement.
+func StartSession(config session.Config, exercises []models.Exercise, ordering session.Ordering, timeBudget time.Duration, practice bool) (int64, error) {
 	tx, err := db.Begin()
 	if err != nil {
 		return 0, fmt.Errorf("begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	// Récupère config depuis domain
-	config := session.GetConfig(energy)
-
 	// Insert session
+	now := nowUnix()
 	result, err := tx.Exec(`
-        INSERT INTO sessions (started_at, energy_level, mode)
-        VALUES (?, ?, ?)
-    `, time.Now().Unix(), energyToString(energy), config.Mode)
+        INSERT INTO sessions (started_at, energy_level, mode, ordering, time_budget_sec, block_started_at, focus_blocks, practice, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, 1, ?, ?)
+    `, now, energyToString(config.Level), config.Mode, string(ordering), int64(timeBudget/time.Second), now, practice, now)
 	if err != nil {
 		return 0, fmt.Errorf("insert session: %w", err)
 	}
@@ -37,8 +38,8 @@ func StartSession(energy models.EnergyLevel, exercises []models.Exercise) (int64
 
 	// Insert exercices de la session
 	stmt, err := tx.Prepare(`
-        INSERT INTO session_exercises (session_id, exercise_id, position)
-        VALUES (?, ?, ?)
+        INSERT INTO session_exercises (session_id, exercise_id, position, practice)
+        VALUES (?, ?, ?, ?)
     `)
 	if err != nil {
 		return 0, fmt.Errorf("prepare session exercises: %w", err)
@@ -46,7 +47,7 @@ func StartSession(energy models.EnergyLevel, exercises []models.Exercise) (int64
 	defer stmt.Close()
 
 	for i, ex := range exercises {
-		_, err := stmt.Exec(sessionID, ex.ID, i)
+		_, err := stmt.Exec(sessionID, ex.ID, i, practice)
 		if err != nil {
 			return 0, fmt.Errorf("insert session exercise %d: %w", ex.ID, err)
 		}
@@ -56,18 +57,42 @@ func StartSession(energy models.EnergyLevel, exercises []models.Exercise) (int64
 		return 0, fmt.Errorf("commit session: %w", err)
 	}
 
+	notifyChange(TableSessions)
 	return sessionID, nil
 }
 
-// CompleteSessionExercise : Marque exercice complété
-func CompleteSessionExercise(sessionID int64, exerciseID int, quality int) error {
+// MarkSessionExerciseShown : Horodate le premier affichage (un rechargement ne le décale pas)
+func MarkSessionExerciseShown(sessionID int64, exerciseID int) error {
+	_, err := db.Exec(`UPDATE session_exercises SET shown_at = ?
+        WHERE session_id = ? AND exercise_id = ? AND shown_at IS NULL AND completed = 0`,
+		nowUnix(), sessionID, exerciseID)
+	if err != nil {
+		return fmt.Errorf("mark session exercise shown: %w", err)
+	}
+	return nil
+}
+
+// GetSessionExerciseShownAt : Premier affichage (zéro si jamais affiché)
+func GetSessionExerciseShownAt(sessionID int64, exerciseID int) (time.Time, error) {
+	var shownAt sql.NullInt64
+	err := db.QueryRow(`SELECT shown_at FROM session_exercises
+        WHERE session_id = ? AND exercise_id = ?`, sessionID, exerciseID).Scan(&shownAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("query shown_at: %w", err)
+	}
+	return fromUnix(shownAt.Int64), nil
+}
+
+// CompleteSessionExercise : Marque exercice complété (duration 0 = non mesurée)
+func CompleteSessionExercise(sessionID int64, exerciseID int, quality int, duration time.Duration) error {
 	query := `UPDATE session_exercises SET
         completed = 1,
         quality = ?,
-        reviewed_at = ?
+        reviewed_at = ?,
+        duration_sec = ?
     WHERE session_id = ? AND exercise_id = ?`
 
-	result, err := db.Exec(query, quality, time.Now().Unix(), sessionID, exerciseID)
+	result, err := db.Exec(query, quality, nowUnix(), toNullSeconds(duration), sessionID, exerciseID)
 	if err != nil {
 		return fmt.Errorf("update session exercise: %w", err)
 	}
@@ -77,16 +102,25 @@ func CompleteSessionExercise(sessionID int64, exerciseID int, quality int) error
 		return fmt.Errorf("exercise %d not found in session %d", exerciseID, sessionID)
 	}
 
+	notifyChange(TableSessions)
 	return nil
 }
 
-// EndSession : Termine session
+// EndSession : Termine session (durée = temps actif, pauses exclues).
+// SessionClosedError si elle est déjà terminée ou abandonnée (analytics
+// comptées une seule fois).
 func EndSession(sessionID int64) error {
-	// Récupère heure de début
-	var startedAt int64
-	err := db.QueryRow("SELECT started_at FROM sessions WHERE id = ?", sessionID).Scan(&startedAt)
+	now := nowUnix()
+	if err := closeFocusBlock(db, sessionID, now); err != nil {
+		return err
+	}
+
+	// Temps actif cumulé
+	var activeSec int64
+	var practice bool
+	err := db.QueryRow("SELECT active_sec, practice FROM sessions WHERE id = ?", sessionID).Scan(&activeSec, &practice)
 	if err != nil {
-		return fmt.Errorf("query session start time: %w", err)
+		return fmt.Errorf("query session active time: %w", err)
 	}
 
 	// Compte exercices complétés
@@ -100,25 +134,40 @@ func EndSession(sessionID int64) error {
 	}
 
 	// Calcule durée
-	durationMin := int(time.Since(time.Unix(startedAt, 0)).Minutes())
+	durationMin := int(activeSec / 60)
 
 	// Update session
 	query := `UPDATE sessions SET
         ended_at = ?,
         completed_count = ?,
-        duration_min = ?
-    WHERE id = ?`
+        duration_min = ?,
+        status = ?
+    WHERE id = ? AND ended_at IS NULL`
 
-	_, err = db.Exec(query, time.Now().Unix(), completedCount, durationMin, sessionID)
+	res, err := db.Exec(query, now, completedCount, durationMin, session.StatusCompleted, sessionID)
 	if err != nil {
 		return fmt.Errorf("update session end: %w", err)
 	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		status, err := GetSessionStatus(sessionID)
+		if err != nil {
+			return err
+		}
+		return &session.SessionClosedError{SessionID: sessionID, Status: status}
+	}
+
+	if err := closeOpenBreaks(sessionID, now); err != nil {
+		return err
+	}
 
-	// Update analytics (non-bloquant)
-	if err := updateAnalytics(completedCount, durationMin); err != nil {
-		fmt.Printf("⚠️ Update analytics failed: %v\n", err)
+	// Update analytics (non-bloquant, entraînement exclu)
+	if !practice {
+		if err := updateAnalytics(completedCount, durationMin); err != nil {
+			fmt.Printf("⚠️ Update analytics failed: %v\n", err)
+		}
 	}
 
+	notifyChange(TableSessions)
 	return nil
 }
 
@@ -143,6 +192,129 @@ func GetActiveSession() (int64, error) {
 	return sessionID, nil
 }
 
+// ============================================
+// REPRISE / INACTIVITÉ
+// ============================================
+
+// lastActivitySQL : Début, dernier affichage, dernière review ou dernière
+// pause de la session s
+const lastActivitySQL = `MAX(s.started_at, COALESCE((
+            SELECT MAX(MAX(COALESCE(se.shown_at, 0), COALESCE(se.reviewed_at, 0)))
+            FROM session_exercises se WHERE se.session_id = s.id), 0), COALESCE((
+            SELECT MAX(MAX(sb.started_at + sb.planned_sec, COALESCE(sb.ended_at, 0)))
+            FROM session_breaks sb WHERE sb.session_id = s.id), 0))`
+
+// GetSessionIdleTimeout : Inactivité avant abandon automatique (défaut si invalide)
+func GetSessionIdleTimeout() time.Duration {
+	timeout := time.Duration(GetSettingInt(SettingSessionIdleMin, int(session.DefaultIdleTimeout/time.Minute))) * time.Minute
+	if session.ValidateIdleTimeout(timeout) != nil {
+		return session.DefaultIdleTimeout
+	}
+	return timeout
+}
+
+// SetSessionIdleTimeout : Persiste le délai (en minutes)
+func SetSessionIdleTimeout(timeout time.Duration) error {
+	return SetSetting(SettingSessionIdleMin, strconv.Itoa(int(timeout/time.Minute)))
+}
+
+// GetOpenSessions : Sessions non terminées, plus récente d'abord
+func GetOpenSessions() ([]models.OpenSession, error) {
+	rows, err := db.Query(`SELECT s.id, COALESCE(s.mode, ''), s.ordering, s.started_at, ` + lastActivitySQL + `,
+            (SELECT COUNT(*) FROM session_exercises se WHERE se.session_id = s.id AND se.completed = 1),
+            (SELECT COUNT(*) FROM session_exercises se WHERE se.session_id = s.id)
+        FROM sessions s
+        WHERE s.ended_at IS NULL
+        ORDER BY s.started_at DESC, s.id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("query open sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []models.OpenSession
+	for rows.Next() {
+		var o models.OpenSession
+		var startedAt, lastActivity int64
+		if err := rows.Scan(&o.ID, &o.Mode, &o.Ordering, &startedAt, &lastActivity, &o.Completed, &o.Total); err != nil {
+			return nil, fmt.Errorf("scan open session: %w", err)
+		}
+		o.StartedAt = fromUnix(startedAt)
+		o.LastActivity = fromUnix(lastActivity)
+		sessions = append(sessions, o)
+	}
+	return sessions, rows.Err()
+}
+
+// GetSessionProgress : Énergie et template de la session, exercices complétés
+func GetSessionProgress(sessionID int64) (models.EnergyLevel, string, int, error) {
+	var energy, mode string
+	var completed int
+	err := db.QueryRow(`SELECT COALESCE(s.energy_level, ''), COALESCE(s.mode, ''),
+            (SELECT COUNT(*) FROM session_exercises se WHERE se.session_id = s.id AND se.completed = 1)
+        FROM sessions s WHERE s.id = ?`, sessionID).Scan(&energy, &mode, &completed)
+	if err == sql.ErrNoRows {
+		return 0, "", 0, &session.SessionNotFoundError{SessionID: sessionID}
+	}
+	if err != nil {
+		return 0, "", 0, fmt.Errorf("query session progress: %w", err)
+	}
+	return stringToEnergy(energy), mode, completed, nil
+}
+
+// IsPracticeSession : Session d'entraînement (SessionNotFoundError si absente)
+func IsPracticeSession(sessionID int64) (bool, error) {
+	var practice bool
+	err := db.QueryRow("SELECT practice FROM sessions WHERE id = ?", sessionID).Scan(&practice)
+	if err == sql.ErrNoRows {
+		return false, &session.SessionNotFoundError{SessionID: sessionID}
+	}
+	if err != nil {
+		return false, fmt.Errorf("query session practice: %w", err)
+	}
+	return practice, nil
+}
+
+// GetSessionStatus : Statut persisté (SessionNotFoundError si absente)
+func GetSessionStatus(sessionID int64) (session.Status, error) {
+	var status string
+	err := db.QueryRow("SELECT status FROM sessions WHERE id = ?", sessionID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", &session.SessionNotFoundError{SessionID: sessionID}
+	}
+	if err != nil {
+		return "", fmt.Errorf("query session status: %w", err)
+	}
+	return session.Status(status), nil
+}
+
+// AbandonSession : Ferme une session inactive à sa dernière activité
+// (temps actif jusqu'à cette activité, compteurs analytics inchangés)
+func AbandonSession(sessionID int64, lastActivity time.Time) error {
+	if _, err := GetSessionStatus(sessionID); err != nil {
+		return err
+	}
+	if err := closeFocusBlock(db, sessionID, lastActivity.Unix()); err != nil {
+		return err
+	}
+
+	_, err := db.Exec(`UPDATE sessions SET
+        ended_at = ?,
+        completed_count = (SELECT COUNT(*) FROM session_exercises WHERE session_id = ? AND completed = 1),
+        duration_min = active_sec / 60,
+        status = ?
+    WHERE id = ? AND ended_at IS NULL`,
+		lastActivity.Unix(), sessionID, session.StatusAbandoned, sessionID)
+	if err != nil {
+		return fmt.Errorf("abandon session %d: %w", sessionID, err)
+	}
+	if err := closeOpenBreaks(sessionID, lastActivity.Unix()); err != nil {
+		return err
+	}
+
+	notifyChange(TableSessions)
+	return nil
+}
+
 // GetNextSessionExercise : Prochain exercice non complété dans la session
 func GetNextSessionExercise(sessionID int64) (int, error) {
 	query := `SELECT exercise_id
@@ -170,14 +342,16 @@ func GetSessionResult(sessionID int64) (*models.SessionResult, error) {
 	query := `SELECT 
         completed_count, 
         duration_min, 
-        ended_at
+        ended_at,
+        practice
     FROM sessions WHERE id = ?`
 
 	var completedCount, durationMin int
 	var endedAt int64
+	var practice bool
 
 	err := db.QueryRow(query, sessionID).Scan(
-		&completedCount, &durationMin, &endedAt,
+		&completedCount, &durationMin, &endedAt, &practice,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("query session result: %w", err)
@@ -214,6 +388,7 @@ func GetSessionResult(sessionID int64) (*models.SessionResult, error) {
 		CompletedAt:    time.Unix(endedAt, 0),
 		Exercises:      exerciseIDs,
 		Qualities:      qualities,
+		Practice:       practice,
 	}, nil
 }
 
@@ -233,3 +408,14 @@ func energyToString(e models.EnergyLevel) string {
 		return "medium"
 	}
 }
+
+func stringToEnergy(s string) models.EnergyLevel {
+	switch s {
+	case "low":
+		return models.EnergyLow
+	case "high":
+		return models.EnergyHigh
+	default:
+		return models.EnergyMedium
+	}
+}
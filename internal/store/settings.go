@@ -0,0 +1,78 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"maestro/internal/domain/calendar"
+)
+
+// ============================================
+// SETTINGS (clé/valeur)
+// ============================================
+
+// Clés de réglages utilisateur
+const (
+	SettingTimezone        = "timezone"
+	SettingDayRolloverHour = "day_rollover_hour"
+	SettingDailyGoalKind   = "daily_goal_kind"
+	SettingDailyGoalTarget = "daily_goal_target"
+	SettingReportDir       = "report_dir"           // "" = rapports planifiés désactivés
+	SettingAtRiskRecall    = "at_risk_recall"       // Seuil de rappel surveillé (%)
+	SettingAtRiskDays      = "at_risk_days"         // Horizon d'alerte (jours)
+	SettingSessionIdleMin  = "session_idle_minutes" // Inactivité avant abandon automatique
+	SettingNewCardsPerDay  = "new_cards_per_day"    // Nouveaux introduits max par jour
+	SettingNewCardRatio    = "new_card_ratio"       // Part de nouveaux (%) en mode "Auto"
+	SettingEnergyAdapter   = "energy_adapter"       // Abaisse l'énergie en cours de session si la qualité chute
+)
+
+// GetSetting : Valeur d'un réglage (fallback si absent)
+func GetSetting(key, fallback string) string {
+	var value string
+	err := db.QueryRow("SELECT value FROM settings WHERE key = ?", key).Scan(&value)
+	if err == sql.ErrNoRows || err != nil {
+		return fallback
+	}
+	return value
+}
+
+// GetSettingInt : Réglage numérique (fallback si absent ou invalide)
+func GetSettingInt(key string, fallback int) int {
+	n, err := strconv.Atoi(GetSetting(key, strconv.Itoa(fallback)))
+	if err != nil {
+		return fallback
+	}
+	return n
+}
+
+// SetSetting : INSERT ou UPDATE d'un réglage
+func SetSetting(key, value string) error {
+	_, err := db.Exec(`
+        INSERT INTO settings (key, value, updated_at) VALUES (?, ?, ?)
+        ON CONFLICT(key) DO UPDATE SET
+            value = excluded.value,
+            updated_at = excluded.updated_at
+    `, key, value, nowUnix())
+	if err != nil {
+		return fmt.Errorf("set setting %s: %w", key, err)
+	}
+	notifyChange(TableSettings)
+	return nil
+}
+
+// LoadCalendar : Configure le jour utilisateur depuis les settings
+func LoadCalendar() error {
+	loc, err := calendar.LoadLocation(GetSetting(SettingTimezone, "Local"))
+	if err != nil {
+		return fmt.Errorf("load timezone: %w", err)
+	}
+
+	cal, err := calendar.New(loc, GetSettingInt(SettingDayRolloverHour, 0))
+	if err != nil {
+		return fmt.Errorf("load rollover hour: %w", err)
+	}
+
+	calendar.Configure(cal)
+	return nil
+}
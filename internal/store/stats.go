@@ -0,0 +1,198 @@
+package store
+
+import (
+	"fmt"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/models"
+)
+
+// ============================================
+// AGRÉGATS DASHBOARD (SQL, sans charger les exercices)
+// ============================================
+
+// reviewBucketSec : Granularité des agrégats horaires (tous les fuseaux
+// sont décalés d'un multiple de 15 min → un bucket n'est jamais à cheval
+// sur deux jours utilisateur)
+const reviewBucketSec = 900
+
+// GetExerciseTotals : Compteurs et moyennes sur les exercices actifs
+func GetExerciseTotals(today int) (models.ExerciseTotals, error) {
+	query := `SELECT
+        COUNT(*),
+        COALESCE(SUM(done = 0 AND COALESCE(completed_steps, '[]') NOT IN ('', '[]', 'null')), 0),
+        COALESCE(SUM(done = 0 AND COALESCE(completed_steps, '[]') IN ('', '[]', 'null')), 0),
+        COALESCE(SUM(done = 0 AND next_review_date > 0 AND next_review_date < ?), 0),
+        COALESCE(AVG(difficulty), 0),
+        COALESCE(AVG(CASE WHEN interval_days > 0 THEN interval_days END), 0),
+        COALESCE(AVG(CASE WHEN ease_factor > 0 THEN ease_factor END), 0),
+        COALESCE(SUM(done = 0 AND interval_days > 0 AND interval_days < 7), 0),
+        COALESCE(SUM(done = 0 AND ease_factor > 0 AND ease_factor < 2.3), 0),
+        COALESCE(MIN(CASE WHEN next_review_date > ? THEN next_review_date END), 0)
+    FROM exercises WHERE deleted = 0`
+
+	var t models.ExerciseTotals
+	var avgInterval float64
+	err := db.QueryRow(query, today, today).Scan(
+		&t.Total, &t.InProgress, &t.Todo, &t.Overdue,
+		&t.AvgDifficulty, &avgInterval, &t.AvgEase,
+		&t.ShortInterval, &t.LowEase, &t.NextReviewDay,
+	)
+	if err != nil {
+		return t, fmt.Errorf("query exercise totals: %w", err)
+	}
+	t.AvgInterval = int(avgInterval)
+	return t, nil
+}
+
+// GetReviewTotals : Reviews, réussites et lapses depuis since (+ sous-fenêtre 7j)
+func GetReviewTotals(since, weekStart int64) (models.ReviewTotals, error) {
+	query := `SELECT
+        COUNT(*),
+        COALESCE(SUM(quality >= 2), 0),
+        COALESCE(SUM(quality = 0), 0),
+        COALESCE(SUM(reviewed_at >= ?), 0)
+    FROM progress_log
+    WHERE reviewed_at >= ?`
+
+	var t models.ReviewTotals
+	err := db.QueryRow(query, weekStart, since).Scan(
+		&t.Reviews, &t.Successful, &t.Lapses, &t.LastWeek,
+	)
+	if err != nil {
+		return t, fmt.Errorf("query review totals: %w", err)
+	}
+	return t, nil
+}
+
+// GetDailyReviewCounts : Nombre de reviews par jour utilisateur (YYYYMMDD)
+// depuis since (Unix). Le regroupement fin se fait en SQL, le passage
+// au jour utilisateur de cal (fuseau, bascule) en Go.
+func GetDailyReviewCounts(cal calendar.Calendar, since int64) (map[int]int, error) {
+	rows, err := db.Query(`SELECT reviewed_at / ?, COUNT(*)
+        FROM progress_log
+        WHERE reviewed_at >= ?
+        GROUP BY reviewed_at / ?`, reviewBucketSec, since, reviewBucketSec)
+	if err != nil {
+		return nil, fmt.Errorf("query daily reviews: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var bucket int64
+		var count int
+		if err := rows.Scan(&bucket, &count); err != nil {
+			return nil, fmt.Errorf("scan daily reviews: %w", err)
+		}
+		counts[cal.DayKey(fromUnix(bucket*reviewBucketSec))] += count
+	}
+	return counts, rows.Err()
+}
+
+// GetSessionTotals : Sessions terminées avec au moins un exercice complété
+// dans session_exercises + minutes actives cumulées
+func GetSessionTotals() (count int, totalMin int, err error) {
+	err = db.QueryRow(`SELECT COUNT(*), COALESCE(SUM(s.duration_min), 0)
+        FROM sessions s
+        WHERE s.ended_at IS NOT NULL
+        AND EXISTS (SELECT 1 FROM session_exercises se WHERE se.session_id = s.id AND se.completed = 1)`).Scan(&count, &totalMin)
+	if err != nil {
+		return 0, 0, fmt.Errorf("query session totals: %w", err)
+	}
+	return count, totalMin, nil
+}
+
+// GetFailurePatterns : Exercices avec le plus d'échecs (quality 0-1)
+func GetFailurePatterns(minFails, limit int) ([]models.FailurePattern, error) {
+	rows, err := db.Query(`SELECT e.id, e.title, e.domain, COUNT(*) AS fails, e.ease_factor
+        FROM progress_log p
+        JOIN exercises e ON e.id = p.exercise_id
+        WHERE e.deleted = 0 AND p.quality <= 1
+        GROUP BY e.id
+        HAVING fails >= ?
+        ORDER BY fails DESC, e.ease_factor ASC
+        LIMIT ?`, minFails, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query failure patterns: %w", err)
+	}
+	defer rows.Close()
+
+	var patterns []models.FailurePattern
+	for rows.Next() {
+		var fp models.FailurePattern
+		if err := rows.Scan(&fp.ExerciseID, &fp.Title, &fp.Domain, &fp.FailCount, &fp.EaseFactor); err != nil {
+			return nil, fmt.Errorf("scan failure pattern: %w", err)
+		}
+		patterns = append(patterns, fp)
+	}
+	return patterns, rows.Err()
+}
+
+// GetRepetitionStats : Exercices les plus révisés (vrai nombre de reviews)
+func GetRepetitionStats(limit int) ([]models.RepetitionStat, error) {
+	rows, err := db.Query(`SELECT e.id, e.title, e.domain, COUNT(*) AS reviews
+        FROM progress_log p
+        JOIN exercises e ON e.id = p.exercise_id
+        WHERE e.deleted = 0
+        GROUP BY e.id
+        ORDER BY reviews DESC, e.id ASC
+        LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query repetition stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.RepetitionStat
+	for rows.Next() {
+		var rs models.RepetitionStat
+		if err := rows.Scan(&rs.ExerciseID, &rs.Title, &rs.Domain, &rs.ReviewCount); err != nil {
+			return nil, fmt.Errorf("scan repetition stat: %w", err)
+		}
+		stats = append(stats, rs)
+	}
+	return stats, rows.Err()
+}
+
+// GetWeakExercises : Exercices non maîtrisés avec ease faible
+func GetWeakExercises(limit int) ([]models.Exercise, error) {
+	query := `SELECT id, title, description, domain, difficulty,
+                     content, mnemonic, conceptual_visuals, tags,
+                     steps, completed_steps, done,
+                     last_reviewed_date, next_review_date,
+                     ease_factor, interval_days, repetitions,
+                     skipped_count, last_skipped_date,
+                     deleted, created_at, updated_at
+              FROM exercises
+              WHERE deleted = 0 AND done = 0 AND ease_factor > 0 AND ease_factor < 2.3
+              ORDER BY ease_factor ASC, id ASC
+              LIMIT ?`
+	return queryExercisesFull(query, limit)
+}
+
+// ============================================
+// PLANNER (présélection SQL par jour d'échéance)
+// ============================================
+
+// GetExercisesDueBetween : Exercices dont l'échéance est dans [from, to] (YYYYMMDD)
+func GetExercisesDueBetween(from, to int) ([]models.Exercise, error) {
+	query := `SELECT id, title, domain, difficulty, done,
+                     next_review_date, completed_steps, steps,
+                     last_reviewed_date, interval_days, tags
+              FROM exercises
+              WHERE deleted = 0 AND next_review_date BETWEEN ? AND ?
+              ORDER BY next_review_date ASC, id ASC`
+	return queryExercisesLight(query, from, to)
+}
+
+// GetExercisesDueAfter : Les N prochaines échéances strictement après day
+func GetExercisesDueAfter(day, limit int) ([]models.Exercise, error) {
+	query := `SELECT id, title, domain, difficulty, done,
+                     next_review_date, completed_steps, steps,
+                     last_reviewed_date, interval_days, tags
+              FROM exercises
+              WHERE deleted = 0 AND next_review_date > ?
+              ORDER BY next_review_date ASC, id ASC
+              LIMIT ?`
+	return queryExercisesLight(query, day, limit)
+}
@@ -0,0 +1,117 @@
+package store
+
+import (
+	"fmt"
+	"strconv"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/streak"
+)
+
+// ============================================
+// STREAK (analytics) + OBJECTIF QUOTIDIEN
+// ============================================
+
+// GetDailyGoal : Objectif quotidien (défaut : 1 review)
+func GetDailyGoal() streak.Goal {
+	def := streak.DefaultGoal()
+	goal := streak.Goal{
+		Kind:   streak.GoalKind(GetSetting(SettingDailyGoalKind, string(def.Kind))),
+		Target: GetSettingInt(SettingDailyGoalTarget, def.Target),
+	}
+	if goal.Validate() != nil {
+		return def
+	}
+	return goal
+}
+
+// SetDailyGoal : Persiste l'objectif quotidien
+func SetDailyGoal(goal streak.Goal) error {
+	if err := SetSetting(SettingDailyGoalKind, string(goal.Kind)); err != nil {
+		return err
+	}
+	return SetSetting(SettingDailyGoalTarget, strconv.Itoa(goal.Target))
+}
+
+// GetStreakState : Streak persisté
+func GetStreakState() (streak.State, error) {
+	var s streak.State
+	err := db.QueryRow(`SELECT
+        COALESCE(current_streak, 0), COALESCE(longest_streak, 0), last_goal_day
+    FROM analytics WHERE id = 1`).Scan(&s.Current, &s.Longest, &s.LastDay)
+	if err != nil {
+		return s, fmt.Errorf("query streak state: %w", err)
+	}
+	return s, nil
+}
+
+// SaveStreakState : Persiste le streak
+func SaveStreakState(s streak.State) error {
+	_, err := db.Exec(`UPDATE analytics SET
+        current_streak = ?, longest_streak = ?, last_goal_day = ?, updated_at = ?
+    WHERE id = 1`, s.Current, s.Longest, s.LastDay, nowUnix())
+	if err != nil {
+		return fmt.Errorf("save streak state: %w", err)
+	}
+	notifyChange(TableStreak)
+	return nil
+}
+
+// GetDayActivity : Reviews et minutes de session d'un jour utilisateur
+func GetDayActivity(cal calendar.Calendar, day int) (streak.Activity, error) {
+	from := cal.FromDayKey(day).Unix()
+	to := cal.FromDayKey(cal.AddDays(day, 1)).Unix()
+
+	var a streak.Activity
+	err := db.QueryRow(`SELECT
+        (SELECT COUNT(*) FROM progress_log WHERE reviewed_at >= ? AND reviewed_at < ?),
+        (SELECT COALESCE(SUM(duration_min), 0) FROM sessions WHERE ended_at >= ? AND ended_at < ?)`,
+		from, to, from, to,
+	).Scan(&a.Reviews, &a.Minutes)
+	if err != nil {
+		return a, fmt.Errorf("query day activity %d: %w", day, err)
+	}
+	return a, nil
+}
+
+// ============================================
+// GELS DE STREAK
+// ============================================
+
+// GetStreakFreezes : Jours gelés à partir de fromDay (inclus), triés
+func GetStreakFreezes(fromDay int) ([]int, error) {
+	rows, err := db.Query("SELECT day FROM streak_freezes WHERE day >= ? ORDER BY day", fromDay)
+	if err != nil {
+		return nil, fmt.Errorf("query streak freezes: %w", err)
+	}
+	defer rows.Close()
+
+	var days []int
+	for rows.Next() {
+		var day int
+		if err := rows.Scan(&day); err != nil {
+			return nil, fmt.Errorf("scan streak freeze: %w", err)
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
+
+// AddStreakFreeze : Planifie un jour de congé (idempotent)
+func AddStreakFreeze(day int) error {
+	_, err := db.Exec("INSERT OR IGNORE INTO streak_freezes (day, created_at) VALUES (?, ?)", day, nowUnix())
+	if err != nil {
+		return fmt.Errorf("add streak freeze %d: %w", day, err)
+	}
+	notifyChange(TableStreak)
+	return nil
+}
+
+// DeleteStreakFreeze : Annule un jour de congé
+func DeleteStreakFreeze(day int) error {
+	if _, err := db.Exec("DELETE FROM streak_freezes WHERE day = ?", day); err != nil {
+		return fmt.Errorf("delete streak freeze %d: %w", day, err)
+	}
+	notifyChange(TableStreak)
+	return nil
+}
@@ -0,0 +1,228 @@
+// internal/store/templates.go
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"maestro/internal/domain/session"
+)
+
+// ============================================
+// TEMPLATES DE SESSION
+// ============================================
+
+const templateColumns = `id, slug, name, description, energy_level, duration_min, max_exercises,
+    break_every, break_schedule, ordering, domains, min_difficulty, max_difficulty, new_ratio, builtin`
+
+// LoadTemplates : Charge session_templates dans le registre du domaine
+// (après chaque écriture, et au démarrage)
+func LoadTemplates() error {
+	templates, err := ListTemplates()
+	if err != nil {
+		return err
+	}
+	session.ConfigureTemplates(templates)
+	return nil
+}
+
+// ListTemplates : Templates intégrés d'abord (énergie croissante), puis par nom
+func ListTemplates() ([]session.Config, error) {
+	rows, err := db.Query(`SELECT ` + templateColumns + ` FROM session_templates
+        ORDER BY builtin DESC,
+            CASE energy_level WHEN 'low' THEN 1 WHEN 'medium' THEN 2 ELSE 3 END,
+            name COLLATE NOCASE`)
+	if err != nil {
+		return nil, fmt.Errorf("query templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []session.Config
+	for rows.Next() {
+		c, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, c)
+	}
+	return templates, rows.Err()
+}
+
+// GetTemplateByID : Template par id
+func GetTemplateByID(id int64) (session.Config, error) {
+	c, err := scanTemplate(db.QueryRow(`SELECT `+templateColumns+` FROM session_templates WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return c, &session.TemplateNotFoundError{Mode: fmt.Sprintf("#%d", id)}
+	}
+	return c, err
+}
+
+// CreateTemplate : Insère un template utilisateur (slug unique)
+func CreateTemplate(c session.Config) (int64, error) {
+	args, err := templateArgs(c)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := db.Exec(`INSERT INTO session_templates (slug, name, description, energy_level,
+            duration_min, max_exercises, break_every, break_schedule, ordering, domains,
+            min_difficulty, max_difficulty, new_ratio, builtin, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 0, ?)`, append(args, nowUnix())...)
+	if isUniqueViolation(err) {
+		return 0, &session.InvalidTemplateError{Field: "slug", Reason: "identifiant déjà utilisé"}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("insert template: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("template id: %w", err)
+	}
+	return id, templatesChanged()
+}
+
+// UpdateTemplate : Met à jour un template (le slug, référencé par
+// sessions.mode, ne change pas)
+func UpdateTemplate(id int64, c session.Config) error {
+	args, err := templateArgs(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := db.Exec(`UPDATE session_templates SET name = ?, description = ?, energy_level = ?,
+            duration_min = ?, max_exercises = ?, break_every = ?, break_schedule = ?, ordering = ?,
+            domains = ?, min_difficulty = ?, max_difficulty = ?, new_ratio = ?
+        WHERE id = ?`, append(args[1:], id)...)
+	if err != nil {
+		return fmt.Errorf("update template: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return &session.TemplateNotFoundError{Mode: c.Mode}
+	}
+	return templatesChanged()
+}
+
+// DeleteTemplate : Supprime un template utilisateur jamais utilisé
+// (intégrés et templates référencés par l'historique refusés)
+func DeleteTemplate(id int64) error {
+	c, err := GetTemplateByID(id)
+	if err != nil {
+		return err
+	}
+	if c.Builtin {
+		return &session.BuiltinTemplateError{Mode: c.Mode}
+	}
+
+	var used int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sessions WHERE mode = ?", c.Mode).Scan(&used); err != nil {
+		return fmt.Errorf("count template sessions: %w", err)
+	}
+	if used > 0 {
+		return &session.TemplateInUseError{Mode: c.Mode, Sessions: used}
+	}
+
+	if _, err := db.Exec("DELETE FROM session_templates WHERE id = ? AND builtin = 0", id); err != nil {
+		return fmt.Errorf("delete template: %w", err)
+	}
+	return templatesChanged()
+}
+
+// templatesChanged : Recharge le registre puis notifie
+func templatesChanged() error {
+	if err := LoadTemplates(); err != nil {
+		return fmt.Errorf("reload templates: %w", err)
+	}
+	notifyChange(TableTemplates)
+	return nil
+}
+
+// templateArgs : Colonnes modifiables, slug en tête
+func templateArgs(c session.Config) ([]any, error) {
+	minutes := make([]int, len(c.BreakSchedule))
+	for i, d := range c.BreakSchedule {
+		minutes[i] = int(d / time.Minute)
+	}
+	schedule, err := json.Marshal(minutes)
+	if err != nil {
+		return nil, fmt.Errorf("marshal break schedule: %w", err)
+	}
+
+	domains := c.Domains
+	if domains == nil {
+		domains = []string{}
+	}
+	domainsJSON, err := json.Marshal(domains)
+	if err != nil {
+		return nil, fmt.Errorf("marshal template domains: %w", err)
+	}
+
+	ordering := c.Ordering
+	if ordering == "" {
+		ordering = session.OrderPriority
+	}
+
+	return []any{c.Mode, c.Name, c.Description, energyToString(c.Level),
+		int(c.Duration / time.Minute), c.MaxExercises, c.BreakEvery, string(schedule),
+		string(ordering), string(domainsJSON), c.MinDifficulty, c.MaxDifficulty, c.NewRatio}, nil
+}
+
+// scanTemplate : Ligne session_templates → session.Config
+func scanTemplate(row interface{ Scan(...any) error }) (session.Config, error) {
+	var c session.Config
+	var energy, schedule, ordering, domains string
+	var durationMin int
+	err := row.Scan(&c.ID, &c.Mode, &c.Name, &c.Description, &energy, &durationMin, &c.MaxExercises,
+		&c.BreakEvery, &schedule, &ordering, &domains, &c.MinDifficulty, &c.MaxDifficulty,
+		&c.NewRatio, &c.Builtin)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return c, err
+		}
+		return c, fmt.Errorf("scan template: %w", err)
+	}
+
+	c.Level = stringToEnergy(energy)
+	c.Duration = time.Duration(durationMin) * time.Minute
+	c.Ordering = session.Ordering(ordering)
+
+	var minutes []int
+	if err := json.Unmarshal([]byte(schedule), &minutes); err != nil {
+		fmt.Printf("⚠️ Planning de pauses illisible (%s): %v\n", c.Mode, err)
+	}
+	for _, m := range minutes {
+		c.BreakSchedule = append(c.BreakSchedule, time.Duration(m)*time.Minute)
+	}
+	if err := json.Unmarshal([]byte(domains), &c.Domains); err != nil {
+		fmt.Printf("⚠️ Domaines du template illisibles (%s): %v\n", c.Mode, err)
+	}
+	return c, nil
+}
+
+// isUniqueViolation : Contrainte UNIQUE violée (message du driver SQLite)
+func isUniqueViolation(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+// CountSessionsByMode : Nombre de sessions par template
+func CountSessionsByMode() (map[string]int, error) {
+	rows, err := db.Query("SELECT mode, COUNT(*) FROM sessions WHERE mode IS NOT NULL GROUP BY mode")
+	if err != nil {
+		return nil, fmt.Errorf("query sessions by mode: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var mode string
+		var n int
+		if err := rows.Scan(&mode, &n); err != nil {
+			return nil, fmt.Errorf("scan sessions by mode: %w", err)
+		}
+		counts[mode] = n
+	}
+	return counts, rows.Err()
+}
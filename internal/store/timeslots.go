@@ -0,0 +1,109 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/timeslot"
+	"maestro/internal/models"
+)
+
+// ============================================
+// ANALYSE HORAIRE (best_time_slot, difficulty_success_rate)
+// ============================================
+
+// TimeTallies : Compteurs bruts par heure locale, jour de semaine et difficulté
+type TimeTallies struct {
+	Hours        [24]timeslot.Tally
+	Weekdays     [7]timeslot.Tally // Indexé par time.Weekday
+	ByDifficulty map[int]timeslot.Tally
+}
+
+// GetTimeTallies : Agrège progress_log (réussite, temps de réponse mesuré)
+// depuis since, dans le fuseau et le jour utilisateur.
+func GetTimeTallies(cal calendar.Calendar, since int64) (TimeTallies, error) {
+	t := TimeTallies{ByDifficulty: make(map[int]timeslot.Tally)}
+
+	// Buckets de 15 min : jamais à cheval sur deux heures locales
+	rows, err := db.Query(`SELECT p.reviewed_at / ?, e.difficulty, COUNT(*), SUM(p.quality >= 2),
+            COALESCE(SUM(p.duration_sec), 0), COUNT(p.duration_sec)
+        FROM progress_log p
+        JOIN exercises e ON e.id = p.exercise_id
+        WHERE p.reviewed_at >= ?
+        GROUP BY 1, 2`, reviewBucketSec, since)
+	if err != nil {
+		return t, fmt.Errorf("query time tallies: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bucket int64
+		var difficulty int
+		var tally timeslot.Tally
+		err := rows.Scan(&bucket, &difficulty, &tally.Reviews, &tally.Successes,
+			&tally.ResponseSec, &tally.Responses)
+		if err != nil {
+			return t, fmt.Errorf("scan time tally: %w", err)
+		}
+
+		at := cal.In(fromUnix(bucket * reviewBucketSec))
+		weekday := cal.FromDayKey(cal.DayKey(at)).Weekday()
+		t.Hours[at.Hour()] = t.Hours[at.Hour()].Add(tally)
+		t.Weekdays[weekday] = t.Weekdays[weekday].Add(tally)
+		t.ByDifficulty[difficulty] = t.ByDifficulty[difficulty].Add(tally)
+	}
+	return t, rows.Err()
+}
+
+// SaveTimePerformance : Écrit best_time_slot, difficulty_success_rate et le détail
+func SaveTimePerformance(perf models.TimePerformance) error {
+	rates, err := json.Marshal(perf.DifficultyRates)
+	if err != nil {
+		return fmt.Errorf("marshal difficulty rates: %w", err)
+	}
+	detail, err := json.Marshal(perf)
+	if err != nil {
+		return fmt.Errorf("marshal time performance: %w", err)
+	}
+
+	_, err = db.Exec(`UPDATE analytics SET
+        best_time_slot = ?, difficulty_success_rate = ?, time_slot_stats = ?, updated_at = ?
+    WHERE id = 1`, perf.BestSlot, string(rates), string(detail), nowUnix())
+	if err != nil {
+		return fmt.Errorf("save time performance: %w", err)
+	}
+	notifyChange(TableTimeSlots)
+	return nil
+}
+
+// GetTimePerformance : Dernier résultat du job (zéro si jamais calculé)
+func GetTimePerformance() (models.TimePerformance, error) {
+	var perf models.TimePerformance
+	var detail sql.NullString
+	if err := db.QueryRow("SELECT time_slot_stats FROM analytics WHERE id = 1").Scan(&detail); err != nil {
+		return perf, fmt.Errorf("query time performance: %w", err)
+	}
+	if !detail.Valid || detail.String == "" {
+		return perf, nil
+	}
+	if err := json.Unmarshal([]byte(detail.String), &perf); err != nil {
+		return perf, fmt.Errorf("decode time performance: %w", err)
+	}
+	return perf, nil
+}
+
+// GetDifficultySuccessRates : difficulty_success_rate (valeurs par défaut si illisible)
+func GetDifficultySuccessRates() map[int]float64 {
+	var raw string
+	if err := db.QueryRow("SELECT difficulty_success_rate FROM analytics WHERE id = 1").Scan(&raw); err != nil {
+		return timeslot.DefaultDifficultySuccessRate
+	}
+
+	rates := make(map[int]float64)
+	if err := json.Unmarshal([]byte(raw), &rates); err != nil || len(rates) == 0 {
+		return timeslot.DefaultDifficultySuccessRate
+	}
+	return rates
+}
@@ -0,0 +1,81 @@
+package store
+
+import (
+	"fmt"
+
+	"maestro/internal/models"
+)
+
+// ============================================
+// TEMPS PAR EXERCICE (progress_log.duration_sec)
+// ============================================
+
+// GetAnswerTimeTotals : Reviews chronométrées et temps moyen global (secondes)
+func GetAnswerTimeTotals() (timed int, avgSec int, err error) {
+	err = db.QueryRow(`SELECT COUNT(duration_sec), COALESCE(CAST(AVG(duration_sec) AS INTEGER), 0)
+        FROM progress_log WHERE duration_sec IS NOT NULL`).Scan(&timed, &avgSec)
+	if err != nil {
+		return 0, 0, fmt.Errorf("query answer time totals: %w", err)
+	}
+	return timed, avgSec, nil
+}
+
+// GetExerciseTimings : Temps de réponse cumulé par exercice actif chronométré
+// (un seul parcours de l'index couvrant ; classements et domaines calculés côté service)
+func GetExerciseTimings() ([]models.ExerciseTiming, error) {
+	rows, err := db.Query(`SELECT e.id, e.title, e.domain, t.total, t.samples
+        FROM (
+            SELECT exercise_id, SUM(duration_sec) AS total, COUNT(duration_sec) AS samples
+            FROM progress_log
+            WHERE duration_sec IS NOT NULL
+            GROUP BY exercise_id
+        ) t
+        JOIN exercises e ON e.id = t.exercise_id
+        WHERE e.deleted = 0`)
+	if err != nil {
+		return nil, fmt.Errorf("query exercise timings: %w", err)
+	}
+	defer rows.Close()
+
+	var timings []models.ExerciseTiming
+	for rows.Next() {
+		var t models.ExerciseTiming
+		if err := rows.Scan(&t.ID, &t.Title, &t.Domain, &t.TotalSec, &t.Samples); err != nil {
+			return nil, fmt.Errorf("scan exercise timing: %w", err)
+		}
+		t.AvgSec = t.TotalSec / t.Samples
+		timings = append(timings, t)
+	}
+	return timings, rows.Err()
+}
+
+// GetExerciseAnswerTimes : Temps moyen (secondes) des exercices donnés ayant été chronométrés
+func GetExerciseAnswerTimes(ids []int) (map[int]int, error) {
+	times := make(map[int]int, len(ids))
+	if len(ids) == 0 {
+		return times, nil
+	}
+
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := db.Query(`SELECT exercise_id, CAST(AVG(duration_sec) AS INTEGER)
+        FROM progress_log
+        WHERE duration_sec IS NOT NULL AND exercise_id IN (`+placeholders(len(ids))+`)
+        GROUP BY exercise_id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query exercise answer times: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, avg int
+		if err := rows.Scan(&id, &avg); err != nil {
+			return nil, fmt.Errorf("scan exercise answer time: %w", err)
+		}
+		times[id] = avg
+	}
+	return times, rows.Err()
+}
@@ -127,3 +127,25 @@ func GetSortOptionsOrdered() []struct {
 		{"domain", "Domaine"},
 	}
 }
+
+// ────────────────────────────────────────────────────
+// TIMEZONES - Suggestions pour /settings
+// ────────────────────────────────────────────────────
+
+// GetTimezoneSuggestions - Fuseaux IANA courants (saisie libre possible)
+func GetTimezoneSuggestions() []string {
+	return []string{
+		"Local",
+		"UTC",
+		"Europe/Paris",
+		"Europe/London",
+		"Europe/Berlin",
+		"America/New_York",
+		"America/Chicago",
+		"America/Los_Angeles",
+		"America/Montreal",
+		"Asia/Tokyo",
+		"Asia/Kolkata",
+		"Australia/Sydney",
+	}
+}
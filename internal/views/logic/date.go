@@ -3,6 +3,8 @@ package logic
 import (
 	"fmt"
 	"time"
+
+	"maestro/internal/domain/calendar"
 )
 
 func FormatDuration(d time.Duration) string {
@@ -13,3 +15,39 @@ func FormatDuration(d time.Duration) string {
 	}
 	return fmt.Sprintf("%d s", seconds)
 }
+
+// FormatRelativeDay : Jour utilisateur relatif ("aujourd'hui", "demain", "dans 3j")
+func FormatRelativeDay(t time.Time) string {
+	cal := calendar.Current()
+	days := 0
+	for d := cal.Today(); d < cal.DayKey(t); d = cal.AddDays(d, 1) {
+		days++
+	}
+	switch days {
+	case 0:
+		return "aujourd'hui"
+	case 1:
+		return "demain"
+	default:
+		return fmt.Sprintf("dans %dj", days)
+	}
+}
+
+// FormatElapsed : Temps écoulé depuis t ("à l'instant", "il y a 12 min", "il y a 3 h")
+func FormatElapsed(t time.Time) string {
+	d := calendar.Current().Now().Sub(t)
+	switch {
+	case d < time.Minute:
+		return "à l'instant"
+	case d < time.Hour:
+		return fmt.Sprintf("il y a %d min", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("il y a %d h", int(d.Hours()))
+	}
+}
+
+// FormatCountdown : Compte à rebours "mm:ss"
+func FormatCountdown(d time.Duration) string {
+	sec := int(d.Round(time.Second).Seconds())
+	return fmt.Sprintf("%02d:%02d", sec/60, sec%60)
+}
@@ -0,0 +1,15 @@
+package logic
+
+import "maestro/internal/models"
+
+// EnergyLabel : Libellé d'un niveau d'énergie (cartes du builder)
+func EnergyLabel(level models.EnergyLevel) string {
+	switch level {
+	case models.EnergyLow:
+		return "Faible"
+	case models.EnergyHigh:
+		return "Élevé"
+	default:
+		return "Moyen"
+	}
+}
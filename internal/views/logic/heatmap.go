@@ -3,6 +3,8 @@ package logic
 
 import (
 	"time"
+
+	"maestro/internal/domain/calendar"
 )
 
 // HeatmapDay - Single day data
@@ -13,9 +15,10 @@ type HeatmapDay struct {
 	IsToday   bool
 }
 
-// GenerateHeatmapDays - Generate heatmap data for N weeks
+// GenerateHeatmapDays - Generate heatmap data for N weeks (user days)
 func GenerateHeatmapDays(reviewCounts map[string]int, weeks int) []HeatmapDay {
-	now := time.Now()
+	cal := calendar.Current()
+	now := cal.TodayStart()
 	days := []HeatmapDay{}
 
 	// Start from N weeks ago
@@ -36,7 +39,7 @@ func GenerateHeatmapDays(reviewCounts map[string]int, weeks int) []HeatmapDay {
 			Date:      dateKey, // ✅ Store as string
 			Count:     reviewCounts[dateKey],
 			DayOfWeek: int(date.Weekday()),
-			IsToday:   isSameDay(date, now),
+			IsToday:   cal.SameDay(date, now),
 		}
 
 		days = append(days, day)
@@ -44,9 +47,3 @@ func GenerateHeatmapDays(reviewCounts map[string]int, weeks int) []HeatmapDay {
 
 	return days
 }
-
-func isSameDay(d1, d2 time.Time) bool {
-	return d1.Year() == d2.Year() &&
-		d1.Month() == d2.Month() &&
-		d1.Day() == d2.Day()
-}
@@ -0,0 +1,55 @@
+package logic
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"maestro/internal/models"
+)
+
+// HistoryPageURL : Lien vers une page de /sessions en conservant les filtres
+func HistoryPageURL(filter models.SessionHistoryFilter, page int) string {
+	q := url.Values{}
+	if filter.Mode != "" {
+		q.Set("mode", filter.Mode)
+	}
+	if !filter.From.IsZero() {
+		q.Set("from", FormatDayInput(filter.From))
+	}
+	if !filter.To.IsZero() {
+		q.Set("to", FormatDayInput(filter.To))
+	}
+	if page > 1 {
+		q.Set("page", strconv.Itoa(page))
+	}
+	if len(q) == 0 {
+		return "/sessions"
+	}
+	return "/sessions?" + q.Encode()
+}
+
+// QualityLabel : Libellé d'une qualité de review (mêmes libellés que ReviewPanel)
+func QualityLabel(quality int) string {
+	switch quality {
+	case 0:
+		return "❌ Échec"
+	case 1:
+		return "😓 Difficile"
+	case 2:
+		return "😊 Bien"
+	case 3:
+		return "🚀 Facile"
+	default:
+		return fmt.Sprintf("? (%d)", quality)
+	}
+}
+
+// FormatDayInput : Valeur d'un <input type="date"> ("" si zéro)
+func FormatDayInput(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
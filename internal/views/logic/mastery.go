@@ -0,0 +1,45 @@
+package logic
+
+import (
+	"fmt"
+
+	"maestro/internal/domain/calendar"
+	"maestro/internal/domain/srs"
+	"maestro/internal/models"
+)
+
+// ExerciseMastery : État de maîtrise courant d'un exercice
+func ExerciseMastery(ex models.Exercise) srs.MasteryState {
+	return srs.Mastery(ex.LastReviewed, ex.IntervalDays, calendar.Current().Now())
+}
+
+// MasteryIcon : Icône du badge d'état
+func MasteryIcon(state srs.MasteryState) string {
+	switch state {
+	case srs.StateLearning:
+		return "⊙"
+	case srs.StateYoung:
+		return "◐"
+	case srs.StateMature:
+		return "●"
+	case srs.StateMastered:
+		return "✓"
+	default:
+		return "○"
+	}
+}
+
+// RecallLabel : Probabilité de rappel actuelle ("Rappel 87%"), vide si jamais révisé
+func RecallLabel(ex models.Exercise) string {
+	if ex.LastReviewed == nil {
+		return ""
+	}
+	recall := srs.Retrievability(ex.LastReviewed, ex.IntervalDays, calendar.Current().Now())
+	return fmt.Sprintf("Rappel %d%%", int(recall*100+0.5))
+}
+
+// MasteryLegend : Rappel des seuils (dashboard)
+func MasteryLegend() string {
+	return fmt.Sprintf("Mastered = intervalle ≥ %dj et rappel prédit ≥ %d%%. Sous %d%% de rappel, un exercice repasse en Learning.",
+		srs.MasteredInterval, int(srs.TargetRecall*100), int(srs.ForgottenRecall*100))
+}
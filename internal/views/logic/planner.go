@@ -1,6 +1,10 @@
 package logic
 
-import "time"
+import (
+	"time"
+
+	"maestro/internal/domain/calendar"
+)
 
 func GetEmptyDaysBefore(firstDay time.Time) int {
 	weekday := int(firstDay.Weekday())
@@ -40,16 +44,15 @@ func GetMonthDayClasses(year int, month time.Month, day int, count int) string {
 	return base
 }
 
-// isToday - utilisé par WeekDayCard
+// isToday - utilisé par WeekDayCard (jour utilisateur)
 func isToday(date time.Time) bool {
-	now := time.Now()
-	return date.Year() == now.Year() && date.YearDay() == now.YearDay()
+	cal := calendar.Current()
+	return cal.DayKey(date) == cal.Today()
 }
 
 // isDayToday - Vérifie si c'est aujourd'hui (pour MonthDayCell)
 func isDayToday(year int, month time.Month, day int) bool {
-	now := time.Now()
-	return year == now.Year() && month == now.Month() && day == now.Day()
+	return year*10000+int(month)*100+day == calendar.Current().Today()
 }
 
 // truncate - utilisé pour les titres des exos
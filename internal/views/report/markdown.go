@@ -0,0 +1,107 @@
+// internal/views/report/markdown.go
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"maestro/internal/models"
+)
+
+// ============================================
+// RAPPORT MARKDOWN (même contenu que la page HTML)
+// ============================================
+
+// Markdown : Rendu texte du rapport (notes, dépôt git, email)
+func Markdown(r models.ProgressReport) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", Title(r))
+	fmt.Fprintf(&b, "_%s — généré le %s_\n\n", PeriodRange(r), r.GeneratedAt.Format("2006-01-02 15:04"))
+
+	b.WriteString("## Activité\n\n")
+	b.WriteString("| Indicateur | Valeur | Période précédente |\n|---|---|---|\n")
+	fmt.Fprintf(&b, "| Révisions | %d | %d (%s) |\n", r.Reviews, r.PrevReviews, Delta(r.Reviews, r.PrevReviews))
+	fmt.Fprintf(&b, "| Rétention | %d%% | %d%% |\n", r.RetentionRate, r.PrevRetentionRate)
+	fmt.Fprintf(&b, "| Oublis | %d | |\n", r.Lapses)
+	fmt.Fprintf(&b, "| Jours actifs | %d / %d | |\n", r.ActiveDays, r.PeriodDays)
+	fmt.Fprintf(&b, "| Temps en session | %s | |\n", FormatDuration(r.TimeSpent))
+	fmt.Fprintf(&b, "| Streak | %d j (record %d j) | |\n\n", r.CurrentStreak, r.LongestStreak)
+
+	fmt.Fprintf(&b, "## Nouveaux exercices appris (%d)\n\n", r.NewLearnedCount)
+	if r.NewLearnedCount == 0 {
+		b.WriteString("Aucun nouvel exercice sur la période.\n\n")
+	} else {
+		for _, ex := range r.NewLearned {
+			fmt.Fprintf(&b, "- %s _(%s)_\n", ex.Title, ex.Domain)
+		}
+		if more := r.NewLearnedCount - len(r.NewLearned); more > 0 {
+			fmt.Fprintf(&b, "- … et %d autres\n", more)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Domaines les plus faibles\n\n")
+	if len(r.WeakestDomains) == 0 {
+		b.WriteString("Pas encore de domaine analysé.\n\n")
+	} else {
+		for _, d := range r.WeakestDomains {
+			fmt.Fprintf(&b, "- **%s** : %d%% (%d/%d maîtrisés)\n", d.Name, d.StrengthPercent, d.MasteredCount, d.TotalCount)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Exercices en échec répété\n\n")
+	if len(r.Leeches) == 0 {
+		b.WriteString("Aucun exercice en échec répété.\n\n")
+	} else {
+		for _, l := range r.Leeches {
+			fmt.Fprintf(&b, "- %s _(%s)_ : %d échecs, ease %.2f\n", l.Title, l.Domain, l.FailCount, l.EaseFactor)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Charge à venir\n\n")
+	fmt.Fprintf(&b, "En retard : **%d** — %d prévus sur %d jours.\n\n", r.OverdueCount, r.UpcomingTotal, len(r.Upcoming))
+	b.WriteString("| Jour | Révisions |\n|---|---|\n")
+	for _, d := range r.Upcoming {
+		fmt.Fprintf(&b, "| %s | %d |\n", d.Date.Format("Mon 02/01"), d.Count)
+	}
+
+	return b.String()
+}
+
+// ============================================
+// HELPERS (partagés avec ProgressReport.templ)
+// ============================================
+
+// Title : "Rapport hebdomadaire 2026-W15"
+func Title(r models.ProgressReport) string {
+	if r.Kind == "monthly" {
+		return "Rapport mensuel " + r.Label
+	}
+	return "Rapport hebdomadaire " + r.Label
+}
+
+// PeriodRange : "2026-04-06 → 2026-04-12" (To est exclu)
+func PeriodRange(r models.ProgressReport) string {
+	return r.From.Format("2006-01-02") + " → " + r.To.AddDate(0, 0, -1).Format("2006-01-02")
+}
+
+// Delta : Variation relative à la période précédente ("+12%", "n/a")
+func Delta(cur, prev int) string {
+	if prev == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+d%%", (cur-prev)*100/prev)
+}
+
+// FormatDuration : 95m → "1h35"
+func FormatDuration(d time.Duration) string {
+	minutes := int(d.Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%02d", minutes/60, minutes%60)
+}
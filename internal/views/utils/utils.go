@@ -71,6 +71,13 @@ func GetStepsValue(ex *models.Exercise) string {
 	return strings.Join(ex.Steps, "\n")
 }
 
+func GetTagsValue(ex *models.Exercise) string {
+	if ex == nil || len(ex.Tags) == 0 {
+		return ""
+	}
+	return strings.Join(ex.Tags, ", ")
+}
+
 func GetVisualsValue(ex *models.Exercise) string {
 	if ex == nil || len(ex.ConceptualVisuals) == 0 {
 		return ""